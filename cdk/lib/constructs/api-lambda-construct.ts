@@ -109,6 +109,8 @@ export class ApiLambdaConstruct extends Construct {
     props.dataBucket.grantRead(this.lambda, 'processed/schedules/*');
     props.dataBucket.grantRead(this.lambda, 'processed/metadata/*');
     props.dataBucket.grantRead(this.lambda, 'processed/feed/*');
+    props.dataBucket.grantRead(this.lambda, 'processed/basedata.db');
+    props.dataBucket.grantRead(this.lambda, 'processed/version.txt');
     props.dataBucket.grantRead(this.lambda, 'raw/ourairports_data/airports.csv');
     props.dataBucket.grantRead(this.lambda, 'raw/ourairports_data/countries.csv');
     props.dataBucket.grantRead(this.lambda, 'raw/ourairports_data/regions.csv');
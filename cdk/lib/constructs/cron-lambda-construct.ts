@@ -65,6 +65,8 @@ export class CronLambdaConstruct extends Construct {
       props.dataBucket.grantRead(fn, 'raw/LH_Public_Data/flightschedules/*');
       props.dataBucket.grantWrite(fn, 'raw/LH_Public_Data/*');
       props.dataBucket.grantWrite(fn, 'raw/ourairports_data/*');
+      props.dataBucket.grantRead(fn, 'processed/schedules/*');
+      props.dataBucket.grantReadWrite(fn, 'tmp/seatmap/*');
 
       fn.addToRolePolicy(new PolicyStatement({
         effect: Effect.ALLOW,
@@ -77,6 +79,7 @@ export class CronLambdaConstruct extends Construct {
 
       // region update lambda layer
       props.dataBucket.grantRead(fn, 'processed/basedata.db');
+      props.dataBucket.grantWrite(fn, 'processed/version.txt');
       props.parquetBucket.grantRead(fn, 'variants.parquet');
       props.parquetBucket.grantRead(fn, 'report.parquet');
       props.parquetBucket.grantRead(fn, 'connections.parquet');
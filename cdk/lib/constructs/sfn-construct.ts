@@ -189,6 +189,7 @@ export class SfnConstruct extends Construct {
                     'parquetPrefix': JsonPath.format('{}/', JsonPath.stringAt('$.time')),
                     'layerName': BASE_DATA_LAYER_NAME,
                     'ssmParameterName': BASE_DATA_LAYER_SSM_PARAMETER_NAME,
+                    'versionKey': 'processed/version.txt',
                   },
                 }),
                 payloadResponseOnly: true,
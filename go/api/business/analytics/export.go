@@ -0,0 +1,292 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GeoJSONFeatureCollection is a GeoJSON FeatureCollection as defined in RFC 7946
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type       string           `json:"type"`
+	Geometry   *GeoJSONGeometry `json:"geometry"`
+	Properties map[string]any   `json:"properties"`
+}
+
+type GeoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// WriteGeoJSON writes the network as a GeoJSON FeatureCollection with one Point per airport and one LineString per route.
+// Features of unknown airports have no geometry.
+func WriteGeoJSON(w io.Writer, n Network) error {
+	nodes := n.nodesByIataCode()
+	fc := GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]GeoJSONFeature, 0, len(n.Nodes)+len(n.Edges)),
+	}
+
+	for _, node := range n.Nodes {
+		var geometry *GeoJSONGeometry
+		if node.Located {
+			geometry = &GeoJSONGeometry{
+				Type:        "Point",
+				Coordinates: node.coordinates(),
+			}
+		}
+
+		fc.Features = append(fc.Features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: geometry,
+			Properties: map[string]any{
+				"type":        "airport",
+				"iataCode":    node.IataCode,
+				"icaoCode":    node.IcaoCode,
+				"name":        node.Name,
+				"countryCode": node.CountryCode,
+			},
+		})
+	}
+
+	for _, edge := range n.Edges {
+		var geometry *GeoJSONGeometry
+		if dep, arr := nodes[edge.DepartureAirportIataCode], nodes[edge.ArrivalAirportIataCode]; dep.Located && arr.Located {
+			geometry = &GeoJSONGeometry{
+				Type:        "LineString",
+				Coordinates: [][2]float64{dep.coordinates(), arr.coordinates()},
+			}
+		}
+
+		fc.Features = append(fc.Features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: geometry,
+			Properties: map[string]any{
+				"type":               "route",
+				"departureAirportId": edge.DepartureAirportIataCode,
+				"arrivalAirportId":   edge.ArrivalAirportIataCode,
+				"flights":            edge.Flights,
+				"weeklyFrequency":    edge.WeeklyFrequency,
+				"aircraft":           edge.aircraftIataCodes(),
+				"seats":              edge.Seats,
+			},
+		})
+	}
+
+	return json.NewEncoder(w).Encode(fc)
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	Id          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Id     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the network as a directed GraphML graph with the airports as nodes and the routes as edges
+func WriteGraphML(w io.Writer, n Network) error {
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{Id: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{Id: "icaoCode", For: "node", AttrName: "icaoCode", AttrType: "string"},
+			{Id: "countryCode", For: "node", AttrName: "countryCode", AttrType: "string"},
+			{Id: "lat", For: "node", AttrName: "lat", AttrType: "double"},
+			{Id: "lng", For: "node", AttrName: "lng", AttrType: "double"},
+			{Id: "flights", For: "edge", AttrName: "flights", AttrType: "int"},
+			{Id: "weeklyFrequency", For: "edge", AttrName: "weeklyFrequency", AttrType: "double"},
+			{Id: "aircraft", For: "edge", AttrName: "aircraft", AttrType: "string"},
+			{Id: "seatsFirst", For: "edge", AttrName: "seatsFirst", AttrType: "int"},
+			{Id: "seatsBusiness", For: "edge", AttrName: "seatsBusiness", AttrType: "int"},
+			{Id: "seatsPremium", For: "edge", AttrName: "seatsPremium", AttrType: "int"},
+			{Id: "seatsEconomy", For: "edge", AttrName: "seatsEconomy", AttrType: "int"},
+			{Id: "seatsTotal", For: "edge", AttrName: "seatsTotal", AttrType: "int"},
+		},
+		Graph: graphMLGraph{
+			Id:          "network",
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, 0, len(n.Nodes)),
+			Edges:       make([]graphMLEdge, 0, len(n.Edges)),
+		},
+	}
+
+	for _, node := range n.Nodes {
+		gn := graphMLNode{
+			Id: node.IataCode,
+			Data: []graphMLData{
+				{Key: "name", Value: node.Name},
+				{Key: "icaoCode", Value: node.IcaoCode},
+				{Key: "countryCode", Value: node.CountryCode},
+			},
+		}
+
+		if node.Located {
+			gn.Data = append(
+				gn.Data,
+				graphMLData{Key: "lat", Value: formatFloat(node.Lat)},
+				graphMLData{Key: "lng", Value: formatFloat(node.Lng)},
+			)
+		}
+
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gn)
+	}
+
+	for _, edge := range n.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Id:     edge.DepartureAirportIataCode + "-" + edge.ArrivalAirportIataCode,
+			Source: edge.DepartureAirportIataCode,
+			Target: edge.ArrivalAirportIataCode,
+			Data: []graphMLData{
+				{Key: "flights", Value: strconv.Itoa(edge.Flights)},
+				{Key: "weeklyFrequency", Value: formatFloat(edge.WeeklyFrequency)},
+				{Key: "aircraft", Value: strings.Join(edge.aircraftIataCodes(), " ")},
+				{Key: "seatsFirst", Value: strconv.Itoa(edge.Seats.First)},
+				{Key: "seatsBusiness", Value: strconv.Itoa(edge.Seats.Business)},
+				{Key: "seatsPremium", Value: strconv.Itoa(edge.Seats.Premium)},
+				{Key: "seatsEconomy", Value: strconv.Itoa(edge.Seats.Economy)},
+				{Key: "seatsTotal", Value: strconv.Itoa(edge.Seats.Total)},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// WriteCSV writes one row per route including the coordinates of both airports; coordinates of unknown airports are left empty
+func WriteCSV(w io.Writer, n Network) error {
+	nodes := n.nodesByIataCode()
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{
+		"departure_airport_id",
+		"departure_lat",
+		"departure_lng",
+		"arrival_airport_id",
+		"arrival_lat",
+		"arrival_lng",
+		"flights",
+		"weekly_frequency",
+		"aircraft",
+		"seats_first",
+		"seats_business",
+		"seats_premium",
+		"seats_economy",
+		"seats_total",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, edge := range n.Edges {
+		depLat, depLng := nodes[edge.DepartureAirportIataCode].formatCoordinates()
+		arrLat, arrLng := nodes[edge.ArrivalAirportIataCode].formatCoordinates()
+
+		err = cw.Write([]string{
+			edge.DepartureAirportIataCode,
+			depLat,
+			depLng,
+			edge.ArrivalAirportIataCode,
+			arrLat,
+			arrLng,
+			strconv.Itoa(edge.Flights),
+			formatFloat(edge.WeeklyFrequency),
+			strings.Join(edge.aircraftIataCodes(), " "),
+			strconv.Itoa(edge.Seats.First),
+			strconv.Itoa(edge.Seats.Business),
+			strconv.Itoa(edge.Seats.Premium),
+			strconv.Itoa(edge.Seats.Economy),
+			strconv.Itoa(edge.Seats.Total),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (n Network) nodesByIataCode() map[string]NetworkNode {
+	nodes := make(map[string]NetworkNode, len(n.Nodes))
+	for _, node := range n.Nodes {
+		nodes[node.IataCode] = node
+	}
+
+	return nodes
+}
+
+// coordinates returns the GeoJSON position (longitude first)
+func (n NetworkNode) coordinates() [2]float64 {
+	return [2]float64{n.Lng, n.Lat}
+}
+
+func (n NetworkNode) formatCoordinates() (string, string) {
+	if !n.Located {
+		return "", ""
+	}
+
+	return formatFloat(n.Lat), formatFloat(n.Lng)
+}
+
+func (e NetworkEdge) aircraftIataCodes() []string {
+	codes := make([]string, 0, len(e.Aircraft))
+	for _, a := range e.Aircraft {
+		codes = append(codes, a.AircraftIataCode)
+	}
+
+	return codes
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
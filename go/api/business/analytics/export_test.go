@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNetwork() Network {
+	return Network{
+		Nodes: []NetworkNode{
+			{IataCode: "FRA", IcaoCode: "EDDF", Name: "Frankfurt", CountryCode: "DE", Lat: 50.03, Lng: 8.57, Located: true},
+			{IataCode: "JFK", IcaoCode: "KJFK", Name: "New York JFK", CountryCode: "US", Lat: 40.64, Lng: -73.78, Located: true},
+			{IataCode: "XXX"},
+		},
+		Edges: []NetworkEdge{
+			{DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 21, WeeklyFrequency: 21, Aircraft: []NetworkAircraft{{"388", 14}, {"359", 7}}, Seats: Seats{Business: 1428, Economy: 6762, Total: 8190}},
+			{DepartureAirportIataCode: "JFK", ArrivalAirportIataCode: "XXX", Flights: 7, WeeklyFrequency: 7, Aircraft: []NetworkAircraft{{"359", 7}}},
+		},
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGeoJSON(&buf, testNetwork()))
+
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry *struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fc))
+
+	assert.Equal(t, "FeatureCollection", fc.Type)
+	require.Len(t, fc.Features, 5)
+	assert.Equal(t, "Point", fc.Features[0].Geometry.Type)
+	assert.JSONEq(t, "[8.57,50.03]", string(fc.Features[0].Geometry.Coordinates))
+	assert.Nil(t, fc.Features[2].Geometry)
+	assert.Equal(t, "LineString", fc.Features[3].Geometry.Type)
+	assert.JSONEq(t, "[[8.57,50.03],[-73.78,40.64]]", string(fc.Features[3].Geometry.Coordinates))
+	assert.Equal(t, []any{"388", "359"}, fc.Features[3].Properties["aircraft"])
+	assert.Nil(t, fc.Features[4].Geometry)
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGraphML(&buf, testNetwork()))
+
+	var doc graphML
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "directed", doc.Graph.EdgeDefault)
+	require.Len(t, doc.Graph.Nodes, 3)
+	require.Len(t, doc.Graph.Edges, 2)
+	assert.Equal(t, "FRA", doc.Graph.Edges[0].Source)
+	assert.Equal(t, "JFK", doc.Graph.Edges[0].Target)
+	assert.Contains(t, doc.Graph.Edges[0].Data, graphMLData{Key: "aircraft", Value: "388 359"})
+	assert.Len(t, doc.Graph.Nodes[2].Data, 3)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testNetwork()))
+
+	assert.Equal(
+		t,
+		`departure_airport_id,departure_lat,departure_lng,arrival_airport_id,arrival_lat,arrival_lng,flights,weekly_frequency,aircraft,seats_first,seats_business,seats_premium,seats_economy,seats_total
+FRA,50.03,8.57,JFK,40.64,-73.78,21,21,388 359,0,1428,0,6762,8190
+JFK,40.64,-73.78,XXX,,,7,7,359,0,0,0,0,0
+`,
+		buf.String(),
+	)
+}
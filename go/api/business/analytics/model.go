@@ -0,0 +1,52 @@
+package analytics
+
+import (
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+type Seats struct {
+	First    int `json:"first"`
+	Business int `json:"business"`
+	Premium  int `json:"premium"`
+	Economy  int `json:"economy"`
+	Total    int `json:"total"`
+}
+
+func seatsFromCabinSeats(cs db.CabinSeats) Seats {
+	return Seats{
+		First:    cs.First,
+		Business: cs.Business,
+		Premium:  cs.Premium,
+		Economy:  cs.Economy,
+		Total:    cs.First + cs.Business + cs.Premium + cs.Economy,
+	}
+}
+
+// RouteFrequency holds the flights of a route within the queried range; Group is empty if the query is not grouped
+type RouteFrequency struct {
+	Group                    string  `json:"group,omitempty"`
+	DepartureAirportIataCode string  `json:"departureAirportId"`
+	ArrivalAirportIataCode   string  `json:"arrivalAirportId"`
+	Flights                  int     `json:"flights"`
+	WeeklyFrequency          float64 `json:"weeklyFrequency"`
+	Seats                    Seats   `json:"seats"`
+}
+
+type Capacity struct {
+	Group        string `json:"group,omitempty"`
+	Flights      int    `json:"flights"`
+	Routes       int    `json:"routes"`
+	Destinations int    `json:"destinations"`
+	Seats        Seats  `json:"seats"`
+}
+
+// RouteChanges holds the routes served within [Start, End] but not within [PreviousStart, PreviousEnd] (New) and vice versa (Dropped)
+type RouteChanges struct {
+	Start         xtime.LocalDate  `json:"start"`
+	End           xtime.LocalDate  `json:"end"`
+	PreviousStart xtime.LocalDate  `json:"previousStart"`
+	PreviousEnd   xtime.LocalDate  `json:"previousEnd"`
+	New           []RouteFrequency `json:"new"`
+	Dropped       []RouteFrequency `json:"dropped"`
+}
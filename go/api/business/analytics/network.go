@@ -0,0 +1,150 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+type NetworkNode struct {
+	IataCode    string
+	IcaoCode    string
+	Name        string
+	CountryCode string
+	Lat         float64
+	Lng         float64
+	// Located is false if the airport is unknown, in which case there are no coordinates
+	Located bool
+}
+
+type NetworkAircraft struct {
+	AircraftIataCode string
+	Flights          int
+}
+
+// NetworkEdge is a directed route; Aircraft is ordered by flights descending
+type NetworkEdge struct {
+	DepartureAirportIataCode string
+	ArrivalAirportIataCode   string
+	Flights                  int
+	WeeklyFrequency          float64
+	Aircraft                 []NetworkAircraft
+	Seats                    Seats
+}
+
+type Network struct {
+	Start xtime.LocalDate
+	End   xtime.LocalDate
+	Nodes []NetworkNode
+	Edges []NetworkEdge
+}
+
+// Network collects the airports and routes of the operating flights matched by the query. The grouping of the query is ignored.
+func (s *Search) Network(ctx context.Context, q Query) (Network, error) {
+	q.GroupBy = db.AnalyticsGroupingNone
+	if err := q.validate(); err != nil {
+		return Network{}, err
+	}
+
+	stats, err := s.repo.RouteStatistics(ctx, q.Start, q.End, db.AnalyticsGroupingAircraft, q.condition())
+	if err != nil {
+		return Network{}, err
+	}
+
+	airports, err := s.repo.Airports(ctx)
+	if err != nil {
+		return Network{}, err
+	}
+
+	type edgeKey struct {
+		departureAirport string
+		arrivalAirport   string
+	}
+
+	edges := make(map[edgeKey]*NetworkEdge)
+	seats := make(map[edgeKey]db.CabinSeats)
+	nodes := make(map[string]NetworkNode)
+	for _, rs := range stats {
+		key := edgeKey{rs.DepartureAirportIataCode, rs.ArrivalAirportIataCode}
+		edge, ok := edges[key]
+		if !ok {
+			edge = &NetworkEdge{
+				DepartureAirportIataCode: rs.DepartureAirportIataCode,
+				ArrivalAirportIataCode:   rs.ArrivalAirportIataCode,
+			}
+			edges[key] = edge
+		}
+
+		edge.Flights += rs.Flights
+		edge.Aircraft = append(edge.Aircraft, NetworkAircraft{
+			AircraftIataCode: rs.Group,
+			Flights:          rs.Flights,
+		})
+
+		cs := seats[key]
+		cs.First += rs.Seats.First
+		cs.Business += rs.Seats.Business
+		cs.Premium += rs.Seats.Premium
+		cs.Economy += rs.Seats.Economy
+		seats[key] = cs
+
+		for _, iataCode := range []string{rs.DepartureAirportIataCode, rs.ArrivalAirportIataCode} {
+			if _, ok := nodes[iataCode]; !ok {
+				nodes[iataCode] = networkNode(iataCode, airports)
+			}
+		}
+	}
+
+	weeks := float64(q.days()) / 7.0
+	network := Network{
+		Start: q.Start,
+		End:   q.End,
+		Nodes: slices.SortedFunc(maps.Values(nodes), func(a, b NetworkNode) int {
+			return cmp.Compare(a.IataCode, b.IataCode)
+		}),
+		Edges: make([]NetworkEdge, 0, len(edges)),
+	}
+
+	for key, edge := range edges {
+		edge.WeeklyFrequency = float64(edge.Flights) / weeks
+		edge.Seats = seatsFromCabinSeats(seats[key])
+		slices.SortFunc(edge.Aircraft, func(a, b NetworkAircraft) int {
+			return cmp.Or(
+				cmp.Compare(b.Flights, a.Flights),
+				cmp.Compare(a.AircraftIataCode, b.AircraftIataCode),
+			)
+		})
+
+		network.Edges = append(network.Edges, *edge)
+	}
+
+	slices.SortFunc(network.Edges, func(a, b NetworkEdge) int {
+		return cmp.Or(
+			cmp.Compare(a.DepartureAirportIataCode, b.DepartureAirportIataCode),
+			cmp.Compare(a.ArrivalAirportIataCode, b.ArrivalAirportIataCode),
+		)
+	})
+
+	return network, nil
+}
+
+func networkNode(iataCode string, airports map[string]db.Airport) NetworkNode {
+	airport, ok := airports[iataCode]
+	if !ok {
+		return NetworkNode{IataCode: iataCode}
+	}
+
+	return NetworkNode{
+		IataCode:    airport.IataCode,
+		IcaoCode:    airport.IcaoCode.String,
+		Name:        airport.Name,
+		CountryCode: airport.CountryCode,
+		Lat:         airport.Lat,
+		Lng:         airport.Lng,
+		Located:     true,
+	}
+}
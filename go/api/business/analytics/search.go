@@ -0,0 +1,280 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+const MaxRangeDays = 366
+
+var (
+	ErrInvalidRange    = fmt.Errorf("start must not be after end and the range must not exceed %d days", MaxRangeDays)
+	ErrInvalidGrouping = errors.New("invalid grouping")
+)
+
+type searchRepo interface {
+	RouteStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.RouteStatistic, error)
+	GroupStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.GroupStatistic, error)
+	Airports(ctx context.Context) (map[string]db.Airport, error)
+}
+
+// Query selects the operating flights departing (local) within [Start, End]
+type Query struct {
+	Start             xtime.LocalDate
+	End               xtime.LocalDate
+	GroupBy           db.AnalyticsGrouping
+	AirlineIataCodes  []string
+	AircraftIataCodes []string
+	// AirportIataCode matches flights departing or arriving at the airport
+	AirportIataCode          string
+	DepartureAirportIataCode string
+	ArrivalAirportIataCode   string
+}
+
+func (q Query) validate() error {
+	if q.End < q.Start || q.days() > MaxRangeDays {
+		return ErrInvalidRange
+	}
+
+	if !q.GroupBy.Valid() {
+		return ErrInvalidGrouping
+	}
+
+	return nil
+}
+
+func (q Query) days() int {
+	return q.Start.DaysUntil(q.End) + 1
+}
+
+func (q Query) condition() db.Condition {
+	condition := make(db.AndCondition, 0)
+	if len(q.AirlineIataCodes) > 0 {
+		condition = append(condition, db.NewInCondition("fvhl.airline_iata_code", slices.Values(q.AirlineIataCodes)))
+	}
+
+	if len(q.AircraftIataCodes) > 0 {
+		condition = append(condition, db.NewInCondition("fvhl.aircraft_iata_code", slices.Values(q.AircraftIataCodes)))
+	}
+
+	if q.AirportIataCode != "" {
+		condition = append(condition, db.OrCondition{
+			db.BaseCondition{
+				Filter: "fvhl.departure_airport_iata_code = ?",
+				Params: []any{q.AirportIataCode},
+			},
+			db.BaseCondition{
+				Filter: "fvhl.arrival_airport_iata_code = ?",
+				Params: []any{q.AirportIataCode},
+			},
+		})
+	}
+
+	if q.DepartureAirportIataCode != "" {
+		condition = append(condition, db.BaseCondition{
+			Filter: "fvhl.departure_airport_iata_code = ?",
+			Params: []any{q.DepartureAirportIataCode},
+		})
+	}
+
+	if q.ArrivalAirportIataCode != "" {
+		condition = append(condition, db.BaseCondition{
+			Filter: "fvhl.arrival_airport_iata_code = ?",
+			Params: []any{q.ArrivalAirportIataCode},
+		})
+	}
+
+	if len(condition) < 1 {
+		return nil
+	}
+
+	return condition
+}
+
+type Search struct {
+	repo searchRepo
+}
+
+func NewSearch(repo searchRepo) *Search {
+	return &Search{repo: repo}
+}
+
+// RouteFrequencies lists the flights and seats per group and route, ordered by group and route
+func (s *Search) RouteFrequencies(ctx context.Context, q Query) ([]RouteFrequency, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	routes, err := s.routeFrequencies(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(routes, compareRoutes)
+	return routes, nil
+}
+
+// BusiestRoutes lists the limit busiest routes per group by number of flights, or by seats if bySeats is set
+func (s *Search) BusiestRoutes(ctx context.Context, q Query, limit int, bySeats bool) ([]RouteFrequency, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	routes, err := s.routeFrequencies(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(routes, func(a, b RouteFrequency) int {
+		byValue := cmp.Compare(b.Flights, a.Flights)
+		if bySeats {
+			byValue = cmp.Compare(b.Seats.Total, a.Seats.Total)
+		}
+
+		return cmp.Or(
+			cmp.Compare(a.Group, b.Group),
+			byValue,
+			compareRoutes(a, b),
+		)
+	})
+
+	result := make([]RouteFrequency, 0, len(routes))
+	var groupCount int
+	for i, route := range routes {
+		if i == 0 || routes[i-1].Group != route.Group {
+			groupCount = 0
+		}
+
+		if groupCount < limit {
+			result = append(result, route)
+			groupCount++
+		}
+	}
+
+	return result, nil
+}
+
+// Capacity lists the flights, seats per cabin, routes and destinations per group, ordered by total seats descending.
+// Airports and countries only account for their departing flights.
+func (s *Search) Capacity(ctx context.Context, q Query) ([]Capacity, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GroupStatistics(ctx, q.Start, q.End, q.GroupBy, q.condition())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Capacity, 0, len(stats))
+	for _, gs := range stats {
+		result = append(result, Capacity{
+			Group:        gs.Group,
+			Flights:      gs.Flights,
+			Routes:       gs.Routes,
+			Destinations: gs.Destinations,
+			Seats:        seatsFromCabinSeats(gs.Seats),
+		})
+	}
+
+	slices.SortFunc(result, func(a, b Capacity) int {
+		return cmp.Or(
+			cmp.Compare(b.Seats.Total, a.Seats.Total),
+			cmp.Compare(a.Group, b.Group),
+		)
+	})
+
+	return result, nil
+}
+
+// RouteChanges compares the routes served within the query range against the preceding range of the same length
+func (s *Search) RouteChanges(ctx context.Context, q Query) (RouteChanges, error) {
+	if err := q.validate(); err != nil {
+		return RouteChanges{}, err
+	}
+
+	previous := q
+	previous.End = q.Start - 1
+	previous.Start = q.Start - xtime.LocalDate(q.days())
+
+	current, err := s.routeFrequencies(ctx, q)
+	if err != nil {
+		return RouteChanges{}, err
+	}
+
+	before, err := s.routeFrequencies(ctx, previous)
+	if err != nil {
+		return RouteChanges{}, err
+	}
+
+	changes := RouteChanges{
+		Start:         q.Start,
+		End:           q.End,
+		PreviousStart: previous.Start,
+		PreviousEnd:   previous.End,
+		New:           routesMissingIn(current, before),
+		Dropped:       routesMissingIn(before, current),
+	}
+
+	return changes, nil
+}
+
+func (s *Search) routeFrequencies(ctx context.Context, q Query) ([]RouteFrequency, error) {
+	stats, err := s.repo.RouteStatistics(ctx, q.Start, q.End, q.GroupBy, q.condition())
+	if err != nil {
+		return nil, err
+	}
+
+	weeks := float64(q.days()) / 7.0
+	result := make([]RouteFrequency, 0, len(stats))
+	for _, rs := range stats {
+		result = append(result, RouteFrequency{
+			Group:                    rs.Group,
+			DepartureAirportIataCode: rs.DepartureAirportIataCode,
+			ArrivalAirportIataCode:   rs.ArrivalAirportIataCode,
+			Flights:                  rs.Flights,
+			WeeklyFrequency:          float64(rs.Flights) / weeks,
+			Seats:                    seatsFromCabinSeats(rs.Seats),
+		})
+	}
+
+	return result, nil
+}
+
+// routesMissingIn returns the routes of a which are not contained in b, ordered by group and route
+func routesMissingIn(a, b []RouteFrequency) []RouteFrequency {
+	type routeKey struct {
+		group            string
+		departureAirport string
+		arrivalAirport   string
+	}
+
+	existing := make(map[routeKey]struct{}, len(b))
+	for _, r := range b {
+		existing[routeKey{r.Group, r.DepartureAirportIataCode, r.ArrivalAirportIataCode}] = struct{}{}
+	}
+
+	result := make([]RouteFrequency, 0)
+	for _, r := range a {
+		if _, ok := existing[routeKey{r.Group, r.DepartureAirportIataCode, r.ArrivalAirportIataCode}]; !ok {
+			result = append(result, r)
+		}
+	}
+
+	slices.SortFunc(result, compareRoutes)
+	return result
+}
+
+func compareRoutes(a, b RouteFrequency) int {
+	return cmp.Or(
+		cmp.Compare(a.Group, b.Group),
+		cmp.Compare(a.DepartureAirportIataCode, b.DepartureAirportIataCode),
+		cmp.Compare(a.ArrivalAirportIataCode, b.ArrivalAirportIataCode),
+	)
+}
@@ -0,0 +1,112 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRepo map[xtime.LocalDate][]db.RouteStatistic
+
+func (r fakeRepo) RouteStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.RouteStatistic, error) {
+	return r[start], nil
+}
+
+func (r fakeRepo) GroupStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.GroupStatistic, error) {
+	return nil, nil
+}
+
+func (r fakeRepo) Airports(ctx context.Context) (map[string]db.Airport, error) {
+	return map[string]db.Airport{
+		"FRA": {IataCode: "FRA", Name: "Frankfurt", CountryCode: "DE", Lat: 50.03, Lng: 8.57},
+		"JFK": {IataCode: "JFK", Name: "New York JFK", CountryCode: "US", Lat: 40.64, Lng: -73.78},
+	}, nil
+}
+
+func TestSearch_BusiestRoutes(t *testing.T) {
+	start := xtime.NewLocalDateFromParts(2026, time.May, 1)
+	s := NewSearch(fakeRepo{
+		start: {
+			{Group: "LH", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "MUC", Flights: 70, Seats: db.CabinSeats{Economy: 9000}},
+			{Group: "LH", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 28, Seats: db.CabinSeats{Business: 1300, Economy: 8000}},
+			{Group: "LH", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "BER", Flights: 56, Seats: db.CabinSeats{Economy: 7000}},
+			{Group: "UA", DepartureAirportIataCode: "EWR", ArrivalAirportIataCode: "FRA", Flights: 14, Seats: db.CabinSeats{Economy: 3000}},
+		},
+	})
+
+	q := Query{Start: start, End: start + 13, GroupBy: db.AnalyticsGroupingAirline}
+
+	routes, err := s.BusiestRoutes(context.Background(), q, 2, false)
+	require.NoError(t, err)
+	require.Len(t, routes, 3)
+	assert.Equal(t, []string{"MUC", "BER", "FRA"}, []string{routes[0].ArrivalAirportIataCode, routes[1].ArrivalAirportIataCode, routes[2].ArrivalAirportIataCode})
+	assert.Equal(t, 35.0, routes[0].WeeklyFrequency)
+
+	routes, err = s.BusiestRoutes(context.Background(), q, 1, true)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, "JFK", routes[0].ArrivalAirportIataCode)
+	assert.Equal(t, 9300, routes[0].Seats.Total)
+}
+
+func TestSearch_RouteChanges(t *testing.T) {
+	start := xtime.NewLocalDateFromParts(2026, time.May, 1)
+	s := NewSearch(fakeRepo{
+		start - 7: {
+			{DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 7},
+			{DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "DME", Flights: 7},
+		},
+		start: {
+			{DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 7},
+			{DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "YVR", Flights: 3},
+		},
+	})
+
+	changes, err := s.RouteChanges(context.Background(), Query{Start: start, End: start + 6})
+	require.NoError(t, err)
+	assert.Equal(t, start-7, changes.PreviousStart)
+	assert.Equal(t, start-1, changes.PreviousEnd)
+	require.Len(t, changes.New, 1)
+	assert.Equal(t, "YVR", changes.New[0].ArrivalAirportIataCode)
+	require.Len(t, changes.Dropped, 1)
+	assert.Equal(t, "DME", changes.Dropped[0].ArrivalAirportIataCode)
+}
+
+func TestQuery_Validate(t *testing.T) {
+	start := xtime.NewLocalDateFromParts(2026, time.May, 1)
+
+	assert.NoError(t, Query{Start: start, End: start}.validate())
+	assert.ErrorIs(t, Query{Start: start, End: start - 1}.validate(), ErrInvalidRange)
+	assert.ErrorIs(t, Query{Start: start, End: start + MaxRangeDays}.validate(), ErrInvalidRange)
+	assert.ErrorIs(t, Query{Start: start, End: start, GroupBy: "route"}.validate(), ErrInvalidGrouping)
+}
+
+func TestSearch_Network(t *testing.T) {
+	start := xtime.NewLocalDateFromParts(2026, time.May, 1)
+	s := NewSearch(fakeRepo{
+		start: {
+			{Group: "359", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 7, Seats: db.CabinSeats{Business: 336, Premium: 147, Economy: 1568}},
+			{Group: "388", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 14, Seats: db.CabinSeats{First: 112, Business: 1092, Premium: 728, Economy: 5194}},
+			{Group: "359", DepartureAirportIataCode: "JFK", ArrivalAirportIataCode: "XXX", Flights: 7},
+		},
+	})
+
+	network, err := s.Network(context.Background(), Query{Start: start, End: start + 6, GroupBy: db.AnalyticsGroupingCountry})
+	require.NoError(t, err)
+
+	require.Len(t, network.Nodes, 3)
+	assert.Equal(t, "FRA", network.Nodes[0].IataCode)
+	assert.True(t, network.Nodes[0].Located)
+	assert.False(t, network.Nodes[2].Located)
+
+	require.Len(t, network.Edges, 2)
+	assert.Equal(t, 21, network.Edges[0].Flights)
+	assert.Equal(t, 21.0, network.Edges[0].WeeklyFrequency)
+	assert.Equal(t, []NetworkAircraft{{"388", 14}, {"359", 7}}, network.Edges[0].Aircraft)
+	assert.Equal(t, 9177, network.Edges[0].Seats.Total)
+}
@@ -2,6 +2,7 @@ package connections
 
 import (
 	"slices"
+	"time"
 )
 
 type SearchOption interface {
@@ -14,6 +15,14 @@ func (a WithCountMultiLeg) Apply(f *Options) {
 	f.countMultiLeg = bool(a)
 }
 
+// WithAsOf searches the network as it was at the given version instead of the latest one
+type WithAsOf time.Time
+
+func (a WithAsOf) Apply(f *Options) {
+	asOf := time.Time(a)
+	f.asOf = &asOf
+}
+
 type WithIncludeAircraft string
 
 func (a WithIncludeAircraft) Apply(f *Options) {
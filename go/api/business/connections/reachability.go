@@ -0,0 +1,228 @@
+package connections
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+// Reachable is an airport reachable from the origin with the earliest arrival and the flights of the path leading to it
+type Reachable struct {
+	AirportIataCode string
+	ArrivalTime     time.Time
+	Flights         []*Flight
+}
+
+// Reachability lists every airport reachable from origin by departing within [minDeparture, maxDeparture] using at most maxFlights flights
+// and arriving at most maxDuration after the first departure, ordered by earliest arrival. Of multiple paths with the same arrival, the one departing latest is returned,
+// and of those the one with the fewest flights.
+// Every leg of a multi-leg flight counts as a flight, and options including flights, airports or aircraft are not applied.
+func (ch *Search) Reachability(ctx context.Context, origin string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxDuration time.Duration, options ...SearchOption) ([]Reachable, error) {
+	var f Options
+	for _, opt := range options {
+		opt.Apply(&f)
+	}
+
+	minDate := xtime.NewLocalDate(minDeparture.UTC())
+	maxDate := xtime.NewLocalDate(maxDeparture.Add(maxDuration).UTC())
+
+	flightsByDeparture, _, err := ch.loadFlights(ctx, minDate, maxDate, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return reachability(ctx, flightsByDeparture, origin, minDeparture, maxDeparture, maxFlights, minLayover, maxDuration)
+}
+
+type reachLabel struct {
+	flight *Flight
+	prev   *reachLabel
+	legs   uint32
+}
+
+// dominates reports whether every continuation of o is also possible from l with at most the same number of legs.
+// The caller must ensure that the deadline of l is not before the deadline of o.
+func (l *reachLabel) dominates(o *reachLabel, minLayover time.Duration) bool {
+	if l.legs > o.legs {
+		return false
+	}
+
+	// o may continue on the same flight number without a layover
+	if l.flight.FlightNumber == o.flight.FlightNumber {
+		return l.flight.ArrivalTime.Compare(o.flight.ArrivalTime) <= 0
+	}
+
+	return l.flight.ArrivalTime.Add(minLayover).Compare(o.flight.ArrivalTime) <= 0
+}
+
+func (l *reachLabel) departureTime() time.Time {
+	for l.prev != nil {
+		l = l.prev
+	}
+
+	return l.flight.DepartureTime
+}
+
+// isBetterThan reports whether l arrives earlier than o, or at the same time but departing later or with fewer legs.
+func (l *reachLabel) isBetterThan(o *reachLabel) bool {
+	return cmp.Or(
+		l.flight.ArrivalTime.Compare(o.flight.ArrivalTime),
+		o.departureTime().Compare(l.departureTime()),
+		cmp.Compare(l.legs, o.legs),
+	) < 0
+}
+
+func (l *reachLabel) flights() []*Flight {
+	flights := make([]*Flight, l.legs)
+	for curr := l; curr != nil; curr = curr.prev {
+		flights[curr.legs-1] = curr.flight
+	}
+
+	return flights
+}
+
+type reachQueue []*reachLabel
+
+func (q reachQueue) Len() int { return len(q) }
+func (q reachQueue) Less(i, j int) bool {
+	return q[i].flight.ArrivalTime.Before(q[j].flight.ArrivalTime)
+}
+func (q reachQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *reachQueue) Push(x any)   { *q = append(*q, x.(*reachLabel)) }
+func (q *reachQueue) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return x
+}
+
+// reachability runs a forward time-dependent Dijkstra for every departure from the origin, latest first.
+// Since a later first departure allows a later arrival, labels of previous runs may prune labels of the current run.
+func reachability(
+	ctx context.Context,
+	flightsByDeparture map[Departure][]*Flight,
+	origin string,
+	minDeparture,
+	maxDeparture time.Time,
+	maxFlights uint32,
+	minLayover,
+	maxDuration time.Duration,
+) ([]Reachable, error) {
+	if maxFlights < 1 || maxDuration < 1 {
+		return []Reachable{}, nil
+	}
+
+	flightsByAirport := make(map[string][]*Flight)
+	for d, flights := range flightsByDeparture {
+		for _, f := range flights {
+			if isScheduledService(f) {
+				flightsByAirport[d.AirportIataCode] = append(flightsByAirport[d.AirportIataCode], f)
+			}
+		}
+	}
+
+	for _, flights := range flightsByAirport {
+		slices.SortFunc(flights, func(a, b *Flight) int {
+			return a.DepartureTime.Compare(b.DepartureTime)
+		})
+	}
+
+	seeds := make([]*Flight, 0)
+	for _, f := range flightsByAirport[origin] {
+		if f.DepartureTime.Compare(minDeparture) >= 0 && f.DepartureTime.Compare(maxDeparture) <= 0 && f.Duration() <= maxDuration && f.ArrivalAirportIataCode != origin {
+			seeds = append(seeds, f)
+		}
+	}
+
+	slices.Reverse(seeds)
+
+	labels := make(map[string][]*reachLabel)
+	best := make(map[string]*reachLabel)
+	isDominated := func(l *reachLabel) bool {
+		return slices.ContainsFunc(labels[l.flight.ArrivalAirportIataCode], func(o *reachLabel) bool {
+			return o.dominates(l, minLayover)
+		})
+	}
+
+	for _, seed := range seeds {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		deadline := seed.DepartureTime.Add(maxDuration)
+		q := reachQueue{{flight: seed, legs: 1}}
+
+		for q.Len() > 0 {
+			l := heap.Pop(&q).(*reachLabel)
+			if isDominated(l) {
+				continue
+			}
+
+			airport := l.flight.ArrivalAirportIataCode
+			labels[airport] = append(labels[airport], l)
+
+			if b, ok := best[airport]; !ok || l.isBetterThan(b) {
+				best[airport] = l
+			}
+
+			if l.legs >= maxFlights {
+				continue
+			}
+
+			flights := flightsByAirport[airport]
+			idx, _ := slices.BinarySearchFunc(flights, l.flight.ArrivalTime, func(f *Flight, t time.Time) int {
+				return f.DepartureTime.Compare(t)
+			})
+
+			for _, f := range flights[idx:] {
+				if f.DepartureTime.After(deadline) {
+					break
+				}
+
+				if f.ArrivalTime.After(deadline) || f.ArrivalAirportIataCode == origin {
+					continue
+				}
+
+				// continuing on the same flight number (multi-leg) requires no layover
+				if f.FlightNumber != l.flight.FlightNumber && f.DepartureTime.Before(l.flight.ArrivalTime.Add(minLayover)) {
+					continue
+				}
+
+				next := &reachLabel{flight: f, prev: l, legs: l.legs + 1}
+				if !isDominated(next) {
+					heap.Push(&q, next)
+				}
+			}
+		}
+	}
+
+	result := make([]Reachable, 0, len(best))
+	for airport, l := range best {
+		result = append(result, Reachable{
+			AirportIataCode: airport,
+			ArrivalTime:     l.flight.ArrivalTime,
+			Flights:         l.flights(),
+		})
+	}
+
+	slices.SortFunc(result, func(a, b Reachable) int {
+		return cmp.Or(
+			a.ArrivalTime.Compare(b.ArrivalTime),
+			cmp.Compare(a.AirportIataCode, b.AirportIataCode),
+		)
+	})
+
+	return result, nil
+}
+
+// isScheduledService reports whether the flight can be booked as part of a connection.
+// J = regular flight, U = Rail&Fly
+func isScheduledService(f *Flight) bool {
+	return f.ServiceType == "J" || f.ServiceType == "U"
+}
@@ -0,0 +1,122 @@
+package connections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDay = time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+func testFlight(airline string, number int, departureAirport string, departure time.Duration, arrivalAirport string, duration time.Duration) *Flight {
+	return &Flight{db.Flight{
+		FlightNumber:             db.FlightNumber{AirlineIataCode: airline, Number: number},
+		DepartureTime:            testDay.Add(departure),
+		DepartureAirportIataCode: departureAirport,
+		ArrivalTime:              testDay.Add(departure + duration),
+		ArrivalAirportIataCode:   arrivalAirport,
+		ServiceType:              "J",
+	}}
+}
+
+func groupByDeparture(flights ...*Flight) map[Departure][]*Flight {
+	result := make(map[Departure][]*Flight)
+	for _, f := range flights {
+		d := f.DepartureUTC()
+		result[d] = append(result[d], f)
+	}
+
+	return result
+}
+
+func TestReachability(t *testing.T) {
+	muc1 := testFlight("LH", 2000, "MUC", 12*time.Hour, "FRA", time.Hour)
+	muc2 := testFlight("LH", 2002, "MUC", 15*time.Hour, "FRA", time.Hour)
+	fraJfk := testFlight("LH", 400, "FRA", 14*time.Hour, "JFK", 9*time.Hour)
+	fraLhr1 := testFlight("LH", 900, "FRA", 13*time.Hour+15*time.Minute, "LHR", time.Hour+30*time.Minute)
+	fraLhr2 := testFlight("LH", 902, "FRA", 17*time.Hour, "LHR", time.Hour+30*time.Minute)
+	lhrDub := testFlight("EI", 155, "LHR", 20*time.Hour, "DUB", time.Hour)
+	mucCgn := testFlight("LH", 1990, "MUC", 13*time.Hour, "CGN", time.Hour)
+	cgnHam := testFlight("LH", 1990, "CGN", 14*time.Hour+10*time.Minute, "HAM", time.Hour)
+	ferry := testFlight("LH", 9999, "MUC", 13*time.Hour, "BER", time.Hour)
+	ferry.ServiceType = "P"
+
+	flights := groupByDeparture(muc1, muc2, fraJfk, fraLhr1, fraLhr2, lhrDub, mucCgn, cgnHam, ferry)
+
+	t.Run("earliest arrival within duration", func(t *testing.T) {
+		result, err := reachability(context.Background(), flights, "MUC", testDay.Add(12*time.Hour), testDay.Add(18*time.Hour), 2, 45*time.Minute, 8*time.Hour)
+		require.NoError(t, err)
+
+		arrivals := make(map[string]time.Time)
+		paths := make(map[string][]*Flight)
+		for _, r := range result {
+			arrivals[r.AirportIataCode] = r.ArrivalTime
+			paths[r.AirportIataCode] = r.Flights
+		}
+
+		require.Len(t, result, 4)
+		assert.Equal(t, []string{"FRA", "CGN", "HAM", "LHR"}, []string{result[0].AirportIataCode, result[1].AirportIataCode, result[2].AirportIataCode, result[3].AirportIataCode})
+
+		// the earlier LHR flight can't be made with a 45min layover; of both paths to the later one, the later departure wins
+		assert.Equal(t, []*Flight{muc2, fraLhr2}, paths["LHR"])
+		assert.Equal(t, fraLhr2.ArrivalTime, arrivals["LHR"])
+
+		// continuing on the same flight number does not require a layover
+		assert.Equal(t, []*Flight{mucCgn, cgnHam}, paths["HAM"])
+
+		// JFK exceeds 8 hours, DUB requires 3 flights, BER is not a scheduled service
+		assert.NotContains(t, arrivals, "JFK")
+		assert.NotContains(t, arrivals, "DUB")
+		assert.NotContains(t, arrivals, "BER")
+	})
+
+	t.Run("later departure allows a connection the earlier one can't make within the duration", func(t *testing.T) {
+		result, err := reachability(context.Background(), flights, "MUC", testDay.Add(12*time.Hour), testDay.Add(18*time.Hour), 3, 45*time.Minute, 6*time.Hour+30*time.Minute)
+		require.NoError(t, err)
+
+		var dub *Reachable
+		for _, r := range result {
+			if r.AirportIataCode == "DUB" {
+				dub = &r
+			}
+		}
+
+		require.NotNil(t, dub)
+		assert.Equal(t, []*Flight{muc2, fraLhr2, lhrDub}, dub.Flights)
+	})
+
+	t.Run("no flights", func(t *testing.T) {
+		result, err := reachability(context.Background(), flights, "MUC", testDay.Add(12*time.Hour), testDay.Add(18*time.Hour), 0, 45*time.Minute, 8*time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestReachabilityEqualArrival(t *testing.T) {
+	mucLhr := testFlight("LH", 2470, "MUC", 12*time.Hour, "LHR", 5*time.Hour+30*time.Minute)
+	mucFra := testFlight("LH", 2004, "MUC", 14*time.Hour, "FRA", time.Hour)
+	fraLhr := testFlight("LH", 904, "FRA", 16*time.Hour, "LHR", time.Hour+30*time.Minute)
+	mucDus := testFlight("LH", 2020, "MUC", 13*time.Hour, "DUS", 3*time.Hour)
+	mucCgn := testFlight("LH", 1992, "MUC", 13*time.Hour, "CGN", time.Hour)
+	cgnDus := testFlight("LH", 1994, "CGN", 15*time.Hour, "DUS", time.Hour)
+
+	flights := groupByDeparture(mucLhr, mucFra, fraLhr, mucDus, mucCgn, cgnDus)
+
+	result, err := reachability(context.Background(), flights, "MUC", testDay.Add(12*time.Hour), testDay.Add(18*time.Hour), 2, 45*time.Minute, 8*time.Hour)
+	require.NoError(t, err)
+
+	paths := make(map[string][]*Flight)
+	for _, r := range result {
+		paths[r.AirportIataCode] = r.Flights
+	}
+
+	// the path departing later wins even though the earlier one needs fewer flights
+	assert.Equal(t, []*Flight{mucFra, fraLhr}, paths["LHR"])
+
+	// of paths departing at the same time, the one with fewer flights wins
+	assert.Equal(t, []*Flight{mucDus}, paths["DUS"])
+}
@@ -0,0 +1,351 @@
+package connections
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+var ErrTooManyIncludeOptions = errors.New("at most 64 include options are supported")
+
+// Journey is a path of flights from an origin to a destination.
+// Legs is the number of flights counted towards maxFlights, which may be less than len(Flights) if multi-leg flights are not counted.
+type Journey struct {
+	Flights []*Flight
+	Legs    uint32
+}
+
+func (j Journey) DepartureTime() time.Time {
+	return j.Flights[0].DepartureTime
+}
+
+func (j Journey) ArrivalTime() time.Time {
+	return j.Flights[len(j.Flights)-1].ArrivalTime
+}
+
+func (j Journey) Duration() time.Duration {
+	return j.ArrivalTime().Sub(j.DepartureTime())
+}
+
+// EarliestArrival returns the journey arriving first; of journeys arriving at the same time, the one with fewer legs, then the one departing latest.
+// All methods searching journeys accept the same constraints and options as FindConnections.
+func (ch *Search) EarliestArrival(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) (Journey, bool, error) {
+	journeys, err := ch.journeys(ctx, origins, destinations, minDeparture, maxDeparture, maxFlights, minLayover, maxLayover, maxDuration, options...)
+	if err != nil {
+		return Journey{}, false, err
+	}
+
+	return bestJourney(journeys, compareEarliestArrival)
+}
+
+// LatestDeparture returns the journey departing last; of journeys departing at the same time, the one arriving first, then the one with fewer legs.
+func (ch *Search) LatestDeparture(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) (Journey, bool, error) {
+	journeys, err := ch.journeys(ctx, origins, destinations, minDeparture, maxDeparture, maxFlights, minLayover, maxLayover, maxDuration, options...)
+	if err != nil {
+		return Journey{}, false, err
+	}
+
+	return bestJourney(journeys, compareLatestDeparture)
+}
+
+// FewestLegs returns the journey with the fewest legs; of journeys with the same number of legs, the one arriving first, then the one departing latest.
+func (ch *Search) FewestLegs(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) (Journey, bool, error) {
+	journeys, err := ch.journeys(ctx, origins, destinations, minDeparture, maxDeparture, maxFlights, minLayover, maxLayover, maxDuration, options...)
+	if err != nil {
+		return Journey{}, false, err
+	}
+
+	return bestJourney(journeys, compareFewestLegs)
+}
+
+// ParetoJourneys returns the journeys which are Pareto-optimal regarding arrival time and legs, ordered by legs ascending.
+// Every returned journey arrives earlier than all journeys with fewer legs.
+func (ch *Search) ParetoJourneys(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) ([]Journey, error) {
+	journeys, err := ch.journeys(ctx, origins, destinations, minDeparture, maxDeparture, maxFlights, minLayover, maxLayover, maxDuration, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return paretoJourneys(journeys), nil
+}
+
+func (ch *Search) journeys(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) ([]Journey, error) {
+	var f Options
+	for _, opt := range options {
+		opt.Apply(&f)
+	}
+
+	minDate := xtime.NewLocalDate(minDeparture.UTC())
+	maxDate := xtime.NewLocalDate(maxDeparture.Add(maxDuration).UTC())
+
+	flightsByDeparture, pctx, err := ch.loadFlights(ctx, minDate, maxDate, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJourneys(
+		ctx,
+		flightsByDeparture,
+		origins,
+		destinations,
+		minDeparture,
+		maxDeparture,
+		maxFlights,
+		minLayover,
+		maxLayover,
+		maxDuration,
+		pctx,
+		f.any,
+		f.countMultiLeg,
+	)
+}
+
+type journeyLabel struct {
+	flight    *Flight
+	prev      *journeyLabel
+	departure time.Time
+	legs      uint32
+	// matched has the bit of every include predicate matched by a flight of the path set
+	matched uint64
+}
+
+// dominates reports whether every continuation of o is also possible from l. Both labels must belong to the same flight.
+func (l *journeyLabel) dominates(o *journeyLabel) bool {
+	return l.legs <= o.legs && l.departure.Compare(o.departure) >= 0 && l.matched&o.matched == o.matched
+}
+
+func (l *journeyLabel) journey() Journey {
+	flights := make([]*Flight, 0, l.legs)
+	for curr := l; curr != nil; curr = curr.prev {
+		flights = append(flights, curr.flight)
+	}
+
+	slices.Reverse(flights)
+
+	return Journey{
+		Flights: flights,
+		Legs:    l.legs,
+	}
+}
+
+// scanJourneys is a connection scan over the flights ordered by departure. Every flight holds the Pareto set of the paths (labels) arriving with it,
+// compared by first departure, legs and matched include predicates. Since the arrival of all paths of a flight is the same, the set contains the optimum of every criterion.
+// Labels are passed on to all flights departing within the layover window of the arrival airport, so every label is final once its flight is scanned.
+func scanJourneys(
+	ctx context.Context,
+	flightsByDeparture map[Departure][]*Flight,
+	origins,
+	destinations []string,
+	minDeparture,
+	maxDeparture time.Time,
+	maxFlights uint32,
+	minLayover,
+	maxLayover,
+	maxDuration time.Duration,
+	pctx *predicateContext,
+	predicates []flightPredicate,
+	countMultiLeg bool,
+) ([]Journey, error) {
+	if maxFlights < 1 || maxDuration < 1 {
+		return []Journey{}, nil
+	} else if len(predicates) > 64 {
+		return nil, ErrTooManyIncludeOptions
+	}
+
+	allMatched := uint64(1)<<len(predicates) - 1
+	matches := func(f *Flight) uint64 {
+		var matched uint64
+		for i, p := range predicates {
+			if p(pctx, f) {
+				matched |= 1 << i
+			}
+		}
+
+		return matched
+	}
+
+	maxArrival := maxDeparture.Add(maxDuration)
+	flights := make([]*Flight, 0)
+	for _, dayFlights := range flightsByDeparture {
+		for _, f := range dayFlights {
+			if isScheduledService(f) && f.DepartureTime.Compare(minDeparture) >= 0 && f.ArrivalTime.Compare(maxArrival) <= 0 {
+				flights = append(flights, f)
+			}
+		}
+	}
+
+	slices.SortFunc(flights, func(a, b *Flight) int {
+		// the arguments of cmp.Or are always evaluated, so compare the departure first
+		if c := a.DepartureTime.Compare(b.DepartureTime); c != 0 {
+			return c
+		}
+
+		return cmp.Or(
+			a.ArrivalTime.Compare(b.ArrivalTime),
+			cmp.Compare(a.DepartureAirportIataCode, b.DepartureAirportIataCode),
+			cmp.Compare(a.AirlineIataCode, b.AirlineIataCode),
+			cmp.Compare(a.Number, b.Number),
+			cmp.Compare(a.Suffix, b.Suffix),
+		)
+	})
+
+	// indices of flights by departure airport, ordered by departure
+	departures := make(map[string][]int)
+	for i, f := range flights {
+		departures[f.DepartureAirportIataCode] = append(departures[f.DepartureAirportIataCode], i)
+	}
+
+	labels := make([][]*journeyLabel, len(flights))
+	result := make([]Journey, 0)
+
+	for i, f := range flights {
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		if slices.Contains(origins, f.DepartureAirportIataCode) && f.DepartureTime.Compare(maxDeparture) <= 0 && f.Duration() <= maxDuration {
+			labels[i] = insertJourneyLabel(labels[i], &journeyLabel{
+				flight:    f,
+				departure: f.DepartureTime,
+				legs:      1,
+				matched:   matches(f),
+			})
+		}
+
+		if len(labels[i]) < 1 {
+			continue
+		}
+
+		// paths end at the first destination reached
+		if slices.Contains(destinations, f.ArrivalAirportIataCode) {
+			for _, l := range labels[i] {
+				if l.matched == allMatched {
+					result = append(result, l.journey())
+				}
+			}
+
+			labels[i] = nil
+			continue
+		}
+
+		next := departures[f.ArrivalAirportIataCode]
+		idx, _ := slices.BinarySearchFunc(next, f.ArrivalTime, func(j int, t time.Time) int {
+			return flights[j].DepartureTime.Compare(t)
+		})
+
+		for _, j := range next[idx:] {
+			g := flights[j]
+			if g.DepartureTime.After(f.ArrivalTime.Add(maxLayover)) {
+				break
+			}
+
+			// continuing on the same flight number (multi-leg) requires no layover
+			sameFlightNumber := g.FlightNumber == f.FlightNumber
+			if !sameFlightNumber && g.DepartureTime.Before(f.ArrivalTime.Add(minLayover)) {
+				continue
+			}
+
+			consumeFlights := uint32(1)
+			if !countMultiLeg && sameFlightNumber {
+				consumeFlights = 0
+			}
+
+			// a path using all flights can only be continued by uncounted legs, so it has to end at a destination otherwise
+			deadEnd := countMultiLeg && !slices.Contains(destinations, g.ArrivalAirportIataCode)
+
+			var matched uint64
+			matchedKnown := false
+
+			for _, l := range labels[i] {
+				legs := l.legs + consumeFlights
+				if legs > maxFlights || (legs == maxFlights && deadEnd) || g.ArrivalTime.Sub(l.departure) > maxDuration {
+					continue
+				}
+
+				if !matchedKnown {
+					matched = matches(g)
+					matchedKnown = true
+				}
+
+				labels[j] = insertJourneyLabel(labels[j], &journeyLabel{
+					flight:    g,
+					prev:      l,
+					departure: l.departure,
+					legs:      legs,
+					matched:   l.matched | matched,
+				})
+			}
+		}
+
+		// labels stay reachable through prev of their successors
+		labels[i] = nil
+	}
+
+	return result, nil
+}
+
+func insertJourneyLabel(labels []*journeyLabel, l *journeyLabel) []*journeyLabel {
+	for _, o := range labels {
+		if o.dominates(l) {
+			return labels
+		}
+	}
+
+	labels = slices.DeleteFunc(labels, func(o *journeyLabel) bool {
+		return l.dominates(o)
+	})
+
+	return append(labels, l)
+}
+
+func bestJourney(journeys []Journey, compare func(a, b Journey) int) (Journey, bool, error) {
+	if len(journeys) < 1 {
+		return Journey{}, false, nil
+	}
+
+	return slices.MinFunc(journeys, compare), true, nil
+}
+
+func compareEarliestArrival(a, b Journey) int {
+	return cmp.Or(
+		a.ArrivalTime().Compare(b.ArrivalTime()),
+		cmp.Compare(a.Legs, b.Legs),
+		b.DepartureTime().Compare(a.DepartureTime()),
+	)
+}
+
+func compareLatestDeparture(a, b Journey) int {
+	return cmp.Or(
+		b.DepartureTime().Compare(a.DepartureTime()),
+		a.ArrivalTime().Compare(b.ArrivalTime()),
+		cmp.Compare(a.Legs, b.Legs),
+	)
+}
+
+func compareFewestLegs(a, b Journey) int {
+	return cmp.Or(
+		cmp.Compare(a.Legs, b.Legs),
+		a.ArrivalTime().Compare(b.ArrivalTime()),
+		b.DepartureTime().Compare(a.DepartureTime()),
+	)
+}
+
+func paretoJourneys(journeys []Journey) []Journey {
+	journeys = slices.Clone(journeys)
+	slices.SortFunc(journeys, compareFewestLegs)
+
+	result := make([]Journey, 0)
+	for _, j := range journeys {
+		if len(result) < 1 || j.ArrivalTime().Before(result[len(result)-1].ArrivalTime()) {
+			result = append(result, j)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,224 @@
+package connections
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomNetwork creates flights departing within the first day of testDay; every fifth flight continues as a multi-leg flight
+func randomNetwork(seed uint64, airports, flightsPerAirport int) map[Departure][]*Flight {
+	r := rand.New(rand.NewPCG(seed, seed))
+	codes := make([]string, airports)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("A%02d", i)
+	}
+
+	randomArrival := func(departure string) string {
+		for {
+			if arrival := codes[r.IntN(airports)]; arrival != departure {
+				return arrival
+			}
+		}
+	}
+
+	flights := make([]*Flight, 0)
+	for i, departure := range codes {
+		for n := range flightsPerAirport {
+			arrival := randomArrival(departure)
+			f := testFlight("XX", i*100+n, departure, time.Duration(r.IntN(24*60))*time.Minute, arrival, time.Duration(45+r.IntN(6*60))*time.Minute)
+			flights = append(flights, f)
+
+			if r.IntN(5) == 0 {
+				flights = append(flights, testFlight("XX", f.Number, arrival, f.ArrivalTime.Add(40*time.Minute).Sub(testDay), randomArrival(arrival), time.Duration(45+r.IntN(3*60))*time.Minute))
+			}
+		}
+	}
+
+	return groupByDeparture(flights...)
+}
+
+// hubNetwork creates flights between every spoke and every hub in both directions and between all hubs, departing every two hours of the first day of testDay
+func hubNetwork(hubs, spokes int) map[Departure][]*Flight {
+	flights := make([]*Flight, 0)
+	number := 0
+	add := func(departure, arrival string, offset, duration time.Duration) {
+		for hour := time.Duration(0); hour < 24; hour += 2 {
+			number++
+			flights = append(flights, testFlight("XX", number, departure, hour*time.Hour+offset, arrival, duration))
+		}
+	}
+
+	for h := range hubs {
+		hub := fmt.Sprintf("H%02d", h)
+		for s := range spokes {
+			spoke := fmt.Sprintf("S%02d", s)
+			add(spoke, hub, time.Duration(s)*time.Minute, time.Hour+time.Duration(h*10)*time.Minute)
+			add(hub, spoke, time.Duration(s)*time.Minute+time.Hour, time.Hour+time.Duration(s)*time.Minute)
+		}
+
+		for o := range hubs {
+			if o != h {
+				add(hub, fmt.Sprintf("H%02d", o), time.Duration(o*15)*time.Minute, time.Hour+30*time.Minute)
+			}
+		}
+	}
+
+	return groupByDeparture(flights...)
+}
+
+// enumeratedJourneys flattens the connections found by findConnections
+func enumeratedJourneys(conns []Connection, prefix []*Flight, countMultiLeg bool) []Journey {
+	result := make([]Journey, 0)
+	for _, conn := range conns {
+		flights := append(append(make([]*Flight, 0, len(prefix)+1), prefix...), conn.Flight)
+		if len(conn.Outgoing) > 0 {
+			result = append(result, enumeratedJourneys(conn.Outgoing, flights, countMultiLeg)...)
+			continue
+		}
+
+		legs := uint32(len(flights))
+		if !countMultiLeg {
+			for i := 1; i < len(flights); i++ {
+				if flights[i].FlightNumber == flights[i-1].FlightNumber {
+					legs--
+				}
+			}
+		}
+
+		result = append(result, Journey{Flights: flights, Legs: legs})
+	}
+
+	return result
+}
+
+func TestScanJourneys(t *testing.T) {
+	direct := testFlight("LH", 400, "MUC", 10*time.Hour, "JFK", 9*time.Hour)
+	feeder := testFlight("LH", 2000, "MUC", 8*time.Hour, "FRA", time.Hour)
+	fraJfk := testFlight("LH", 402, "FRA", 10*time.Hour, "JFK", 7*time.Hour)
+	flights := groupByDeparture(direct, feeder, fraJfk)
+
+	journeys, err := scanJourneys(context.Background(), flights, []string{"MUC"}, []string{"JFK"}, testDay, testDay.Add(24*time.Hour), 2, 45*time.Minute, 6*time.Hour, 24*time.Hour, nil, nil, true)
+	require.NoError(t, err)
+	require.Len(t, journeys, 2)
+
+	ea, ok, _ := bestJourney(journeys, compareEarliestArrival)
+	require.True(t, ok)
+	assert.Equal(t, []*Flight{feeder, fraJfk}, ea.Flights)
+
+	ld, _, _ := bestJourney(journeys, compareLatestDeparture)
+	assert.Equal(t, []*Flight{direct}, ld.Flights)
+
+	fl, _, _ := bestJourney(journeys, compareFewestLegs)
+	assert.Equal(t, []*Flight{direct}, fl.Flights)
+
+	pareto := paretoJourneys(journeys)
+	require.Len(t, pareto, 2)
+	assert.Equal(t, []*Flight{direct}, pareto[0].Flights)
+	assert.Equal(t, []*Flight{feeder, fraJfk}, pareto[1].Flights)
+
+	_, ok, _ = bestJourney(nil, compareEarliestArrival)
+	assert.False(t, ok)
+}
+
+func TestScanJourneys_MatchesEnumeration(t *testing.T) {
+	ctx := context.Background()
+	flights := randomNetwork(1, 10, 25)
+	origins := []string{"A00"}
+	destinations := []string{"A01", "A02"}
+	minDeparture := testDay
+	maxDeparture := testDay.Add(12 * time.Hour)
+
+	var include Options
+	WithIncludeAirport("A03").Apply(&include)
+
+	for _, maxFlights := range []uint32{1, 2, 3} {
+		for _, countMultiLeg := range []bool{true, false} {
+			for _, predicates := range [][]flightPredicate{nil, include.any} {
+				t.Run(fmt.Sprintf("maxFlights=%d,countMultiLeg=%v,predicates=%d", maxFlights, countMultiLeg, len(predicates)), func(t *testing.T) {
+					conns, err := collectCtx(ctx, findConnections(ctx, flights, origins, destinations, minDeparture, maxDeparture, maxFlights, 45*time.Minute, 6*time.Hour, 18*time.Hour, nil, predicates, countMultiLeg, nil))
+					require.NoError(t, err)
+
+					expected := enumeratedJourneys(conns, nil, countMultiLeg)
+					actual, err := scanJourneys(ctx, flights, origins, destinations, minDeparture, maxDeparture, maxFlights, 45*time.Minute, 6*time.Hour, 18*time.Hour, nil, predicates, countMultiLeg)
+					require.NoError(t, err)
+
+					if maxFlights > 1 && len(predicates) < 1 {
+						require.NotEmpty(t, expected)
+					}
+
+					for name, compare := range map[string]func(a, b Journey) int{
+						"earliestArrival": compareEarliestArrival,
+						"latestDeparture": compareLatestDeparture,
+						"fewestLegs":      compareFewestLegs,
+					} {
+						e, eok, _ := bestJourney(expected, compare)
+						a, aok, _ := bestJourney(actual, compare)
+						require.Equal(t, eok, aok, name)
+
+						if eok {
+							assert.Zero(t, compare(e, a), name)
+						}
+					}
+
+					type point struct {
+						legs    uint32
+						arrival time.Time
+					}
+
+					toPoints := func(journeys []Journey) []point {
+						points := make([]point, 0, len(journeys))
+						for _, j := range journeys {
+							points = append(points, point{j.Legs, j.ArrivalTime()})
+						}
+
+						return points
+					}
+
+					assert.Equal(t, toPoints(paretoJourneys(expected)), toPoints(paretoJourneys(actual)))
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkRouting(b *testing.B) {
+	ctx := context.Background()
+	minDeparture := testDay
+	maxDeparture := testDay.Add(12 * time.Hour)
+
+	networks := []struct {
+		name         string
+		flights      map[Departure][]*Flight
+		origins      []string
+		destinations []string
+	}{
+		{"random", randomNetwork(2, 40, 30), []string{"A00"}, []string{"A01"}},
+		{"hub", hubNetwork(4, 30), []string{"S00"}, []string{"S01"}},
+	}
+
+	for _, n := range networks {
+		for _, maxFlights := range []uint32{2, 3} {
+			b.Run(fmt.Sprintf("%s/findConnections/maxFlights=%d", n.name, maxFlights), func(b *testing.B) {
+				for b.Loop() {
+					if _, err := collectCtx(ctx, findConnections(ctx, n.flights, n.origins, n.destinations, minDeparture, maxDeparture, maxFlights, 45*time.Minute, 6*time.Hour, 18*time.Hour, nil, nil, true, nil)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			b.Run(fmt.Sprintf("%s/scanJourneys/maxFlights=%d", n.name, maxFlights), func(b *testing.B) {
+				for b.Loop() {
+					if _, err := scanJourneys(ctx, n.flights, n.origins, n.destinations, minDeparture, maxDeparture, maxFlights, 45*time.Minute, 6*time.Hour, 18*time.Hour, nil, nil, true); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
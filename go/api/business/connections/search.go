@@ -74,7 +74,7 @@ func (pctx *predicateContext) globMatch(v, pattern string) bool {
 type flightPredicate func(pctx *predicateContext, f *Flight) bool
 
 type searchRepo interface {
-	Flights(ctx context.Context, start, end xtime.LocalDate) (map[xtime.LocalDate][]db.Flight, error)
+	Flights(ctx context.Context, start, end xtime.LocalDate, asOf *time.Time) (map[xtime.LocalDate][]db.Flight, error)
 	Airlines(ctx context.Context) (map[string]db.Airline, error)
 	Airports(ctx context.Context) (map[string]db.Airport, error)
 	Aircraft(ctx context.Context) (map[string]db.Aircraft, error)
@@ -82,6 +82,7 @@ type searchRepo interface {
 
 type Options struct {
 	countMultiLeg bool
+	asOf          *time.Time
 	all           []flightPredicate
 	any           []flightPredicate
 }
@@ -100,6 +101,17 @@ func NewSearch(repo searchRepo) *Search {
 }
 
 func (ch *Search) FindConnections(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) ([]Connection, error) {
+	conns, err := ch.StreamConnections(ctx, origins, destinations, minDeparture, maxDeparture, maxFlights, minLayover, maxLayover, maxDuration, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectCtx(ctx, conns)
+}
+
+// StreamConnections emits every connection as soon as all of its outgoing connections are known.
+// The channel is closed once the search is done or ctx is done.
+func (ch *Search) StreamConnections(ctx context.Context, origins, destinations []string, minDeparture, maxDeparture time.Time, maxFlights uint32, minLayover, maxLayover, maxDuration time.Duration, options ...SearchOption) (<-chan Connection, error) {
 	var f Options
 	for _, opt := range options {
 		opt.Apply(&f)
@@ -108,53 +120,12 @@ func (ch *Search) FindConnections(ctx context.Context, origins, destinations []s
 	minDate := xtime.NewLocalDate(minDeparture.UTC())
 	maxDate := xtime.NewLocalDate(maxDeparture.Add(maxDuration).UTC())
 
-	var pctx predicateContext
-	var flightsByDeparture map[Departure][]*Flight
-	{
-		var flightsByDate map[xtime.LocalDate][]db.Flight
-		var airlines map[string]db.Airline
-		var airports map[string]db.Airport
-		var aircraft map[string]db.Aircraft
-
-		g, ctx := errgroup.WithContext(ctx)
-		g.Go(func() error {
-			var err error
-			flightsByDate, err = ch.repo.Flights(ctx, minDate, maxDate)
-			return err
-		})
-
-		g.Go(func() error {
-			var err error
-			airlines, err = ch.repo.Airlines(ctx)
-			return err
-		})
-
-		g.Go(func() error {
-			var err error
-			airports, err = ch.repo.Airports(ctx)
-			return err
-		})
-
-		g.Go(func() error {
-			var err error
-			aircraft, err = ch.repo.Aircraft(ctx)
-			return err
-		})
-
-		if err := g.Wait(); err != nil {
-			return nil, err
-		}
-
-		pctx = predicateContext{
-			airlines: airlines,
-			airports: airports,
-			aircraft: aircraft,
-		}
-
-		flightsByDeparture = mapAndGroupByDepartureUTC(&pctx, flightsByDate, f.all)
+	flightsByDeparture, pctx, err := ch.loadFlights(ctx, minDate, maxDate, f)
+	if err != nil {
+		return nil, err
 	}
 
-	return collectCtx(ctx, findConnections(
+	return findConnections(
 		ctx,
 		flightsByDeparture,
 		origins,
@@ -165,11 +136,56 @@ func (ch *Search) FindConnections(ctx context.Context, origins, destinations []s
 		minLayover,
 		maxLayover,
 		maxDuration,
-		&pctx,
+		pctx,
 		f.any,
 		f.countMultiLeg,
 		nil,
-	))
+	), nil
+}
+
+// loadFlights loads the flights departing (UTC) within [minDate, maxDate] matching all predicates of f, grouped by departure
+func (ch *Search) loadFlights(ctx context.Context, minDate, maxDate xtime.LocalDate, f Options) (map[Departure][]*Flight, *predicateContext, error) {
+	var flightsByDate map[xtime.LocalDate][]db.Flight
+	var airlines map[string]db.Airline
+	var airports map[string]db.Airport
+	var aircraft map[string]db.Aircraft
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		flightsByDate, err = ch.repo.Flights(ctx, minDate, maxDate, f.asOf)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		airlines, err = ch.repo.Airlines(ctx)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		airports, err = ch.repo.Airports(ctx)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		aircraft, err = ch.repo.Aircraft(ctx)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	pctx := &predicateContext{
+		airlines: airlines,
+		airports: airports,
+		aircraft: aircraft,
+	}
+
+	return mapAndGroupByDepartureUTC(pctx, flightsByDate, f.all), pctx, nil
 }
 
 func findConnections(
@@ -234,9 +250,7 @@ func findConnections(
 						}
 					}
 
-					// J = regular flight
-					// U = Rail&Fly
-					if (f.ServiceType != "J" && f.ServiceType != "U") || (maxFlights < 1 && !sameFlightNumber) || f.Duration() > maxDuration || f.DepartureTime.Compare(minDeparture) < 0 || f.DepartureTime.Compare(maxDeparture) > 0 {
+					if !isScheduledService(f) || (maxFlights < 1 && !sameFlightNumber) || f.Duration() > maxDuration || f.DepartureTime.Compare(minDeparture) < 0 || f.DepartureTime.Compare(maxDeparture) > 0 {
 						continue
 					}
 
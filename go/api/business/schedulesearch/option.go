@@ -124,17 +124,27 @@ func WithIgnoreCodeShares() Condition {
 	}}
 }
 
+// WithMinDepartureTime matches flights departing at or after the given time. The redundant condition
+// on the local departure date (at most one day off the UTC departure date) allows duckdb to skip
+// row groups of flight_variant_history which it can not do for the computed departure time alone.
 func WithMinDepartureTime(minDepartureTime time.Time) Condition {
 	return Condition{db.BaseCondition{
-		Filter: "(fvh.departure_date_local + fv.departure_time_local - TO_SECONDS(fv.departure_utc_offset_seconds)) >= CAST(? AS TIMESTAMPTZ)",
-		Params: []any{minDepartureTime.UTC().Format(time.RFC3339)},
+		Filter: "fvh.departure_date_local >= CAST(? AS DATE) AND (fvh.departure_date_local + fv.departure_time_local - TO_SECONDS(fv.departure_utc_offset_seconds)) >= CAST(? AS TIMESTAMPTZ)",
+		Params: []any{
+			(xtime.NewLocalDate(minDepartureTime.UTC()) - 1).String(),
+			minDepartureTime.UTC().Format(time.RFC3339),
+		},
 	}}
 }
 
+// WithMaxDepartureTime matches flights departing at or before the given time. See WithMinDepartureTime.
 func WithMaxDepartureTime(maxDepartureTime time.Time) Condition {
 	return Condition{db.BaseCondition{
-		Filter: "(fvh.departure_date_local + fv.departure_time_local - TO_SECONDS(fv.departure_utc_offset_seconds)) <= CAST(? AS TIMESTAMPTZ)",
-		Params: []any{maxDepartureTime.UTC().Format(time.RFC3339)},
+		Filter: "fvh.departure_date_local <= CAST(? AS DATE) AND (fvh.departure_date_local + fv.departure_time_local - TO_SECONDS(fv.departure_utc_offset_seconds)) <= CAST(? AS TIMESTAMPTZ)",
+		Params: []any{
+			(xtime.NewLocalDate(maxDepartureTime.UTC()) + 1).String(),
+			maxDepartureTime.UTC().Format(time.RFC3339),
+		},
 	}}
 }
 
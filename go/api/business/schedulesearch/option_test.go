@@ -22,3 +22,13 @@ func TestWithDepartureDateRangeLocal(t *testing.T) {
 	)
 	assert.Equal(t, []any{"2026-01-01", "2027-01-01"}, params)
 }
+
+func TestWithDepartureTimeBoundsDepartureDateLocal(t *testing.T) {
+	departureTime := time.Date(2026, time.March, 1, 23, 30, 0, 0, time.FixedZone("", -2*60*60))
+
+	_, params := WithMinDepartureTime(departureTime).cond.Condition()
+	assert.Equal(t, []any{"2026-03-01", "2026-03-02T01:30:00Z"}, params)
+
+	_, params = WithMaxDepartureTime(departureTime).cond.Condition()
+	assert.Equal(t, []any{"2026-03-03", "2026-03-02T01:30:00Z"}, params)
+}
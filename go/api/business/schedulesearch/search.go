@@ -2,11 +2,13 @@ package schedulesearch
 
 import (
 	"context"
+	"time"
+
 	"github.com/explore-flights/monorepo/go/api/db"
 )
 
 type searchRepo interface {
-	FlightSchedulesLatestRaw(ctx context.Context, filter db.Condition) (db.FlightSchedulesMany, error)
+	FlightSchedulesLatestRaw(ctx context.Context, filter db.Condition, asOf *time.Time) (db.FlightSchedulesMany, error)
 }
 
 type Search struct {
@@ -17,6 +19,7 @@ func NewSearch(repo searchRepo) *Search {
 	return &Search{repo: repo}
 }
 
-func (s *Search) QuerySchedules(ctx context.Context, cond Condition) (db.FlightSchedulesMany, error) {
-	return s.repo.FlightSchedulesLatestRaw(ctx, cond.cond)
+// QuerySchedules returns the latest schedules matching cond, or the schedules as they were at asOf if set
+func (s *Search) QuerySchedules(ctx context.Context, cond Condition, asOf *time.Time) (db.FlightSchedulesMany, error) {
+	return s.repo.FlightSchedulesLatestRaw(ctx, cond.cond, asOf)
 }
@@ -0,0 +1,107 @@
+package schedulesearch
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/ssim"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ToSSIM converts the result of QuerySchedules to an SSIM file, created at creationDate
+func ToSSIM(result db.FlightSchedulesMany, creationDate xtime.LocalDate) *ssim.File {
+	return ssim.FromFlightSchedules(ToFlightSchedules(result), creationDate)
+}
+
+// ToFlightSchedules converts the result of QuerySchedules to one schedule per flight number, omitting cancelled flights
+func ToFlightSchedules(result db.FlightSchedulesMany) []*common.FlightSchedule {
+	type variantKey struct {
+		id                       uuid.UUID
+		departureAirportIataCode string
+	}
+
+	schedules := make([]*common.FlightSchedule, 0, len(result.Schedules))
+	for fn, items := range result.Schedules {
+		ranges := make(map[variantKey]xtime.LocalDateRanges)
+		for _, item := range items {
+			if !item.FlightVariantId.Valid {
+				continue
+			}
+
+			key := variantKey{item.FlightVariantId.V, item.DepartureAirportIataCode}
+			ranges[key] = ranges[key].Add(item.DepartureDateLocal)
+		}
+
+		if len(ranges) < 1 {
+			continue
+		}
+
+		fs := &common.FlightSchedule{
+			Airline:      common.AirlineIdentifier(fn.AirlineIataCode),
+			FlightNumber: fn.Number,
+			Suffix:       fn.Suffix,
+			Variants:     make([]*common.FlightScheduleVariant, 0, len(ranges)),
+		}
+
+		keys := slices.SortedFunc(maps.Keys(ranges), func(a, b variantKey) int {
+			return cmp.Or(
+				cmp.Compare(a.departureAirportIataCode, b.departureAirportIataCode),
+				cmp.Compare(a.id.String(), b.id.String()),
+			)
+		})
+
+		for _, key := range keys {
+			variant, ok := result.Variants[key.id]
+			if !ok {
+				continue
+			}
+
+			fs.Variants = append(fs.Variants, &common.FlightScheduleVariant{
+				Ranges: ranges[key],
+				Data:   flightScheduleData(variant, key.departureAirportIataCode),
+			})
+		}
+
+		schedules = append(schedules, fs)
+	}
+
+	slices.SortFunc(schedules, func(a, b *common.FlightSchedule) int {
+		return cmp.Compare(a.Number().String(), b.Number().String())
+	})
+
+	return schedules
+}
+
+func flightScheduleData(variant db.FlightScheduleVariant, departureAirportIataCode string) common.FlightScheduleData {
+	codeShares := make(common.Set[common.FlightNumber], len(variant.CodeShares))
+	for cs := range variant.CodeShares {
+		codeShares[flightNumber(cs)] = struct{}{}
+	}
+
+	return common.FlightScheduleData{
+		OperatedAs:                   flightNumber(variant.OperatedAs),
+		DepartureTime:                variant.DepartureTimeLocal,
+		DepartureAirport:             departureAirportIataCode,
+		DepartureUTCOffset:           int(variant.DepartureUtcOffsetSeconds),
+		DurationSeconds:              variant.DurationSeconds,
+		ArrivalAirport:               variant.ArrivalAirportIataCode,
+		ArrivalUTCOffset:             int(variant.ArrivalUtcOffsetSeconds),
+		ServiceType:                  variant.ServiceType,
+		AircraftOwner:                common.AirlineIdentifier(variant.AircraftOwner),
+		AircraftType:                 variant.AircraftIataCode,
+		AircraftConfigurationVersion: variant.AircraftConfigurationVersion,
+		CodeShares:                   codeShares,
+	}
+}
+
+func flightNumber(fn db.FlightNumber) common.FlightNumber {
+	return common.FlightNumber{
+		Airline: common.AirlineIdentifier(fn.AirlineIataCode),
+		Number:  fn.Number,
+		Suffix:  fn.Suffix,
+	}
+}
@@ -0,0 +1,62 @@
+package schedulesearch
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/ssim"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSSIM(t *testing.T) {
+	variantId := uuid.Must(uuid.FromString("00000000-0000-0000-0000-000000000001"))
+	lh400 := db.FlightNumber{AirlineIataCode: "LH", Number: 400}
+
+	result := db.FlightSchedulesMany{
+		Schedules: map[db.FlightNumber][]db.FlightScheduleItem{
+			lh400: {
+				{DepartureDateLocal: xtime.NewLocalDateFromParts(2026, time.June, 1), DepartureAirportIataCode: "FRA", FlightVariantId: sql.Null[uuid.UUID]{V: variantId, Valid: true}},
+				{DepartureDateLocal: xtime.NewLocalDateFromParts(2026, time.June, 8), DepartureAirportIataCode: "FRA", FlightVariantId: sql.Null[uuid.UUID]{V: variantId, Valid: true}},
+				// cancelled
+				{DepartureDateLocal: xtime.NewLocalDateFromParts(2026, time.June, 15), DepartureAirportIataCode: "FRA"},
+			},
+		},
+		Variants: map[uuid.UUID]db.FlightScheduleVariant{
+			variantId: {
+				Id:                           variantId,
+				OperatedAs:                   lh400,
+				DepartureTimeLocal:           xtime.MustParseLocalTime("10:00:00"),
+				DepartureUtcOffsetSeconds:    7200,
+				DurationSeconds:              32400,
+				ArrivalAirportIataCode:       "JFK",
+				ArrivalUtcOffsetSeconds:      -14400,
+				ServiceType:                  "J",
+				AircraftOwner:                "LH",
+				AircraftIataCode:             "359",
+				AircraftConfigurationVersion: "C48E21M224",
+				CodeShares:                   map[db.FlightNumber]struct{}{{AirlineIataCode: "UA", Number: 9051}: {}},
+			},
+		},
+	}
+
+	f := ToSSIM(result, xtime.NewLocalDateFromParts(2026, time.May, 1))
+	if !assert.Len(t, f.Carriers, 1) || !assert.Len(t, f.Carriers[0].Legs, 1) {
+		return
+	}
+
+	leg := f.Carriers[0].Legs[0]
+	assert.Equal(t, xtime.NewLocalDateFromParts(2026, time.June, 1), leg.PeriodFrom)
+	assert.Equal(t, xtime.NewLocalDateFromParts(2026, time.June, 8), leg.PeriodTo)
+	assert.Equal(t, [7]bool{time.Monday: true}, leg.DaysOfOperation)
+	assert.Equal(t, xtime.MustParseLocalTime("13:00:00"), leg.AircraftArrivalTime)
+	assert.Equal(t, []ssim.DataElement{{Id: ssim.DataElementCodeShares, Data: "UA 9051"}}, leg.DataElements)
+
+	var buf bytes.Buffer
+	assert.NoError(t, ssim.Write(&buf, f))
+	assert.Contains(t, buf.String(), "3 LH 04000101J01JUN2608JUN261      ")
+}
@@ -0,0 +1,313 @@
+package seatmap
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+)
+
+type Comparison struct {
+	A      ConfigurationSummary `json:"a"`
+	B      ConfigurationSummary `json:"b"`
+	Cabins []CabinComparison    `json:"cabins"`
+}
+
+type ConfigurationSummary struct {
+	AirlineIataCode              string         `json:"airlineIataCode"`
+	AircraftIataCode             string         `json:"aircraftIataCode"`
+	AircraftConfigurationVersion string         `json:"aircraftConfigurationVersion"`
+	Version                      time.Time      `json:"version"`
+	Seats                        int            `json:"seats"`
+	Lavatories                   int            `json:"lavatories"`
+	Galleys                      int            `json:"galleys"`
+	Cabins                       []CabinSummary `json:"cabins"`
+}
+
+type CabinSummary struct {
+	Deck       int      `json:"deck"`
+	CabinClass string   `json:"cabinClass"`
+	Rows       RowRange `json:"rows"`
+	Seats      int      `json:"seats"`
+	// Layout is the most common seat layout of all rows, e.g. 1-2-1 or 3-3-3
+	Layout string `json:"layout"`
+	// Layouts counts the rows per seat layout
+	Layouts    map[string]int `json:"layouts"`
+	Features   map[string]int `json:"features"`
+	Lavatories int            `json:"lavatories"`
+	Galleys    int            `json:"galleys"`
+}
+
+// CabinComparison compares the seats of a cabin class across all decks
+type CabinComparison struct {
+	CabinClass string   `json:"cabinClass"`
+	SeatsA     int      `json:"seatsA"`
+	SeatsB     int      `json:"seatsB"`
+	SeatsDelta int      `json:"seatsDelta"`
+	LayoutsA   []string `json:"layoutsA"`
+	LayoutsB   []string `json:"layoutsB"`
+	// FeaturesDelta is the difference of seats per seat characteristic (B - A), unchanged characteristics are omitted
+	FeaturesDelta map[string]int `json:"featuresDelta"`
+}
+
+// Compare compares the latest known seat maps of two configurations
+func (s *Search) Compare(ctx context.Context, a, b ConfigurationKey) (Comparison, error) {
+	summaryA, err := s.latestSummary(ctx, a)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	summaryB, err := s.latestSummary(ctx, b)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	return CompareSummaries(summaryA, summaryB), nil
+}
+
+func (s *Search) latestSummary(ctx context.Context, key ConfigurationKey) (ConfigurationSummary, error) {
+	version, ok, err := s.LatestVersion(ctx, key)
+	if err != nil {
+		return ConfigurationSummary{}, err
+	}
+
+	if !ok {
+		return ConfigurationSummary{}, ErrNotFound
+	}
+
+	sm, err := s.SeatMapVersion(ctx, key, version)
+	if err != nil {
+		return ConfigurationSummary{}, err
+	}
+
+	summary := Summarize(sm)
+	summary.AirlineIataCode = key.AirlineIataCode
+	summary.AircraftIataCode = key.AircraftIataCode
+	summary.AircraftConfigurationVersion = key.AircraftConfigurationVersion
+	summary.Version = version
+
+	return summary, nil
+}
+
+func Summarize(sm SeatMap) ConfigurationSummary {
+	summary := ConfigurationSummary{
+		Cabins: make([]CabinSummary, 0),
+	}
+
+	for deckIdx, deck := range sm.Decks {
+		if deck == nil {
+			continue
+		}
+
+		for _, cabin := range deck.Cabins {
+			cs := summarizeCabin(cabin)
+			cs.Deck = deckIdx
+
+			summary.Seats += cs.Seats
+			summary.Lavatories += cs.Lavatories
+			summary.Galleys += cs.Galleys
+			summary.Cabins = append(summary.Cabins, cs)
+		}
+	}
+
+	return summary
+}
+
+func CompareSummaries(a, b ConfigurationSummary) Comparison {
+	type cabinClassSummary struct {
+		seats    int
+		layouts  []string
+		features map[string]int
+	}
+
+	byCabinClass := func(summary ConfigurationSummary) map[string]*cabinClassSummary {
+		result := make(map[string]*cabinClassSummary)
+		for _, cs := range summary.Cabins {
+			ccs, ok := result[cs.CabinClass]
+			if !ok {
+				ccs = &cabinClassSummary{
+					layouts:  make([]string, 0),
+					features: make(map[string]int),
+				}
+				result[cs.CabinClass] = ccs
+			}
+
+			ccs.seats += cs.Seats
+			if cs.Layout != "" && !slices.Contains(ccs.layouts, cs.Layout) {
+				ccs.layouts = append(ccs.layouts, cs.Layout)
+			}
+
+			for feature, count := range cs.Features {
+				ccs.features[feature] += count
+			}
+		}
+
+		return result
+	}
+
+	cabinsA := byCabinClass(a)
+	cabinsB := byCabinClass(b)
+	empty := &cabinClassSummary{layouts: make([]string, 0)}
+
+	cabinClasses := slices.Collect(maps.Keys(cabinsA))
+	for cc := range cabinsB {
+		if !slices.Contains(cabinClasses, cc) {
+			cabinClasses = append(cabinClasses, cc)
+		}
+	}
+
+	slices.SortFunc(cabinClasses, compareCabinClasses)
+
+	c := Comparison{
+		A:      a,
+		B:      b,
+		Cabins: make([]CabinComparison, 0, len(cabinClasses)),
+	}
+
+	for _, cc := range cabinClasses {
+		ccA := cmp.Or(cabinsA[cc], empty)
+		ccB := cmp.Or(cabinsB[cc], empty)
+
+		featuresDelta := make(map[string]int)
+		for feature, count := range ccB.features {
+			featuresDelta[feature] += count
+		}
+
+		for feature, count := range ccA.features {
+			featuresDelta[feature] -= count
+		}
+
+		maps.DeleteFunc(featuresDelta, func(_ string, delta int) bool {
+			return delta == 0
+		})
+
+		c.Cabins = append(c.Cabins, CabinComparison{
+			CabinClass:    cc,
+			SeatsA:        ccA.seats,
+			SeatsB:        ccB.seats,
+			SeatsDelta:    ccB.seats - ccA.seats,
+			LayoutsA:      ccA.layouts,
+			LayoutsB:      ccB.layouts,
+			FeaturesDelta: featuresDelta,
+		})
+	}
+
+	return c
+}
+
+func summarizeCabin(cabin Cabin) CabinSummary {
+	cs := CabinSummary{
+		CabinClass: cabin.CabinClass,
+		Layouts:    make(map[string]int),
+		Features:   make(map[string]int),
+	}
+
+	countComponents := func(components [][]*Column) {
+		for _, line := range components {
+			for _, c := range line {
+				if c == nil {
+					continue
+				}
+
+				if slices.Contains(c.Features, string(lufthansa.ComponentCharacteristicLavatory)) {
+					cs.Lavatories++
+				}
+
+				if slices.Contains(c.Features, string(lufthansa.ComponentCharacteristicGalley)) {
+					cs.Galleys++
+				}
+			}
+		}
+	}
+
+	for _, row := range cabin.Rows {
+		countComponents(row.Front)
+		countComponents(row.Rear)
+
+		seats := 0
+		for _, col := range row.Seats {
+			if col == nil || col.Type != "seat" {
+				continue
+			}
+
+			seats++
+			for _, feature := range col.Features {
+				cs.Features[feature]++
+			}
+		}
+
+		if seats < 1 {
+			continue
+		}
+
+		if cs.Rows[0] == 0 {
+			cs.Rows[0] = row.Number
+		}
+
+		cs.Rows[1] = row.Number
+		cs.Seats += seats
+		cs.Layouts[rowLayout(cabin, row)]++
+	}
+
+	maxRows := 0
+	for _, layout := range slices.Sorted(maps.Keys(cs.Layouts)) {
+		if rows := cs.Layouts[layout]; rows > maxRows {
+			cs.Layout = layout
+			maxRows = rows
+		}
+	}
+
+	return cs
+}
+
+// rowLayout counts the seats between the aisles of a row, e.g. 2-4-2
+func rowLayout(cabin Cabin, row Row) string {
+	blocks := make([]string, 0, len(cabin.Aisle)+1)
+	seats := 0
+
+	for colIdx, col := range row.Seats {
+		if col != nil && col.Type == "seat" {
+			seats++
+		}
+
+		if cabin.Aisle.Contains(colIdx) || colIdx == len(row.Seats)-1 {
+			if seats > 0 {
+				blocks = append(blocks, strconv.Itoa(seats))
+			}
+
+			seats = 0
+		}
+	}
+
+	return strings.Join(blocks, "-")
+}
+
+func compareCabinClasses(a, b string) int {
+	idx := func(cc string) int {
+		switch cc {
+		case "FIRST":
+			return 0
+
+		case "BIZ":
+			return 1
+
+		case "PRECO":
+			return 2
+
+		case "ECO":
+			return 3
+		}
+
+		return 4
+	}
+
+	return cmp.Or(
+		cmp.Compare(idx(a), idx(b)),
+		cmp.Compare(a, b),
+	)
+}
@@ -0,0 +1,59 @@
+package seatmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	biz := testCabin("BIZ", []string{"A", "D", "G", "K"}, map[int][]string{
+		1: {"A", "D", "G", "K"},
+		2: {"A", "D", "G", "K"},
+		3: {"A", "K"},
+	})
+	biz.Aisle = map[int]struct{}{0: {}, 2: {}}
+	biz.Rows[0].Front = [][]*Column{{{Type: "component", Features: []string{"G"}}, {Type: "component", Features: []string{"LA"}}}}
+
+	eco := testCabin("ECO", []string{"A", "B", "C", "D", "E", "F"}, map[int][]string{
+		20: {"A", "B", "C", "D", "E", "F"},
+		21: {"A", "B", "C", "D", "E", "F"},
+	})
+	eco.Aisle = map[int]struct{}{2: {}}
+	eco.Rows[1].Rear = [][]*Column{{{Type: "component", Features: []string{"LA"}}}}
+
+	summary := Summarize(testSeatMap(biz, eco))
+	assert.Equal(t, 22, summary.Seats)
+	assert.Equal(t, 2, summary.Lavatories)
+	assert.Equal(t, 1, summary.Galleys)
+	require.Len(t, summary.Cabins, 2)
+
+	assert.Equal(t, "1-2-1", summary.Cabins[0].Layout)
+	assert.Equal(t, map[string]int{"1-2-1": 2, "1-1": 1}, summary.Cabins[0].Layouts)
+	assert.Equal(t, RowRange{1, 3}, summary.Cabins[0].Rows)
+	assert.Equal(t, "3-3", summary.Cabins[1].Layout)
+	assert.Equal(t, 12, summary.Cabins[1].Features["9"])
+}
+
+func TestCompareSummaries(t *testing.T) {
+	a := ConfigurationSummary{Cabins: []CabinSummary{
+		{CabinClass: "ECO", Seats: 200, Layout: "3-3-3", Features: map[string]int{"L": 10}},
+		{CabinClass: "BIZ", Seats: 48, Layout: "1-2-1", Features: map[string]int{}},
+	}}
+	b := ConfigurationSummary{Cabins: []CabinSummary{
+		{CabinClass: "ECO", Seats: 180, Layout: "3-3-3", Features: map[string]int{"L": 12}},
+		{CabinClass: "PRECO", Seats: 24, Layout: "2-3-2", Features: map[string]int{}},
+		{CabinClass: "BIZ", Seats: 38, Layout: "1-2-1", Features: map[string]int{}},
+		{CabinClass: "FIRST", Seats: 4, Layout: "1-1", Features: map[string]int{}},
+	}}
+
+	c := CompareSummaries(a, b)
+	require.Len(t, c.Cabins, 4)
+	assert.Equal(t, []string{"FIRST", "BIZ", "PRECO", "ECO"}, []string{c.Cabins[0].CabinClass, c.Cabins[1].CabinClass, c.Cabins[2].CabinClass, c.Cabins[3].CabinClass})
+	assert.Equal(t, 4, c.Cabins[0].SeatsDelta)
+	assert.Empty(t, c.Cabins[0].LayoutsA)
+	assert.Equal(t, -10, c.Cabins[1].SeatsDelta)
+	assert.Equal(t, -20, c.Cabins[3].SeatsDelta)
+	assert.Equal(t, map[string]int{"L": 2}, c.Cabins[3].FeaturesDelta)
+}
@@ -0,0 +1,304 @@
+package seatmap
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Diff struct {
+	From                time.Time    `json:"from"`
+	To                  time.Time    `json:"to"`
+	CabinClassesAdded   []string     `json:"cabinClassesAdded"`
+	CabinClassesRemoved []string     `json:"cabinClassesRemoved"`
+	RowShifts           []RowShift   `json:"rowShifts"`
+	SeatsAdded          []SeatDiff   `json:"seatsAdded"`
+	SeatsRemoved        []SeatDiff   `json:"seatsRemoved"`
+	SeatsChanged        []SeatChange `json:"seatsChanged"`
+}
+
+type SeatPosition struct {
+	Deck       int    `json:"deck"`
+	CabinClass string `json:"cabinClass"`
+	Row        int    `json:"row"`
+	Column     string `json:"column"`
+}
+
+type SeatDiff struct {
+	SeatPosition
+	Features []string `json:"features"`
+}
+
+// SeatChange describes a seat which exists in both versions (taking row shifts into account) but changed its cabin class or features
+type SeatChange struct {
+	From            SeatPosition `json:"from"`
+	To              SeatPosition `json:"to"`
+	FeaturesAdded   []string     `json:"featuresAdded"`
+	FeaturesRemoved []string     `json:"featuresRemoved"`
+}
+
+// RowShift describes rows of a cabin which kept their layout but were renumbered by Offset
+type RowShift struct {
+	Deck       int      `json:"deck"`
+	CabinClass string   `json:"cabinClass"`
+	From       RowRange `json:"from"`
+	To         RowRange `json:"to"`
+	Offset     int      `json:"offset"`
+}
+
+type seatKey struct {
+	deck   int
+	row    int
+	column string
+}
+
+type seatInfo struct {
+	cabinClass string
+	features   []string
+}
+
+type cabinKey struct {
+	deck       int
+	cabinClass string
+}
+
+// Changed reports whether the diff contains any change
+func (d Diff) Changed() bool {
+	return len(d.CabinClassesAdded) > 0 ||
+		len(d.CabinClassesRemoved) > 0 ||
+		len(d.RowShifts) > 0 ||
+		len(d.SeatsAdded) > 0 ||
+		len(d.SeatsRemoved) > 0 ||
+		len(d.SeatsChanged) > 0
+}
+
+func DiffSeatMaps(from, to SeatMap) Diff {
+	d := Diff{
+		CabinClassesAdded:   make([]string, 0),
+		CabinClassesRemoved: make([]string, 0),
+		RowShifts:           make([]RowShift, 0),
+		SeatsAdded:          make([]SeatDiff, 0),
+		SeatsRemoved:        make([]SeatDiff, 0),
+		SeatsChanged:        make([]SeatChange, 0),
+	}
+
+	for cc := range to.CabinClasses {
+		if !from.CabinClasses.Contains(cc) {
+			d.CabinClassesAdded = append(d.CabinClassesAdded, cc)
+		}
+	}
+
+	for cc := range from.CabinClasses {
+		if !to.CabinClasses.Contains(cc) {
+			d.CabinClassesRemoved = append(d.CabinClassesRemoved, cc)
+		}
+	}
+
+	slices.Sort(d.CabinClassesAdded)
+	slices.Sort(d.CabinClassesRemoved)
+
+	fromSeats, fromRows := collectSeats(from)
+	toSeats, toRows := collectSeats(to)
+
+	// rows of the previous version which moved to a different row number
+	shiftedRows := make(map[cabinKey]map[int]int)
+	for ck, rows := range fromRows {
+		otherRows, ok := toRows[ck]
+		if !ok {
+			continue
+		}
+
+		offset, matched := findRowOffset(rows, otherRows)
+		if offset == 0 || len(matched) < 1 {
+			continue
+		}
+
+		shiftedRows[ck] = make(map[int]int, len(matched))
+		for _, row := range matched {
+			shiftedRows[ck][row] = row + offset
+		}
+
+		d.RowShifts = append(d.RowShifts, RowShift{
+			Deck:       ck.deck,
+			CabinClass: ck.cabinClass,
+			From:       RowRange{matched[0], matched[len(matched)-1]},
+			To:         RowRange{matched[0] + offset, matched[len(matched)-1] + offset},
+			Offset:     offset,
+		})
+	}
+
+	matchedToSeats := make(map[seatKey]struct{})
+	for _, fromKey := range slices.SortedFunc(maps.Keys(fromSeats), compareSeatKeys) {
+		fromSeat := fromSeats[fromKey]
+		toKey := fromKey
+		if row, ok := shiftedRows[cabinKey{fromKey.deck, fromSeat.cabinClass}][fromKey.row]; ok {
+			toKey.row = row
+		}
+
+		toSeat, ok := toSeats[toKey]
+		if _, matched := matchedToSeats[toKey]; !ok || matched {
+			d.SeatsRemoved = append(d.SeatsRemoved, SeatDiff{
+				SeatPosition: fromKey.position(fromSeat),
+				Features:     fromSeat.features,
+			})
+
+			continue
+		}
+
+		matchedToSeats[toKey] = struct{}{}
+
+		added, removed := diffFeatures(fromSeat.features, toSeat.features)
+		if fromSeat.cabinClass != toSeat.cabinClass || len(added) > 0 || len(removed) > 0 {
+			d.SeatsChanged = append(d.SeatsChanged, SeatChange{
+				From:            fromKey.position(fromSeat),
+				To:              toKey.position(toSeat),
+				FeaturesAdded:   added,
+				FeaturesRemoved: removed,
+			})
+		}
+	}
+
+	for _, toKey := range slices.SortedFunc(maps.Keys(toSeats), compareSeatKeys) {
+		if _, ok := matchedToSeats[toKey]; ok {
+			continue
+		}
+
+		toSeat := toSeats[toKey]
+		d.SeatsAdded = append(d.SeatsAdded, SeatDiff{
+			SeatPosition: toKey.position(toSeat),
+			Features:     toSeat.features,
+		})
+	}
+
+	slices.SortFunc(d.RowShifts, func(a, b RowShift) int {
+		return cmp.Or(
+			cmp.Compare(a.Deck, b.Deck),
+			cmp.Compare(a.From[0], b.From[0]),
+		)
+	})
+
+	return d
+}
+
+// collectSeats returns all seats by position and the layout of every row per cabin
+func collectSeats(sm SeatMap) (map[seatKey]seatInfo, map[cabinKey]map[int]string) {
+	seats := make(map[seatKey]seatInfo)
+	rows := make(map[cabinKey]map[int]string)
+
+	for deckIdx, deck := range sm.Decks {
+		if deck == nil {
+			continue
+		}
+
+		for _, cabin := range deck.Cabins {
+			ck := cabinKey{deckIdx, cabin.CabinClass}
+			if rows[ck] == nil {
+				rows[ck] = make(map[int]string)
+			}
+
+			for _, row := range cabin.Rows {
+				var layout strings.Builder
+				hasSeats := false
+				for colIdx, col := range row.Seats {
+					if col == nil || col.Type != "seat" || colIdx >= len(cabin.SeatColumns) {
+						layout.WriteString("_")
+						continue
+					}
+
+					column := cabin.SeatColumns[colIdx]
+					layout.WriteString(column)
+					hasSeats = true
+
+					features := slices.Clone(col.Features)
+					slices.Sort(features)
+
+					seats[seatKey{deckIdx, row.Number, column}] = seatInfo{
+						cabinClass: cabin.CabinClass,
+						features:   slices.Compact(features),
+					}
+				}
+
+				if hasSeats {
+					rows[ck][row.Number] = layout.String()
+				}
+			}
+		}
+	}
+
+	return seats, rows
+}
+
+// findRowOffset finds the renumbering which keeps the most rows with an identical layout.
+// It returns 0 unless an offset matches more rows than keeping the row numbers.
+func findRowOffset(from, to map[int]string) (int, []int) {
+	matches := func(offset int) []int {
+		matched := make([]int, 0)
+		for row, layout := range from {
+			if other, ok := to[row+offset]; ok && other == layout {
+				matched = append(matched, row)
+			}
+		}
+
+		slices.Sort(matched)
+		return matched
+	}
+
+	bestOffset := 0
+	bestMatched := matches(0)
+
+	candidates := make(map[int]struct{})
+	for fromRow := range from {
+		for toRow := range to {
+			if offset := toRow - fromRow; offset != 0 {
+				candidates[offset] = struct{}{}
+			}
+		}
+	}
+
+	for _, offset := range slices.Sorted(maps.Keys(candidates)) {
+		if matched := matches(offset); len(matched) > len(bestMatched) {
+			bestOffset = offset
+			bestMatched = matched
+		}
+	}
+
+	return bestOffset, bestMatched
+}
+
+func diffFeatures(from, to []string) ([]string, []string) {
+	added := make([]string, 0)
+	removed := make([]string, 0)
+
+	for _, f := range to {
+		if !slices.Contains(from, f) {
+			added = append(added, f)
+		}
+	}
+
+	for _, f := range from {
+		if !slices.Contains(to, f) {
+			removed = append(removed, f)
+		}
+	}
+
+	return added, removed
+}
+
+func (k seatKey) position(info seatInfo) SeatPosition {
+	return SeatPosition{
+		Deck:       k.deck,
+		CabinClass: info.cabinClass,
+		Row:        k.row,
+		Column:     k.column,
+	}
+}
+
+func compareSeatKeys(a, b seatKey) int {
+	return cmp.Or(
+		cmp.Compare(a.deck, b.deck),
+		cmp.Compare(a.row, b.row),
+		cmp.Compare(a.column, b.column),
+	)
+}
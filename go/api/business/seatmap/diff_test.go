@@ -0,0 +1,122 @@
+package seatmap
+
+import (
+	"testing"
+
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCabin(cabinClass string, columns []string, rows map[int][]string) Cabin {
+	c := Cabin{
+		CabinClass:  cabinClass,
+		SeatColumns: columns,
+		Rows:        make([]Row, 0, len(rows)),
+	}
+
+	for number := range 100 {
+		rowColumns, ok := rows[number]
+		if !ok {
+			continue
+		}
+
+		row := Row{Number: number, Seats: make([]*Column, len(columns))}
+		for i, col := range columns {
+			for _, rc := range rowColumns {
+				if rc == col {
+					row.Seats[i] = &Column{Type: "seat", Features: []string{"9"}}
+				}
+			}
+		}
+
+		c.Rows = append(c.Rows, row)
+	}
+
+	return c
+}
+
+func testSeatMap(cabins ...Cabin) SeatMap {
+	sm := SeatMap{
+		CabinClasses: make(common.Set[string]),
+		Decks:        []*Deck{{Cabins: cabins}},
+	}
+
+	for _, c := range cabins {
+		sm.CabinClasses.Add(c.CabinClass)
+	}
+
+	return sm
+}
+
+func TestDiffSeatMapsUnchanged(t *testing.T) {
+	sm := testSeatMap(testCabin("M", []string{"A", "B", "C"}, map[int][]string{
+		10: {"A", "B", "C"},
+		11: {"A", "C"},
+	}))
+
+	d := DiffSeatMaps(sm, sm)
+	assert.Empty(t, d.CabinClassesAdded)
+	assert.Empty(t, d.CabinClassesRemoved)
+	assert.Empty(t, d.RowShifts)
+	assert.Empty(t, d.SeatsAdded)
+	assert.Empty(t, d.SeatsRemoved)
+	assert.Empty(t, d.SeatsChanged)
+}
+
+func TestDiffSeatMapsRowShift(t *testing.T) {
+	from := testSeatMap(
+		testCabin("C", []string{"A", "D"}, map[int][]string{1: {"A", "D"}, 2: {"A", "D"}}),
+		testCabin("M", []string{"A", "B", "C"}, map[int][]string{
+			10: {"A", "B", "C"},
+			11: {"A", "C"},
+			12: {"A", "B", "C"},
+		}),
+	)
+
+	// one business row added, economy renumbered
+	to := testSeatMap(
+		testCabin("C", []string{"A", "D"}, map[int][]string{1: {"A", "D"}, 2: {"A", "D"}, 3: {"A", "D"}}),
+		testCabin("M", []string{"A", "B", "C"}, map[int][]string{
+			11: {"A", "B", "C"},
+			12: {"A", "C"},
+			13: {"A", "B", "C"},
+		}),
+	)
+
+	d := DiffSeatMaps(from, to)
+	assert.Equal(t, []RowShift{{Deck: 0, CabinClass: "M", From: RowRange{10, 12}, To: RowRange{11, 13}, Offset: 1}}, d.RowShifts)
+	assert.Equal(t, []SeatDiff{
+		{SeatPosition: SeatPosition{CabinClass: "C", Row: 3, Column: "A"}, Features: []string{"9"}},
+		{SeatPosition: SeatPosition{CabinClass: "C", Row: 3, Column: "D"}, Features: []string{"9"}},
+	}, d.SeatsAdded)
+	assert.Empty(t, d.SeatsRemoved)
+	assert.Empty(t, d.SeatsChanged)
+}
+
+func TestDiffSeatMapsSeatsAndFeatures(t *testing.T) {
+	from := testSeatMap(testCabin("M", []string{"A", "B", "C"}, map[int][]string{
+		10: {"A", "B", "C"},
+		11: {"A", "B", "C"},
+	}))
+
+	to := testSeatMap(testCabin("E", []string{"A", "B", "C"}, map[int][]string{
+		10: {"A", "C"},
+		11: {"A", "B", "C"},
+	}))
+	to.Decks[0].Cabins[0].Rows[1].Seats[0].Features = []string{"9", "E"}
+
+	d := DiffSeatMaps(from, to)
+	assert.Equal(t, []string{"E"}, d.CabinClassesAdded)
+	assert.Equal(t, []string{"M"}, d.CabinClassesRemoved)
+	assert.Empty(t, d.RowShifts)
+	assert.Empty(t, d.SeatsAdded)
+	assert.Equal(t, []SeatDiff{{SeatPosition: SeatPosition{CabinClass: "M", Row: 10, Column: "B"}, Features: []string{"9"}}}, d.SeatsRemoved)
+
+	if assert.Len(t, d.SeatsChanged, 5) {
+		c := d.SeatsChanged[2]
+		assert.Equal(t, SeatPosition{CabinClass: "M", Row: 11, Column: "A"}, c.From)
+		assert.Equal(t, SeatPosition{CabinClass: "E", Row: 11, Column: "A"}, c.To)
+		assert.Equal(t, []string{"E"}, c.FeaturesAdded)
+		assert.Empty(t, c.FeaturesRemoved)
+	}
+}
@@ -0,0 +1,242 @@
+package seatmap
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/seatmapstore"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+// seat maps of a configuration are fetched again once the latest snapshot has not been confirmed for this long
+const snapshotRefreshInterval = time.Hour * 24 * 7
+
+type ConfigurationKey = seatmapstore.ConfigurationKey
+
+type Version struct {
+	// Version is the time this layout was fetched first
+	Version time.Time `json:"version"`
+	// CheckedAt is the last time this layout was fetched and found unchanged
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type snapshot = seatmapstore.Snapshot
+
+// Versions lists all known seat map versions of a configuration, oldest first
+func (s *Search) Versions(ctx context.Context, key ConfigurationKey) ([]Version, error) {
+	versions, err := s.listVersions(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Version, 0, len(versions))
+	for _, version := range versions {
+		sn, err := s.loadSnapshot(ctx, key, version)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, Version{
+			Version:   version,
+			CheckedAt: sn.LastChecked(),
+		})
+	}
+
+	return result, nil
+}
+
+// LatestVersion returns the latest seat map version of a configuration
+func (s *Search) LatestVersion(ctx context.Context, key ConfigurationKey) (time.Time, bool, error) {
+	versions, err := s.listVersions(ctx, key)
+	if err != nil || len(versions) < 1 {
+		return time.Time{}, false, err
+	}
+
+	return versions[len(versions)-1], true, nil
+}
+
+func (s *Search) SeatMapVersion(ctx context.Context, key ConfigurationKey, version time.Time) (SeatMap, error) {
+	sn, err := s.loadSnapshot(ctx, key, version)
+	if err != nil {
+		return SeatMap{}, err
+	}
+
+	return s.normalizeSeatMaps(sn.CabinClasses), nil
+}
+
+// Diff compares the seat maps of two versions of a configuration
+func (s *Search) Diff(ctx context.Context, key ConfigurationKey, from, to time.Time) (Diff, error) {
+	fromSeatMap, err := s.SeatMapVersion(ctx, key, from)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	toSeatMap, err := s.SeatMapVersion(ctx, key, to)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	d := DiffSeatMaps(fromSeatMap, toSeatMap)
+	d.From = from.UTC()
+	d.To = to.UTC()
+
+	return d, nil
+}
+
+// latestSnapshot returns the latest snapshot of the configuration, falling back to the unversioned seat maps if no version has been stored yet.
+// If it is missing or outdated and the flight has not departed yet, the seat maps are fetched again and stored as a new version if their layout changed.
+func (s *Search) latestSnapshot(ctx context.Context, key ConfigurationKey, departureDateLocal xtime.LocalDate, fetch func(ctx context.Context) (map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability, error)) (snapshot, error) {
+	version, ok, err := s.LatestVersion(ctx, key)
+	if err != nil {
+		return snapshot{}, err
+	}
+
+	var latest *snapshot
+	var legacy bool
+	if ok {
+		sn, err := s.loadSnapshot(ctx, key, version)
+		if err != nil {
+			return snapshot{}, err
+		}
+
+		latest = &sn
+	} else {
+		sn, ok, err := seatmapstore.LoadLegacySnapshot(ctx, s.s3c, s.bucket, key)
+		if err != nil {
+			return snapshot{}, err
+		}
+
+		if ok {
+			latest = &sn
+			legacy = true
+		}
+	}
+
+	canFetch := departureDateLocal > xtime.NewLocalDate(time.Now().UTC())
+	if latest != nil && (!canFetch || time.Since(latest.LastChecked()) < snapshotRefreshInterval) {
+		return *latest, nil
+	} else if !canFetch {
+		return snapshot{}, ErrNotFound
+	}
+
+	cabinClasses, err := fetch(ctx)
+	if err != nil {
+		if latest != nil {
+			return *latest, nil
+		}
+
+		return snapshot{}, err
+	}
+
+	if len(cabinClasses) < 1 {
+		if latest != nil {
+			return *latest, nil
+		}
+
+		return snapshot{CabinClasses: cabinClasses}, nil
+	}
+
+	now := seatmapstore.NewVersion(time.Now())
+	if latest != nil {
+		changed, err := s.layoutChanged(latest.CabinClasses, cabinClasses)
+		if err != nil {
+			return snapshot{}, err
+		}
+
+		if !changed {
+			latest.CheckedAt = now
+			if err = adapt.S3PutJson(ctx, s.s3c, s.bucket, seatmapstore.VersionKey(key, latest.Version), latest); err != nil {
+				return snapshot{}, err
+			}
+
+			return *latest, nil
+		}
+
+		// keep the unversioned seat maps as the version preceding the new one
+		if legacy {
+			if err = adapt.S3PutJson(ctx, s.s3c, s.bucket, seatmapstore.VersionKey(key, latest.Version), latest); err != nil {
+				return snapshot{}, err
+			}
+		}
+	}
+
+	sn := snapshot{
+		Version:      now,
+		CheckedAt:    now,
+		CabinClasses: cabinClasses,
+	}
+
+	if err = adapt.S3PutJson(ctx, s.s3c, s.bucket, seatmapstore.VersionKey(key, sn.Version), sn); err != nil {
+		return snapshot{}, err
+	}
+
+	return sn, nil
+}
+
+func (s *Search) listVersions(ctx context.Context, key ConfigurationKey) ([]time.Time, error) {
+	paginator := s3.NewListObjectsV2Paginator(s.s3c, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(seatmapstore.VersionsPrefix(key)),
+	})
+
+	versions := make([]time.Time, 0)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range resp.Contents {
+			if version, ok := seatmapstore.ParseVersionKey(*obj.Key); ok {
+				versions = append(versions, version)
+			}
+		}
+	}
+
+	slices.SortFunc(versions, time.Time.Compare)
+
+	return versions, nil
+}
+
+func (s *Search) loadSnapshot(ctx context.Context, key ConfigurationKey, version time.Time) (snapshot, error) {
+	var sn snapshot
+	if err := adapt.S3GetJson(ctx, s.s3c, s.bucket, seatmapstore.VersionKey(key, version), &sn); err != nil {
+		if adapt.IsS3NotFound(err) {
+			return snapshot{}, ErrNotFound
+		}
+
+		return snapshot{}, err
+	}
+
+	return sn, nil
+}
+
+// layoutChanged compares the normalized seat maps, since the raw ones also describe the flight they were fetched for
+func (s *Search) layoutChanged(a, b map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability) (bool, error) {
+	// normalizing modifies the raw seat maps
+	var aCopy, bCopy map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability
+	if err := cloneJson(a, &aCopy); err != nil {
+		return false, err
+	}
+
+	if err := cloneJson(b, &bCopy); err != nil {
+		return false, err
+	}
+
+	return DiffSeatMaps(s.normalizeSeatMaps(aCopy), s.normalizeSeatMaps(bCopy)).Changed(), nil
+}
+
+func cloneJson[T any](v T, out *T) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,156 @@
+//go:build !lambda
+
+package seatmap
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/seatmapstore"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSnapshotVersionsLayoutChangesOnly(t *testing.T) {
+	s, _ := newTestSearch(t)
+	ctx := t.Context()
+
+	_, err := s.SeatMap(ctx, db.FlightNumber{AirlineIataCode: "LH", Number: 100}, "FRA", testDepartureDate)
+	require.NoError(t, err)
+
+	fetchedKey := ConfigurationKey{
+		AirlineIataCode:              "LH",
+		AircraftIataCode:             "320",
+		AircraftConfigurationVersion: "C12M156",
+	}
+
+	fetchedVersion, _, err := s.LatestVersion(ctx, fetchedKey)
+	require.NoError(t, err)
+
+	// store the fetched seat maps as an older version of another configuration
+	key := ConfigurationKey{
+		AirlineIataCode:              "LH",
+		AircraftIataCode:             "320",
+		AircraftConfigurationVersion: "TEST",
+	}
+
+	sn, err := s.loadSnapshot(ctx, fetchedKey, fetchedVersion)
+	require.NoError(t, err)
+	require.NotEmpty(t, sn.CabinClasses)
+
+	sn.Version = seatmapstore.NewVersion(time.Now().Add(-snapshotRefreshInterval * 4))
+	require.NoError(t, adapt.S3PutJson(ctx, s.s3c, s.bucket, seatmapstore.VersionKey(key, sn.Version), sn))
+
+	versions, err := s.Versions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+
+	// outdate the snapshot so the seat maps are fetched again
+	outdate := func() {
+		sn, err := s.loadSnapshot(ctx, key, versions[0].Version)
+		require.NoError(t, err)
+
+		sn.CheckedAt = time.Now().Add(-snapshotRefreshInterval * 2)
+		require.NoError(t, adapt.S3PutJson(ctx, s.s3c, s.bucket, seatmapstore.VersionKey(key, sn.Version), sn))
+	}
+
+	fetched := func(cabinClasses map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability) func(ctx context.Context) (map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability, error) {
+		return func(ctx context.Context) (map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability, error) {
+			return cabinClasses, nil
+		}
+	}
+
+	// the same layout fetched for another flight
+	otherFlight := maps.Clone(sn.CabinClasses)
+	for cabinClass, sm := range otherFlight {
+		sm.Flights = json.RawMessage(`{"Flight":{"Departure":{"AirportCode":"FRA","ScheduledTimeLocal":{"DateTime":"2099-06-02T07:00"}},"MarketingCarrier":{"AirlineID":"LH","FlightNumber":"102"}}}`)
+		otherFlight[cabinClass] = sm
+	}
+
+	outdate()
+	checkedBefore := time.Now().Add(-time.Minute)
+	latest, err := s.latestSnapshot(ctx, key, testDepartureDate, fetched(otherFlight))
+	require.NoError(t, err)
+	assert.Equal(t, versions[0].Version, latest.Version)
+
+	versions, err = s.Versions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.True(t, versions[0].CheckedAt.After(checkedBefore))
+
+	// a removed cabin class changes the layout
+	changed := maps.Clone(sn.CabinClasses)
+	for cabinClass := range changed {
+		delete(changed, cabinClass)
+		break
+	}
+
+	outdate()
+	latest, err = s.latestSnapshot(ctx, key, testDepartureDate, fetched(changed))
+	require.NoError(t, err)
+
+	versions, err = s.Versions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, versions[1].Version, latest.Version)
+
+	latestVersion, ok, err := s.LatestVersion(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, versions[1].Version, latestVersion)
+}
+
+func TestLatestSnapshotFallsBackToLegacySeatMaps(t *testing.T) {
+	s, _ := newTestSearch(t)
+	ctx := t.Context()
+
+	_, err := s.SeatMap(ctx, db.FlightNumber{AirlineIataCode: "LH", Number: 100}, "FRA", testDepartureDate)
+	require.NoError(t, err)
+
+	fetchedKey := ConfigurationKey{
+		AirlineIataCode:              "LH",
+		AircraftIataCode:             "320",
+		AircraftConfigurationVersion: "C12M156",
+	}
+
+	fetchedVersion, _, err := s.LatestVersion(ctx, fetchedKey)
+	require.NoError(t, err)
+
+	sn, err := s.loadSnapshot(ctx, fetchedKey, fetchedVersion)
+	require.NoError(t, err)
+	require.NotEmpty(t, sn.CabinClasses)
+
+	// store the fetched seat maps unversioned for another configuration
+	key := ConfigurationKey{
+		AirlineIataCode:              "LH",
+		AircraftIataCode:             "320",
+		AircraftConfigurationVersion: "LEGACY",
+	}
+
+	for cabinClass, sm := range sn.CabinClasses {
+		require.NoError(t, adapt.S3PutJson(ctx, s.s3c, s.bucket, seatmapstore.LegacyKey(key, cabinClass), sm))
+	}
+
+	notFetched := func(ctx context.Context) (map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability, error) {
+		t.Fatal("departed flights must not be fetched")
+		return nil, nil
+	}
+
+	departed := xtime.NewLocalDateFromParts(2020, time.January, 1)
+	latest, err := s.latestSnapshot(ctx, key, departed, notFetched)
+	require.NoError(t, err)
+	assert.Equal(t, slices.Sorted(maps.Keys(sn.CabinClasses)), slices.Sorted(maps.Keys(latest.CabinClasses)))
+	assert.False(t, latest.Version.IsZero())
+
+	// without seat maps of either kind, departed flights are not found
+	_, err = s.latestSnapshot(ctx, ConfigurationKey{AirlineIataCode: "LH", AircraftIataCode: "320", AircraftConfigurationVersion: "MISSING"}, departed, notFetched)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -93,3 +93,9 @@ func (rr RowRanges) Expand(other RowRanges) RowRanges {
 
 	return result
 }
+
+func (rr RowRanges) Contains(row int) bool {
+	return slices.ContainsFunc(rr, func(r RowRange) bool {
+		return row >= r[0] && row <= r[1]
+	})
+}
@@ -0,0 +1,258 @@
+package seatmap
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+)
+
+var ErrUnknownProfile = errors.New("unknown scoring profile")
+
+type ScoringProfile string
+
+const (
+	ScoringProfileLegroom      = ScoringProfile("legroom")
+	ScoringProfileQuietFront   = ScoringProfile("quiet")
+	ScoringProfileWindowNoWing = ScoringProfile("window")
+)
+
+type ScoredCabin struct {
+	Deck       int          `json:"deck"`
+	CabinClass string       `json:"cabinClass"`
+	Seats      []ScoredSeat `json:"seats"`
+}
+
+type ScoredSeat struct {
+	Row      int      `json:"row"`
+	Column   string   `json:"column"`
+	Score    float64  `json:"score"`
+	Features []string `json:"features"`
+	// Reasons lists the seat properties which contributed to the score
+	Reasons []string `json:"reasons"`
+}
+
+type seatProperty string
+
+const (
+	seatPropertyWindow              = seatProperty("window")
+	seatPropertyWindowWithoutWindow = seatProperty("windowWithoutWindow")
+	seatPropertyAisle               = seatProperty("aisle")
+	seatPropertyMiddle              = seatProperty("middle")
+	seatPropertyLegroom             = seatProperty("legroom")
+	seatPropertyBulkhead            = seatProperty("bulkhead")
+	seatPropertyExitRow             = seatProperty("exitRow")
+	seatPropertyWingView            = seatProperty("wingView")
+	seatPropertyRestrictedRecline   = seatProperty("restrictedRecline")
+	seatPropertyQuietZone           = seatProperty("quietZone")
+	seatPropertyNearFacilities      = seatProperty("nearFacilities")
+	seatPropertyFront               = seatProperty("front")
+)
+
+// scoringProfiles weights every seat property; seatPropertyFront is scaled by the relative position of the row within its cabin
+var scoringProfiles = map[ScoringProfile]map[seatProperty]float64{
+	ScoringProfileLegroom: {
+		seatPropertyLegroom:           3,
+		seatPropertyExitRow:           3,
+		seatPropertyBulkhead:          2,
+		seatPropertyAisle:             1,
+		seatPropertyMiddle:            -1,
+		seatPropertyRestrictedRecline: -1,
+		seatPropertyNearFacilities:    -0.5,
+	},
+	ScoringProfileQuietFront: {
+		seatPropertyFront:             3,
+		seatPropertyQuietZone:         2,
+		seatPropertyWindow:            0.5,
+		seatPropertyMiddle:            -1,
+		seatPropertyRestrictedRecline: -0.5,
+		seatPropertyNearFacilities:    -2,
+	},
+	ScoringProfileWindowNoWing: {
+		seatPropertyWindow:              3,
+		seatPropertyFront:               1,
+		seatPropertyWindowWithoutWindow: -4,
+		seatPropertyWingView:            -2,
+		seatPropertyMiddle:              -1,
+		seatPropertyNearFacilities:      -0.5,
+	},
+}
+
+func ParseScoringProfile(raw string) (ScoringProfile, error) {
+	profile := ScoringProfile(raw)
+	if _, ok := scoringProfiles[profile]; !ok {
+		return "", ErrUnknownProfile
+	}
+
+	return profile, nil
+}
+
+// ScoreSeats scores all seats of the seat map for the given profile. Seats of every cabin are sorted best first.
+func ScoreSeats(sm SeatMap, profile ScoringProfile) ([]ScoredCabin, error) {
+	weights, ok := scoringProfiles[profile]
+	if !ok {
+		return nil, ErrUnknownProfile
+	}
+
+	result := make([]ScoredCabin, 0)
+	for deckIdx, deck := range sm.Decks {
+		if deck == nil {
+			continue
+		}
+
+		for _, cabin := range deck.Cabins {
+			result = append(result, ScoredCabin{
+				Deck:       deckIdx,
+				CabinClass: cabin.CabinClass,
+				Seats:      scoreCabin(deck, cabin, weights),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func scoreCabin(deck *Deck, cabin Cabin, weights map[seatProperty]float64) []ScoredSeat {
+	facilityRows := facilityRows(cabin)
+	seatRows := make([]int, 0, len(cabin.Rows))
+	for _, row := range cabin.Rows {
+		if slices.ContainsFunc(row.Seats, func(c *Column) bool { return c != nil }) {
+			seatRows = append(seatRows, row.Number)
+		}
+	}
+
+	seats := make([]ScoredSeat, 0)
+	for _, row := range cabin.Rows {
+		front := 1.0
+		if len(seatRows) > 1 {
+			front = 1 - float64(slices.Index(seatRows, row.Number))/float64(len(seatRows)-1)
+		}
+
+		for colIdx, col := range row.Seats {
+			if col == nil || col.Type != "seat" || colIdx >= len(cabin.SeatColumns) {
+				continue
+			}
+
+			properties := seatProperties(deck, row.Number, col, facilityRows)
+			seat := ScoredSeat{
+				Row:      row.Number,
+				Column:   cabin.SeatColumns[colIdx],
+				Features: col.Features,
+				Reasons:  make([]string, 0),
+			}
+
+			for _, property := range properties {
+				if w := weights[property]; w != 0 {
+					seat.Score += w
+					seat.Reasons = append(seat.Reasons, string(property))
+				}
+			}
+
+			if w := weights[seatPropertyFront]; w != 0 && front > 0 {
+				seat.Score += w * front
+				seat.Reasons = append(seat.Reasons, string(seatPropertyFront))
+			}
+
+			seat.Score = math.Round(seat.Score*100) / 100
+			seats = append(seats, seat)
+		}
+	}
+
+	slices.SortStableFunc(seats, func(a, b ScoredSeat) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.Row, b.Row),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+
+	return seats
+}
+
+func seatProperties(deck *Deck, row int, col *Column, facilityRows common.Set[int]) []seatProperty {
+	has := func(sc lufthansa.SeatCharacteristic) bool {
+		return slices.Contains(col.Features, string(sc))
+	}
+
+	properties := make([]seatProperty, 0)
+	if has(lufthansa.SeatCharacteristicWindowWithoutWindow) {
+		properties = append(properties, seatPropertyWindowWithoutWindow)
+	} else if has(lufthansa.SeatCharacteristicWindow) || has(lufthansa.SeatCharacteristicWindowAndAisleTogether) {
+		properties = append(properties, seatPropertyWindow)
+
+		if has(lufthansa.SeatCharacteristicOverwing) || deck.WingPosition.Contains(row) {
+			properties = append(properties, seatPropertyWingView)
+		}
+	}
+
+	if has(lufthansa.SeatCharacteristicAisle) || has(lufthansa.SeatCharacteristicWindowAndAisleTogether) {
+		properties = append(properties, seatPropertyAisle)
+	}
+
+	if has(lufthansa.SeatCharacteristicCenter) {
+		properties = append(properties, seatPropertyMiddle)
+	}
+
+	if has(lufthansa.SeatCharacteristicLegSpace) {
+		properties = append(properties, seatPropertyLegroom)
+	}
+
+	if has(lufthansa.SeatCharacteristicBulkhead) {
+		properties = append(properties, seatPropertyBulkhead)
+	}
+
+	if has(lufthansa.SeatCharacteristicExitRow) || deck.ExitRowPosition.Contains(row) {
+		properties = append(properties, seatPropertyExitRow)
+	}
+
+	if has(lufthansa.SeatCharacteristicRestrictedRecline) {
+		properties = append(properties, seatPropertyRestrictedRecline)
+	}
+
+	if has(lufthansa.SeatCharacteristicQuietZone) {
+		properties = append(properties, seatPropertyQuietZone)
+	}
+
+	if facilityRows.Contains(row) {
+		properties = append(properties, seatPropertyNearFacilities)
+	}
+
+	return properties
+}
+
+// facilityRows returns the rows next to a galley or lavatory
+func facilityRows(cabin Cabin) common.Set[int] {
+	isFacility := func(components [][]*Column) bool {
+		for _, line := range components {
+			for _, c := range line {
+				if c != nil && (slices.Contains(c.Features, string(lufthansa.ComponentCharacteristicGalley)) || slices.Contains(c.Features, string(lufthansa.ComponentCharacteristicLavatory))) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	rows := make(common.Set[int])
+	for i, row := range cabin.Rows {
+		if isFacility(row.Front) {
+			rows.Add(row.Number)
+			if i > 0 {
+				rows.Add(cabin.Rows[i-1].Number)
+			}
+		}
+
+		if isFacility(row.Rear) {
+			rows.Add(row.Number)
+			if i+1 < len(cabin.Rows) {
+				rows.Add(cabin.Rows[i+1].Number)
+			}
+		}
+	}
+
+	return rows
+}
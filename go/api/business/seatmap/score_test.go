@@ -0,0 +1,70 @@
+package seatmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScoringSeatMap() SeatMap {
+	cabin := testCabin("ECO", []string{"A", "B", "C"}, map[int][]string{
+		10: {"A", "B", "C"},
+		11: {"A", "B", "C"},
+		12: {"A", "B", "C"},
+	})
+
+	for _, row := range cabin.Rows {
+		row.Seats[0].Features = []string{"W"}
+		row.Seats[1].Features = []string{"9"}
+		row.Seats[2].Features = []string{"A"}
+	}
+
+	cabin.Rows[1].Seats[2].Features = []string{"A", "L"}
+	cabin.Rows[2].Rear = [][]*Column{{{Type: "component", Features: []string{"LA"}}}}
+
+	sm := testSeatMap(cabin)
+	sm.Decks[0].WingPosition = RowRanges{{10, 10}}
+	sm.Decks[0].ExitRowPosition = RowRanges{{11, 11}}
+
+	return sm
+}
+
+func TestScoreSeatsUnknownProfile(t *testing.T) {
+	_, err := ScoreSeats(testScoringSeatMap(), "unknown")
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+}
+
+func TestScoreSeatsLegroom(t *testing.T) {
+	cabins, err := ScoreSeats(testScoringSeatMap(), ScoringProfileLegroom)
+	require.NoError(t, err)
+	require.Len(t, cabins, 1)
+	require.Len(t, cabins[0].Seats, 9)
+
+	best := cabins[0].Seats[0]
+	assert.Equal(t, 11, best.Row)
+	assert.Equal(t, "C", best.Column)
+	assert.ElementsMatch(t, []string{"aisle", "legroom", "exitRow"}, best.Reasons)
+	assert.Equal(t, 7.0, best.Score)
+}
+
+func TestScoreSeatsWindowNoWing(t *testing.T) {
+	cabins, err := ScoreSeats(testScoringSeatMap(), ScoringProfileWindowNoWing)
+	require.NoError(t, err)
+
+	best := cabins[0].Seats[0]
+	assert.Equal(t, 11, best.Row)
+	assert.Equal(t, "A", best.Column)
+	assert.ElementsMatch(t, []string{"window", "front"}, best.Reasons)
+}
+
+func TestScoreSeatsQuietFront(t *testing.T) {
+	cabins, err := ScoreSeats(testScoringSeatMap(), ScoringProfileQuietFront)
+	require.NoError(t, err)
+
+	seats := cabins[0].Seats
+	assert.Equal(t, 10, seats[0].Row)
+	assert.Equal(t, "A", seats[0].Column)
+	assert.Equal(t, 12, seats[len(seats)-1].Row)
+	assert.Contains(t, seats[len(seats)-1].Reasons, "nearFacilities")
+}
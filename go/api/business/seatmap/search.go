@@ -3,20 +3,17 @@ package seatmap
 import (
 	"cmp"
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"maps"
 	"net/http"
 	"slices"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/explore-flights/monorepo/go/api/db"
 	"github.com/explore-flights/monorepo/go/common"
 	"github.com/explore-flights/monorepo/go/common/adapt"
 	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/seatmapstore"
 	"github.com/explore-flights/monorepo/go/common/xtime"
 )
 
@@ -32,6 +29,7 @@ type Search struct {
 	s3c interface {
 		adapt.S3Getter
 		adapt.S3Putter
+		adapt.S3Lister
 	}
 	bucket string
 	repo   searchRepo
@@ -41,6 +39,7 @@ type Search struct {
 func NewSearch(s3c interface {
 	adapt.S3Getter
 	adapt.S3Putter
+	adapt.S3Lister
 }, bucket string, repo searchRepo, lhc *lufthansa.Client) *Search {
 	return &Search{
 		s3c:    s3c,
@@ -98,69 +97,43 @@ func (s *Search) loadSeatMap(ctx context.Context, fn db.FlightNumber, departureA
 		arrivalAirport = airports[variant.ArrivalAirportIataCode]
 	}
 
-	rawSeatMaps := make(map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability)
-	cabinClasses := []lufthansa.RequestCabinClass{
-		lufthansa.RequestCabinClassEco,
-		lufthansa.RequestCabinClassPremiumEco,
-		lufthansa.RequestCabinClassBusiness,
-		lufthansa.RequestCabinClassFirst,
-	}
-
-	for _, cabinClass := range cabinClasses {
-		sm, err := s.loadSeatMapInternal(ctx, fn, airline, departureAirport, arrivalAirport, departureDateLocal, variant, cabinClass)
-		if err != nil {
-			return SeatMap{}, err
-		}
+	key := ConfigurationKey{
+		AirlineIataCode:              airline.IataCode,
+		AircraftIataCode:             variant.AircraftIataCode,
+		AircraftConfigurationVersion: variant.AircraftConfigurationVersion,
+	}
+
+	snapshot, err := s.latestSnapshot(ctx, key, departureDateLocal, func(ctx context.Context) (map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability, error) {
+		rawSeatMaps := make(map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability)
+		for _, cabinClass := range seatmapstore.CabinClasses {
+			sm, err := s.loadSeatMapFromLH(
+				ctx,
+				common.FlightNumber{
+					Airline: common.AirlineIdentifier(airline.IataCode),
+					Number:  fn.Number,
+					Suffix:  fn.Suffix,
+				},
+				departureAirport.IataCode,
+				arrivalAirport.IataCode,
+				departureDateLocal,
+				cabinClass,
+			)
+			if err != nil {
+				return nil, err
+			}
 
-		if sm != nil {
-			rawSeatMaps[cabinClass] = *sm
+			if sm != nil {
+				rawSeatMaps[cabinClass] = *sm
+			}
 		}
-	}
-
-	return s.normalizeSeatMaps(rawSeatMaps), nil
-}
-
-func (s *Search) loadSeatMapInternal(ctx context.Context, fn db.FlightNumber, airline db.Airline, departureAirport, arrivalAirport db.Airport, departureDateLocal xtime.LocalDate, variant db.FlightScheduleVariant, cabinClass lufthansa.RequestCabinClass) (*lufthansa.SeatAvailability, error) {
-	s3Key := s.seatMapS3Key(
-		airline.IataCode,
-		variant.AircraftIataCode,
-		variant.AircraftConfigurationVersion,
-		cabinClass,
-	)
-
-	sm, exists, err := s.loadSeatMapFromS3(ctx, s3Key)
-	if err != nil {
-		return nil, err
-	}
-
-	if exists {
-		return sm, nil
-	}
-
-	today := xtime.NewLocalDate(time.Now().UTC())
-	if departureDateLocal <= today {
-		return nil, ErrNotFound
-	}
 
-	sm, err = s.loadSeatMapFromLH(
-		ctx,
-		common.FlightNumber{
-			Airline: common.AirlineIdentifier(airline.IataCode),
-			Number:  fn.Number,
-			Suffix:  fn.Suffix,
-		},
-		departureAirport.IataCode,
-		arrivalAirport.IataCode,
-		departureDateLocal,
-		cabinClass,
-	)
+		return rawSeatMaps, nil
+	})
 	if err != nil {
-		return nil, err
+		return SeatMap{}, err
 	}
 
-	_ = adapt.S3PutJson(ctx, s.s3c, s.bucket, s3Key, sm)
-
-	return sm, nil
+	return s.normalizeSeatMaps(snapshot.CabinClasses), nil
 }
 
 func (s *Search) loadSeatMapFromLH(ctx context.Context, fn common.FlightNumber, departureAirport, arrivalAirport string, departureDate xtime.LocalDate, cabinClass lufthansa.RequestCabinClass) (*lufthansa.SeatAvailability, error) {
@@ -185,34 +158,6 @@ func (s *Search) loadSeatMapFromLH(ctx context.Context, fn common.FlightNumber,
 	return &sm, nil
 }
 
-func (s *Search) loadSeatMapFromS3(ctx context.Context, s3Key string) (*lufthansa.SeatAvailability, bool, error) {
-	resp, err := s.s3c.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(s.bucket),
-		Key:    aws.String(s3Key),
-	})
-
-	if err != nil {
-		if adapt.IsS3NotFound(err) {
-			return nil, false, nil
-		} else {
-			return nil, false, err
-		}
-	}
-
-	defer resp.Body.Close()
-
-	var sm *lufthansa.SeatAvailability
-	if err := json.NewDecoder(resp.Body).Decode(&sm); err != nil {
-		return nil, false, err
-	}
-
-	return sm, true, nil
-}
-
-func (s *Search) seatMapS3Key(airlineIataCode, aircraftIataCode, aircraftConfigurationVersion string, cabinClass lufthansa.RequestCabinClass) string {
-	return fmt.Sprintf("tmp/seatmap/%s/%s/%s/%s.json", airlineIataCode, aircraftIataCode, aircraftConfigurationVersion, cabinClass)
-}
-
 func (s *Search) deduplicateSeatMaps(rawSeatMaps map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability) {
 	type Key struct {
 		cc   lufthansa.RequestCabinClass
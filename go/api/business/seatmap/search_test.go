@@ -0,0 +1,140 @@
+//go:build !lambda
+
+package seatmap
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/local"
+	"github.com/explore-flights/monorepo/go/common/lufthansa/lhtest"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+type fakeSearchRepo struct {
+	variants map[db.FlightNumber]db.FlightScheduleVariant
+}
+
+func (r fakeSearchRepo) FlightSchedules(ctx context.Context, fn db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedules, error) {
+	fs := db.FlightSchedules{
+		Items:    make([]db.FlightScheduleItem, 0),
+		Variants: make(map[uuid.UUID]db.FlightScheduleVariant),
+	}
+
+	if variant, ok := r.variants[fn]; ok {
+		fs.Items = append(fs.Items, db.FlightScheduleItem{
+			DepartureDateLocal:       testDepartureDate,
+			DepartureAirportIataCode: "FRA",
+			FlightVariantId:          sql.Null[uuid.UUID]{V: variant.Id, Valid: true},
+		})
+		fs.Variants[variant.Id] = variant
+	}
+
+	return fs, nil
+}
+
+func (r fakeSearchRepo) Airlines(ctx context.Context) (map[string]db.Airline, error) {
+	return map[string]db.Airline{
+		"LH": {IataCode: "LH", IcaoCode: sql.NullString{String: "DLH", Valid: true}, Name: "Lufthansa"},
+	}, nil
+}
+
+func (r fakeSearchRepo) Airports(ctx context.Context) (map[string]db.Airport, error) {
+	return map[string]db.Airport{
+		"FRA": {IataCode: "FRA"},
+		"MUC": {IataCode: "MUC"},
+		"JFK": {IataCode: "JFK"},
+	}, nil
+}
+
+// far enough in the future for the seat maps to always be fetched
+var testDepartureDate = xtime.NewLocalDateFromParts(2099, time.June, 1)
+
+func newTestSearch(t *testing.T) (*Search, *lhtest.Server) {
+	fixtures, err := lhtest.LoadFixtures(os.DirFS(filepath.Join("testdata", "lh")))
+	require.NoError(t, err)
+
+	lhs := lhtest.NewServer(fixtures)
+	t.Cleanup(lhs.Close)
+
+	repo := fakeSearchRepo{
+		variants: map[db.FlightNumber]db.FlightScheduleVariant{
+			{AirlineIataCode: "LH", Number: 100}: {
+				Id:                           uuid.Must(uuid.NewV4()),
+				ArrivalAirportIataCode:       "MUC",
+				AircraftIataCode:             "320",
+				AircraftConfigurationVersion: "C12M156",
+			},
+			{AirlineIataCode: "LH", Number: 400}: {
+				Id:                           uuid.Must(uuid.NewV4()),
+				ArrivalAirportIataCode:       "JFK",
+				AircraftIataCode:             "359",
+				AircraftConfigurationVersion: "C40E21M252",
+			},
+		},
+	}
+
+	return NewSearch(local.NewS3Client(t.TempDir()), "bucket", repo, lhs.Client()), lhs
+}
+
+func TestSearchSeatMapGolden(t *testing.T) {
+	for name, fn := range map[string]db.FlightNumber{
+		"LH100": {AirlineIataCode: "LH", Number: 100},
+		"LH400": {AirlineIataCode: "LH", Number: 400},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, lhs := newTestSearch(t)
+			ctx := t.Context()
+
+			sm, err := s.SeatMap(ctx, fn, "FRA", testDepartureDate)
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join("testdata", "golden", name+".json")
+			if *updateGolden {
+				b, err := json.MarshalIndent(sm, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenPath), 0750))
+				require.NoError(t, os.WriteFile(goldenPath, append(b, '\n'), 0644))
+			}
+
+			b, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+
+			var expected SeatMap
+			require.NoError(t, json.Unmarshal(b, &expected))
+
+			// compare through json to ignore differences between nil and empty
+			actualJson, err := json.Marshal(sm)
+			require.NoError(t, err)
+
+			var actual SeatMap
+			require.NoError(t, json.Unmarshal(actualJson, &actual))
+			assert.Equal(t, expected, actual)
+
+			// the second lookup is served from the stored snapshot
+			requests := len(lhs.Requests())
+			_, err = s.SeatMap(ctx, fn, "FRA", testDepartureDate)
+			require.NoError(t, err)
+			assert.Len(t, lhs.Requests(), requests)
+		})
+	}
+}
+
+func TestSearchSeatMapNotFound(t *testing.T) {
+	s, _ := newTestSearch(t)
+
+	_, err := s.SeatMap(t.Context(), db.FlightNumber{AirlineIataCode: "LH", Number: 1}, "FRA", testDepartureDate)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
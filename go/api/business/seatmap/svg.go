@@ -0,0 +1,285 @@
+package seatmap
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+)
+
+const (
+	svgPadding         = 16
+	svgSeatSize        = 28
+	svgSeatGap         = 4
+	svgAisleWidth      = 20
+	svgRowLabelWidth   = 28
+	svgSideMarkerWidth = 6
+	svgComponentHeight = 20
+	svgTitleHeight     = 28
+	svgCabinGap        = 12
+	svgDeckGap         = 32
+)
+
+const svgStyle = `text{font-family:sans-serif;font-size:11px;text-anchor:middle;dominant-baseline:central}` +
+	`.title{font-size:14px;font-weight:bold;text-anchor:start}` +
+	`.cabin{font-size:12px;font-weight:bold}` +
+	`.row-label{fill:#666}` +
+	`.seat{stroke:#333;stroke-width:1}` +
+	`.seat-FIRST{fill:#e8d9a8}` +
+	`.seat-BIZ{fill:#a8c4e8}` +
+	`.seat-PRECO{fill:#b8e0c0}` +
+	`.seat-ECO{fill:#e0e0e0}` +
+	`.seat.highlight{fill:#f5a623;stroke:#b36b00;stroke-width:2}` +
+	`.component{fill:#fff;stroke:#999;stroke-dasharray:3 2}` +
+	`.component-label{fill:#666;font-size:9px}` +
+	`.wing{fill:#9aa7b5}` +
+	`.exit{fill:#d0021b}`
+
+var componentLabels = map[string]string{
+	string(lufthansa.ComponentCharacteristicAirphone):          "Phone",
+	string(lufthansa.ComponentCharacteristicBar):               "Bar",
+	string(lufthansa.ComponentCharacteristicBulkhead):          "Bulkhead",
+	string(lufthansa.ComponentCharacteristicCloset):            "Closet",
+	string(lufthansa.ComponentCharacteristicExitDoor):          "Door",
+	string(lufthansa.ComponentCharacteristicEmergencyExit):     "Exit",
+	string(lufthansa.ComponentCharacteristicGalley):            "Galley",
+	string(lufthansa.ComponentCharacteristicLavatory):          "WC",
+	string(lufthansa.ComponentCharacteristicLuggageStorage):    "Luggage",
+	string(lufthansa.ComponentCharacteristicMovieScreen):       "Screen",
+	string(lufthansa.ComponentCharacteristicStorageSpace):      "Storage",
+	string(lufthansa.ComponentCharacteristicStairsToUpperDeck): "Stairs",
+	string(lufthansa.ComponentCharacteristicTable):             "Table",
+}
+
+type RenderOptions struct {
+	highlightFeatures []string
+}
+
+type RenderOption interface {
+	Apply(o *RenderOptions)
+}
+
+// WithHighlightFeatures highlights all seats having at least one of the given seat characteristics
+type WithHighlightFeatures []string
+
+func (a WithHighlightFeatures) Apply(o *RenderOptions) {
+	o.highlightFeatures = append(o.highlightFeatures, a...)
+}
+
+// RenderSVG draws all decks of the seat map below each other, front of the aircraft on top
+func RenderSVG(w io.Writer, sm SeatMap, opts ...RenderOption) error {
+	var o RenderOptions
+	for _, opt := range opts {
+		opt.Apply(&o)
+	}
+
+	r := svgRenderer{
+		opts:  o,
+		width: svgContentWidth(sm),
+	}
+
+	y := svgPadding
+	for i, deck := range sm.Decks {
+		if deck == nil {
+			continue
+		}
+
+		if y > svgPadding {
+			y += svgDeckGap
+		}
+
+		y = r.renderDeck(i, deck, y)
+	}
+
+	height := y + svgPadding
+	width := r.width + svgPadding*2 + svgRowLabelWidth*2
+
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d"><style>%s</style>`, width, height, width, height, svgStyle); err != nil {
+		return err
+	}
+
+	if _, err := r.body.WriteTo(w); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "</svg>")
+	return err
+}
+
+type svgRenderer struct {
+	opts  RenderOptions
+	width int
+	body  bytes.Buffer
+}
+
+func (r *svgRenderer) renderDeck(idx int, deck *Deck, y int) int {
+	title := "Main Deck"
+	if idx > 0 {
+		title = "Upper Deck"
+	}
+
+	r.printf(`<text class="title" x="%d" y="%d">%s</text>`, svgPadding, y+svgTitleHeight/2, title)
+	y += svgTitleHeight
+
+	for i, cabin := range deck.Cabins {
+		if i > 0 {
+			y += svgCabinGap
+		}
+
+		y = r.renderCabin(deck, cabin, y)
+	}
+
+	return y
+}
+
+func (r *svgRenderer) renderCabin(deck *Deck, cabin Cabin, y int) int {
+	cabinWidth := svgCabinWidth(cabin)
+	left := svgPadding + svgRowLabelWidth + (r.width-cabinWidth)/2
+	centerX := svgPadding + svgRowLabelWidth + r.width/2
+
+	r.printf(`<text class="cabin" x="%d" y="%d">%s</text>`, centerX, y+svgTitleHeight/2, html.EscapeString(cabin.CabinClass))
+	y += svgTitleHeight
+
+	for _, row := range cabin.Rows {
+		for _, components := range row.Front {
+			y = r.renderComponents(cabin, components, left, cabinWidth, y)
+		}
+
+		if slices.ContainsFunc(row.Seats, func(c *Column) bool { return c != nil }) {
+			r.renderSideMarkers(deck, row.Number, y)
+			r.printf(`<text class="row-label" x="%d" y="%d">%d</text>`, svgPadding+svgRowLabelWidth/2, y+svgSeatSize/2, row.Number)
+			r.printf(`<text class="row-label" x="%d" y="%d">%d</text>`, svgPadding+svgRowLabelWidth+r.width+svgRowLabelWidth/2, y+svgSeatSize/2, row.Number)
+
+			for colIdx, col := range row.Seats {
+				if col == nil || colIdx >= len(cabin.SeatColumns) {
+					continue
+				}
+
+				r.renderSeat(cabin, row.Number, cabin.SeatColumns[colIdx], col, left+svgSeatOffset(cabin, colIdx), y)
+			}
+
+			y += svgSeatSize + svgSeatGap
+		}
+
+		for _, components := range row.Rear {
+			y = r.renderComponents(cabin, components, left, cabinWidth, y)
+		}
+	}
+
+	return y
+}
+
+func (r *svgRenderer) renderSeat(cabin Cabin, row int, column string, col *Column, x, y int) {
+	class := "seat seat-" + cabin.CabinClass
+	if slices.ContainsFunc(col.Features, func(f string) bool { return slices.Contains(r.opts.highlightFeatures, f) }) {
+		class += " highlight"
+	}
+
+	title := fmt.Sprintf("%d%s", row, column)
+	if len(col.Features) > 0 {
+		title += " (" + strings.Join(col.Features, ", ") + ")"
+	}
+
+	r.printf(
+		`<g><title>%s</title><rect class="%s" x="%d" y="%d" width="%d" height="%d" rx="4"/><text x="%d" y="%d">%s</text></g>`,
+		html.EscapeString(title),
+		html.EscapeString(class),
+		x, y, svgSeatSize, svgSeatSize,
+		x+svgSeatSize/2, y+svgSeatSize/2,
+		html.EscapeString(column),
+	)
+}
+
+// renderComponents draws one line of components, each component column taking an equal share of the cabin width
+func (r *svgRenderer) renderComponents(cabin Cabin, components []*Column, left, cabinWidth, y int) int {
+	if len(cabin.ComponentColumns) < 1 || !slices.ContainsFunc(components, func(c *Column) bool { return c != nil }) {
+		return y
+	}
+
+	slotWidth := cabinWidth / len(cabin.ComponentColumns)
+	for i, comp := range components {
+		if comp == nil || len(comp.Features) < 1 {
+			continue
+		}
+
+		label := componentLabels[comp.Features[0]]
+		if label == "" {
+			label = comp.Features[0]
+		}
+
+		x := left + i*slotWidth
+		r.printf(
+			`<g><title>%s</title><rect class="component" x="%d" y="%d" width="%d" height="%d" rx="2"/><text class="component-label" x="%d" y="%d">%s</text></g>`,
+			html.EscapeString(label),
+			x+1, y, slotWidth-2, svgComponentHeight,
+			x+slotWidth/2, y+svgComponentHeight/2,
+			html.EscapeString(label),
+		)
+	}
+
+	return y + svgComponentHeight + svgSeatGap
+}
+
+func (r *svgRenderer) renderSideMarkers(deck *Deck, row, y int) {
+	leftX := svgPadding + svgRowLabelWidth - svgSideMarkerWidth
+	rightX := svgPadding + svgRowLabelWidth + r.width
+
+	if deck.WingPosition.Contains(row) {
+		r.printf(`<rect class="wing" x="%d" y="%d" width="%d" height="%d"/>`, leftX, y-svgSeatGap/2, svgSideMarkerWidth, svgSeatSize+svgSeatGap)
+		r.printf(`<rect class="wing" x="%d" y="%d" width="%d" height="%d"/>`, rightX, y-svgSeatGap/2, svgSideMarkerWidth, svgSeatSize+svgSeatGap)
+	}
+
+	if deck.ExitRowPosition.Contains(row) {
+		r.printf(`<g><title>Exit row</title><polygon class="exit" points="%d,%d %d,%d %d,%d"/><polygon class="exit" points="%d,%d %d,%d %d,%d"/></g>`,
+			leftX, y,
+			leftX-svgSideMarkerWidth, y+svgSeatSize/2,
+			leftX, y+svgSeatSize,
+			rightX+svgSideMarkerWidth, y,
+			rightX+svgSideMarkerWidth*2, y+svgSeatSize/2,
+			rightX+svgSideMarkerWidth, y+svgSeatSize,
+		)
+	}
+}
+
+func (r *svgRenderer) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&r.body, format, args...)
+}
+
+// svgSeatOffset returns the x offset of a seat column within its cabin; aisles are placed right of the columns contained in Cabin.Aisle
+func svgSeatOffset(cabin Cabin, colIdx int) int {
+	offset := colIdx * (svgSeatSize + svgSeatGap)
+	for aisleIdx := range cabin.Aisle {
+		if aisleIdx < colIdx {
+			offset += svgAisleWidth
+		}
+	}
+
+	return offset
+}
+
+func svgCabinWidth(cabin Cabin) int {
+	if len(cabin.SeatColumns) < 1 {
+		return svgSeatSize * max(1, len(cabin.ComponentColumns))
+	}
+
+	return svgSeatOffset(cabin, len(cabin.SeatColumns)-1) + svgSeatSize
+}
+
+func svgContentWidth(sm SeatMap) int {
+	width := svgSeatSize
+	for _, deck := range sm.Decks {
+		if deck == nil {
+			continue
+		}
+
+		for _, cabin := range deck.Cabins {
+			width = max(width, svgCabinWidth(cabin))
+		}
+	}
+
+	return width
+}
@@ -0,0 +1,40 @@
+package seatmap
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSVG(t *testing.T) {
+	cabin := testCabin("ECO", []string{"A", "B", "C", "D"}, map[int][]string{
+		20: {"A", "B", "C", "D"},
+		21: {"A", "B", "C", "D"},
+	})
+	cabin.Aisle = common.Set[int]{1: {}}
+	cabin.ComponentColumns = []ColumnIdentifier{{Position: "L", Repeat: 1}, {Position: "R", Repeat: 1}}
+	cabin.Rows[0].Front = [][]*Column{{{Type: "component", Features: []string{"G"}}, {Type: "component", Features: []string{"LA"}}}}
+	cabin.Rows[1].Seats[0].Features = []string{"W", "E"}
+
+	sm := testSeatMap(cabin)
+	sm.Decks[0].WingPosition = RowRanges{{21, 21}}
+	sm.Decks[0].ExitRowPosition = RowRanges{{21, 21}}
+
+	var sb strings.Builder
+	require.NoError(t, RenderSVG(&sb, sm, WithHighlightFeatures{"E"}))
+
+	svg := sb.String()
+	require.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})))
+
+	assert.Equal(t, 8, strings.Count(svg, `<rect class="seat `))
+	assert.Equal(t, 1, strings.Count(svg, "highlight\""))
+	assert.Contains(t, svg, "<title>21A (W, E)</title>")
+	assert.Contains(t, svg, "<title>Galley</title>")
+	assert.Contains(t, svg, "<title>WC</title>")
+	assert.Equal(t, 2, strings.Count(svg, `class="wing"`))
+	assert.Contains(t, svg, "<title>Exit row</title>")
+}
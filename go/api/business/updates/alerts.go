@@ -0,0 +1,213 @@
+package updates
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/data"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+const (
+	DefaultAlertVersions = 7
+	MaxAlertVersions     = 14
+)
+
+var ErrAlertsFilterRequired = errors.New("an airline or route filter is required")
+
+type AlertType string
+
+const (
+	AlertTypeEquipmentSwap         = AlertType("equipment_swap")
+	AlertTypeConfigurationChange   = AlertType("configuration_change")
+	AlertTypeAllegrisRemoved       = AlertType("allegris_removed")
+	AlertTypeFirstRemoved          = AlertType("first_class_removed")
+	AlertTypeBusinessRemoved       = AlertType("business_class_removed")
+	AlertTypePremiumEconomyRemoved = AlertType("premium_economy_removed")
+	// AlertTypeDowngauge is reported if the total number of seats decreased
+	AlertTypeDowngauge = AlertType("downgauge")
+)
+
+type Equipment struct {
+	AircraftOwner                string `json:"aircraftOwner"`
+	AircraftIataCode             string `json:"aircraftId"`
+	AircraftConfigurationVersion string `json:"aircraftConfigurationVersion"`
+	AircraftConfigurationName    string `json:"aircraftConfigurationName,omitempty"`
+	Allegris                     bool   `json:"allegris"`
+	SeatsFirst                   int    `json:"seatsFirst"`
+	SeatsBusiness                int    `json:"seatsBusiness"`
+	SeatsPremium                 int    `json:"seatsPremium"`
+	SeatsEconomy                 int    `json:"seatsEconomy"`
+}
+
+func (e Equipment) Seats() int {
+	return e.SeatsFirst + e.SeatsBusiness + e.SeatsPremium + e.SeatsEconomy
+}
+
+func EquipmentFromVariant(v db.FlightScheduleVariant) Equipment {
+	e := Equipment{
+		AircraftOwner:                v.AircraftOwner,
+		AircraftIataCode:             v.AircraftIataCode,
+		AircraftConfigurationVersion: v.AircraftConfigurationVersion,
+		SeatsFirst:                   v.SeatsFirst,
+		SeatsBusiness:                v.SeatsBusiness,
+		SeatsPremium:                 v.SeatsPremium,
+		SeatsEconomy:                 v.SeatsEconomy,
+	}
+
+	if names, ok := data.AircraftConfigurationName(v.AircraftOwner, v.AircraftIataCode, v.AircraftConfigurationVersion); ok {
+		e.AircraftConfigurationName = names.Name
+		e.Allegris = names.ShortName == "Allegris"
+	}
+
+	return e
+}
+
+// Alert describes an equipment change of a flight on one or more departure dates, all introduced with the same version
+type Alert struct {
+	Version                  time.Time         `json:"version"`
+	FlightNumber             string            `json:"flightNumber"`
+	DepartureAirportIataCode string            `json:"departureAirportId"`
+	ArrivalAirportIataCode   string            `json:"arrivalAirportId"`
+	DepartureDatesLocal      []xtime.LocalDate `json:"departureDatesLocal"`
+	Types                    []AlertType       `json:"types"`
+	From                     Equipment         `json:"from"`
+	To                       Equipment         `json:"to"`
+}
+
+// Downgrade reports whether the alert contains anything but a plain equipment or configuration change
+func (a Alert) Downgrade() bool {
+	return slices.ContainsFunc(a.Types, func(t AlertType) bool {
+		return t != AlertTypeEquipmentSwap && t != AlertTypeConfigurationChange
+	})
+}
+
+// DetectAlerts lists the alert types of an equipment change. It returns nil if the equipment did not change.
+func DetectAlerts(from, to Equipment) []AlertType {
+	var types []AlertType
+	if from.AircraftIataCode != to.AircraftIataCode || from.AircraftOwner != to.AircraftOwner {
+		types = append(types, AlertTypeEquipmentSwap)
+	} else if from.AircraftConfigurationVersion != to.AircraftConfigurationVersion {
+		types = append(types, AlertTypeConfigurationChange)
+	} else {
+		return nil
+	}
+
+	if from.Allegris && !to.Allegris {
+		types = append(types, AlertTypeAllegrisRemoved)
+	}
+
+	if from.SeatsFirst > 0 && to.SeatsFirst < 1 {
+		types = append(types, AlertTypeFirstRemoved)
+	}
+
+	if from.SeatsBusiness > 0 && to.SeatsBusiness < 1 {
+		types = append(types, AlertTypeBusinessRemoved)
+	}
+
+	if from.SeatsPremium > 0 && to.SeatsPremium < 1 {
+		types = append(types, AlertTypePremiumEconomyRemoved)
+	}
+
+	if to.Seats() < from.Seats() {
+		types = append(types, AlertTypeDowngauge)
+	}
+
+	return types
+}
+
+type alertKey struct {
+	version                  time.Time
+	flightNumber             db.FlightNumber
+	departureAirportIataCode string
+	arrivalAirportIataCode   string
+	from                     Equipment
+	to                       Equipment
+}
+
+// Alerts detects equipment swaps and cabin downgrades of operating flights introduced by the latest numVersions versions, newest first.
+// The filter must restrict the airlines or the route.
+func (s *Search) Alerts(ctx context.Context, filter Filter, numVersions int) ([]Alert, error) {
+	if len(filter.AirlineIataCodes) < 1 && (filter.DepartureAirportIataCode == "" || filter.ArrivalAirportIataCode == "") {
+		return nil, ErrAlertsFilterRequired
+	}
+
+	versions, err := s.versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first version has no previous version to compare against
+	if len(versions) < 2 {
+		return make([]Alert, 0), nil
+	}
+
+	since := versions[max(len(versions)-numVersions, 1)]
+	changes, err := s.repo.EquipmentChanges(ctx, since, filter.condition())
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make(map[alertKey]*Alert)
+	for _, item := range changes.Items {
+		from, fromOk := changes.Variants[item.FromFlightVariantId]
+		to, toOk := changes.Variants[item.ToFlightVariantId]
+		if !fromOk || !toOk {
+			continue
+		}
+
+		fromEquipment, toEquipment := EquipmentFromVariant(from), EquipmentFromVariant(to)
+		types := DetectAlerts(fromEquipment, toEquipment)
+		if len(types) < 1 {
+			continue
+		}
+
+		key := alertKey{
+			version:                  item.Version,
+			flightNumber:             item.FlightNumber,
+			departureAirportIataCode: item.DepartureAirportIataCode,
+			arrivalAirportIataCode:   to.ArrivalAirportIataCode,
+			from:                     fromEquipment,
+			to:                       toEquipment,
+		}
+
+		alert, ok := alerts[key]
+		if !ok {
+			alert = &Alert{
+				Version:                  item.Version,
+				FlightNumber:             item.FlightNumber.String(),
+				DepartureAirportIataCode: item.DepartureAirportIataCode,
+				ArrivalAirportIataCode:   to.ArrivalAirportIataCode,
+				DepartureDatesLocal:      make([]xtime.LocalDate, 0),
+				Types:                    types,
+				From:                     fromEquipment,
+				To:                       toEquipment,
+			}
+			alerts[key] = alert
+		}
+
+		alert.DepartureDatesLocal = append(alert.DepartureDatesLocal, item.DepartureDateLocal)
+	}
+
+	result := make([]Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		slices.Sort(alert.DepartureDatesLocal)
+		result = append(result, *alert)
+	}
+
+	slices.SortFunc(result, func(a, b Alert) int {
+		return cmp.Or(
+			b.Version.Compare(a.Version),
+			strings.Compare(a.FlightNumber, b.FlightNumber),
+			cmp.Compare(a.DepartureDatesLocal[0], b.DepartureDatesLocal[0]),
+			strings.Compare(a.DepartureAirportIataCode, b.DepartureAirportIataCode),
+		)
+	})
+
+	return result, nil
+}
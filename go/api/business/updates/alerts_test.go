@@ -0,0 +1,127 @@
+package updates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEquipment(owner, aircraft, configuration string, first, business, premium, economy int) Equipment {
+	return EquipmentFromVariant(db.FlightScheduleVariant{
+		AircraftOwner:                owner,
+		AircraftIataCode:             aircraft,
+		AircraftConfigurationVersion: configuration,
+		SeatsFirst:                   first,
+		SeatsBusiness:                business,
+		SeatsPremium:                 premium,
+		SeatsEconomy:                 economy,
+	})
+}
+
+func TestDetectAlerts(t *testing.T) {
+	allegris := Equipment{
+		AircraftOwner:                "LH",
+		AircraftIataCode:             "359",
+		AircraftConfigurationVersion: "F4C38E24M201",
+		Allegris:                     true,
+		SeatsFirst:                   4,
+		SeatsBusiness:                38,
+		SeatsPremium:                 24,
+		SeatsEconomy:                 201,
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Nil(t, DetectAlerts(allegris, allegris))
+	})
+
+	t.Run("allegris without first", func(t *testing.T) {
+		to := allegris
+		to.AircraftConfigurationVersion = "C38E24M201"
+		to.SeatsFirst = 0
+
+		assert.Equal(t, []AlertType{AlertTypeConfigurationChange, AlertTypeFirstRemoved, AlertTypeDowngauge}, DetectAlerts(allegris, to))
+	})
+
+	t.Run("old configuration", func(t *testing.T) {
+		to := testEquipment("LH", "359", "C48E21M224", 0, 48, 21, 224)
+		assert.Equal(t, "Old LH Config", to.AircraftConfigurationName)
+		assert.Equal(t, []AlertType{AlertTypeConfigurationChange, AlertTypeAllegrisRemoved, AlertTypeFirstRemoved}, DetectAlerts(allegris, to))
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		to := testEquipment("LH", "333", "C42E28M185", 0, 42, 28, 185)
+		assert.Equal(t, []AlertType{AlertTypeEquipmentSwap, AlertTypeAllegrisRemoved, AlertTypeFirstRemoved, AlertTypeDowngauge}, DetectAlerts(allegris, to))
+	})
+
+	t.Run("upgauge", func(t *testing.T) {
+		from := testEquipment("LH", "32N", "C24M156", 0, 24, 0, 156)
+		to := testEquipment("LH", "359", "C48M245", 0, 48, 0, 245)
+		assert.Equal(t, []AlertType{AlertTypeEquipmentSwap}, DetectAlerts(from, to))
+	})
+}
+
+func TestSearchAlerts(t *testing.T) {
+	s := newTestSearch(
+		t,
+		// UA9051 is a codeshare of LH400 and swapped alongside it
+		`INSERT INTO flight_variant_history VALUES
+			('UA', 1, 9051, '', 'FRA', '2026-05-01', '00000000-0000-0000-0000-000000000001', '2025-12-01T00:00:00Z', '2026-02-01T00:00:00Z'),
+			('UA', 1, 9051, '', 'FRA', '2026-05-01', '00000000-0000-0000-0000-000000000002', '2026-02-01T00:00:00Z', NULL)`,
+		// LH401 JFK-FRA on 2026-05-03 cancelled and re-added with another aircraft by the following version
+		`INSERT INTO flight_variants VALUES
+			('00000000-0000-0000-0000-000000000006', 'LH', 401, '', '13:30:00', -14400, 30000, 'FRA', 7200, 'J', 'LH', '74H', 8, 80, 32, 244, 'F8C80E32M244', [], MAP {})`,
+		`INSERT INTO flight_variant_history VALUES
+			('LH', 1, 401, '', 'JFK', '2026-05-03', '00000000-0000-0000-0000-000000000003', '2025-12-01T00:00:00Z', '2026-02-01T00:00:00Z'),
+			('LH', 1, 401, '', 'JFK', '2026-05-03', NULL, '2026-02-01T00:00:00Z', '2026-03-01T00:00:00Z'),
+			('LH', 1, 401, '', 'JFK', '2026-05-03', '00000000-0000-0000-0000-000000000006', '2026-03-01T00:00:00Z', NULL)`,
+		`INSERT INTO updates_report VALUES ('LH', 401, '', '2026-03-01T00:00:00Z', 1, 0, 0)`,
+	)
+
+	t.Run("filter required", func(t *testing.T) {
+		for _, filter := range []Filter{{}, {AirportIataCode: "FRA"}, {DepartureAirportIataCode: "FRA"}} {
+			_, err := s.Alerts(t.Context(), filter, DefaultAlertVersions)
+			assert.ErrorIs(t, err, ErrAlertsFilterRequired)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		filter      Filter
+		numVersions int
+		expected    []string
+	}{
+		{"airline", Filter{AirlineIataCodes: []string{"LH"}}, DefaultAlertVersions, []string{"LH400"}},
+		{"codeshare airline", Filter{AirlineIataCodes: []string{"UA"}}, DefaultAlertVersions, []string{}},
+		{"route", Filter{DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK"}, DefaultAlertVersions, []string{"LH400"}},
+		{"other route", Filter{DepartureAirportIataCode: "JFK", ArrivalAirportIataCode: "FRA"}, DefaultAlertVersions, []string{}},
+		{"latest version only", Filter{AirlineIataCodes: []string{"LH"}}, 1, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts, err := s.Alerts(t.Context(), tt.filter, tt.numVersions)
+			require.NoError(t, err)
+
+			fns := make([]string, 0, len(alerts))
+			for _, a := range alerts {
+				fns = append(fns, a.FlightNumber)
+			}
+
+			assert.Equal(t, tt.expected, fns)
+		})
+	}
+
+	alerts, err := s.Alerts(t.Context(), Filter{AirlineIataCodes: []string{"LH"}}, DefaultAlertVersions)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, testVersion, alerts[0].Version)
+	assert.Equal(t, "JFK", alerts[0].ArrivalAirportIataCode)
+	assert.Equal(t, []xtime.LocalDate{xtime.NewLocalDateFromParts(2026, time.May, 1)}, alerts[0].DepartureDatesLocal)
+	assert.Equal(t, []AlertType{AlertTypeEquipmentSwap}, alerts[0].Types)
+	assert.Equal(t, "359", alerts[0].From.AircraftIataCode)
+	assert.Equal(t, "74H", alerts[0].To.AircraftIataCode)
+}
@@ -0,0 +1,155 @@
+package updates
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+type ChangeType string
+
+const (
+	ChangeTypeAdded                 = ChangeType("added")
+	ChangeTypeCancellation          = ChangeType("cancellation")
+	ChangeTypeTimeShift             = ChangeType("time_shift")
+	ChangeTypeDurationChange        = ChangeType("duration_change")
+	ChangeTypeRouteChange           = ChangeType("route_change")
+	ChangeTypeAircraftSwap          = ChangeType("aircraft_swap")
+	ChangeTypeConfigurationChange   = ChangeType("configuration_change")
+	ChangeTypeCodeShareAdded        = ChangeType("codeshare_added")
+	ChangeTypeCodeShareRemoved      = ChangeType("codeshare_removed")
+	ChangeTypeOperatingFlightChange = ChangeType("operating_flight_change")
+	// ChangeTypeOther is reported if the variant changed in attributes without a dedicated change type, e.g. data elements
+	ChangeTypeOther = ChangeType("other")
+)
+
+type Diff struct {
+	Since   time.Time    `json:"since"`
+	Version time.Time    `json:"version"`
+	Flights []FlightDiff `json:"flights"`
+	// Truncated is true if more flights changed than requested
+	Truncated bool `json:"truncated"`
+}
+
+type FlightDiff struct {
+	FlightNumber             string          `json:"flightNumber"`
+	DepartureAirportIataCode string          `json:"departureAirportId"`
+	DepartureDateLocal       xtime.LocalDate `json:"departureDateLocal"`
+	Changes                  []Change        `json:"changes"`
+}
+
+// Change describes a single difference between two variants of a flight instance.
+// From and To hold the previous and new value where applicable, Minutes the shift of time based changes.
+type Change struct {
+	Type    ChangeType `json:"type"`
+	From    string     `json:"from,omitempty"`
+	To      string     `json:"to,omitempty"`
+	Minutes int        `json:"minutes,omitempty"`
+}
+
+// Classify lists the changes between two variants of the same flight instance. A nil variant means the flight did not exist (or was cancelled) at that version.
+func Classify(from, to *db.FlightScheduleVariant) []Change {
+	changes := make([]Change, 0)
+	switch {
+	case from == nil && to == nil:
+		return changes
+
+	case from == nil:
+		return append(changes, Change{Type: ChangeTypeAdded})
+
+	case to == nil:
+		return append(changes, Change{Type: ChangeTypeCancellation})
+	}
+
+	if from.OperatedAs != to.OperatedAs {
+		changes = append(changes, Change{
+			Type: ChangeTypeOperatingFlightChange,
+			From: from.OperatedAs.String(),
+			To:   to.OperatedAs.String(),
+		})
+	}
+
+	if shift := departureUtc(*to) - departureUtc(*from); shift != 0 {
+		changes = append(changes, Change{
+			Type:    ChangeTypeTimeShift,
+			From:    from.DepartureTimeLocal.String(),
+			To:      to.DepartureTimeLocal.String(),
+			Minutes: int(shift.Minutes()),
+		})
+	}
+
+	if from.DurationSeconds != to.DurationSeconds {
+		changes = append(changes, Change{
+			Type:    ChangeTypeDurationChange,
+			From:    formatDuration(from.DurationSeconds),
+			To:      formatDuration(to.DurationSeconds),
+			Minutes: int((to.DurationSeconds - from.DurationSeconds) / 60),
+		})
+	}
+
+	if from.ArrivalAirportIataCode != to.ArrivalAirportIataCode {
+		changes = append(changes, Change{
+			Type: ChangeTypeRouteChange,
+			From: from.ArrivalAirportIataCode,
+			To:   to.ArrivalAirportIataCode,
+		})
+	}
+
+	if from.AircraftIataCode != to.AircraftIataCode || from.AircraftOwner != to.AircraftOwner {
+		changes = append(changes, Change{
+			Type: ChangeTypeAircraftSwap,
+			From: from.AircraftIataCode,
+			To:   to.AircraftIataCode,
+		})
+	} else if from.AircraftConfigurationVersion != to.AircraftConfigurationVersion {
+		// a different configuration is implied by an aircraft swap and only reported on its own for the same aircraft type
+		changes = append(changes, Change{
+			Type: ChangeTypeConfigurationChange,
+			From: from.AircraftConfigurationVersion,
+			To:   to.AircraftConfigurationVersion,
+		})
+	}
+
+	for _, cs := range sortedFlightNumbers(to.CodeShares) {
+		if !from.CodeShares.Contains(cs) {
+			changes = append(changes, Change{Type: ChangeTypeCodeShareAdded, To: cs.String()})
+		}
+	}
+
+	for _, cs := range sortedFlightNumbers(from.CodeShares) {
+		if !to.CodeShares.Contains(cs) {
+			changes = append(changes, Change{Type: ChangeTypeCodeShareRemoved, From: cs.String()})
+		}
+	}
+
+	if len(changes) < 1 && (from.ServiceType != to.ServiceType || from.ArrivalUtcOffsetSeconds != to.ArrivalUtcOffsetSeconds || !maps.Equal(from.DataElements, to.DataElements)) {
+		changes = append(changes, Change{Type: ChangeTypeOther})
+	}
+
+	return changes
+}
+
+// departureUtc is the departure relative to the (same) local departure date in UTC
+func departureUtc(v db.FlightScheduleVariant) time.Duration {
+	return time.Duration(v.DepartureTimeLocal) - time.Duration(v.DepartureUtcOffsetSeconds)*time.Second
+}
+
+func formatDuration(seconds int64) string {
+	return fmt.Sprintf("%d:%02d", seconds/3600, (seconds%3600)/60)
+}
+
+func sortedFlightNumbers(fns common.Set[db.FlightNumber]) []db.FlightNumber {
+	return slices.SortedFunc(maps.Keys(fns), func(a, b db.FlightNumber) int {
+		return cmp.Or(
+			cmp.Compare(a.AirlineIataCode, b.AirlineIataCode),
+			cmp.Compare(a.Number, b.Number),
+			cmp.Compare(a.Suffix, b.Suffix),
+		)
+	})
+}
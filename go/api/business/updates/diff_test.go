@@ -0,0 +1,102 @@
+package updates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func testVariant() db.FlightScheduleVariant {
+	return db.FlightScheduleVariant{
+		OperatedAs:                   db.FlightNumber{AirlineIataCode: "LH", Number: 400},
+		DepartureTimeLocal:           xtime.LocalTime(10 * time.Hour),
+		DepartureUtcOffsetSeconds:    7200,
+		DurationSeconds:              9 * 60 * 60,
+		ArrivalAirportIataCode:       "JFK",
+		ArrivalUtcOffsetSeconds:      -14400,
+		ServiceType:                  "J",
+		AircraftOwner:                "LH",
+		AircraftIataCode:             "359",
+		AircraftConfigurationVersion: "C48E21M224",
+		CodeShares:                   common.Set[db.FlightNumber]{{AirlineIataCode: "UA", Number: 9051}: {}},
+		DataElements:                 map[int64]string{},
+	}
+}
+
+func TestClassify(t *testing.T) {
+	from := testVariant()
+
+	t.Run("added and cancelled", func(t *testing.T) {
+		assert.Equal(t, []Change{{Type: ChangeTypeAdded}}, Classify(nil, &from))
+		assert.Equal(t, []Change{{Type: ChangeTypeCancellation}}, Classify(&from, nil))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		to := testVariant()
+		assert.Empty(t, Classify(&from, &to))
+	})
+
+	t.Run("time shift", func(t *testing.T) {
+		to := testVariant()
+		to.DepartureTimeLocal = xtime.LocalTime(10*time.Hour + 45*time.Minute)
+		to.DurationSeconds -= 15 * 60
+
+		assert.Equal(
+			t,
+			[]Change{
+				{Type: ChangeTypeTimeShift, From: "10:00:00", To: "10:45:00", Minutes: 45},
+				{Type: ChangeTypeDurationChange, From: "9:00", To: "8:45", Minutes: -15},
+			},
+			Classify(&from, &to),
+		)
+	})
+
+	t.Run("utc offset correction", func(t *testing.T) {
+		to := testVariant()
+		to.DepartureUtcOffsetSeconds = 3600
+
+		assert.Equal(t, []Change{{Type: ChangeTypeTimeShift, From: "10:00:00", To: "10:00:00", Minutes: 60}}, Classify(&from, &to))
+	})
+
+	t.Run("aircraft swap", func(t *testing.T) {
+		to := testVariant()
+		to.AircraftIataCode = "74H"
+		to.AircraftConfigurationVersion = "F8C80E32M244"
+
+		assert.Equal(t, []Change{{Type: ChangeTypeAircraftSwap, From: "359", To: "74H"}}, Classify(&from, &to))
+	})
+
+	t.Run("configuration change", func(t *testing.T) {
+		to := testVariant()
+		to.AircraftConfigurationVersion = "F4C38E24M201"
+
+		assert.Equal(t, []Change{{Type: ChangeTypeConfigurationChange, From: "C48E21M224", To: "F4C38E24M201"}}, Classify(&from, &to))
+	})
+
+	t.Run("route and codeshares", func(t *testing.T) {
+		to := testVariant()
+		to.ArrivalAirportIataCode = "EWR"
+		to.CodeShares = common.Set[db.FlightNumber]{{AirlineIataCode: "AC", Number: 9099}: {}}
+
+		assert.Equal(
+			t,
+			[]Change{
+				{Type: ChangeTypeRouteChange, From: "JFK", To: "EWR"},
+				{Type: ChangeTypeCodeShareAdded, To: "AC9099"},
+				{Type: ChangeTypeCodeShareRemoved, From: "UA9051"},
+			},
+			Classify(&from, &to),
+		)
+	})
+
+	t.Run("other", func(t *testing.T) {
+		to := testVariant()
+		to.DataElements = map[int64]string{10: "UA 9051"}
+
+		assert.Equal(t, []Change{{Type: ChangeTypeOther}}, Classify(&from, &to))
+	})
+}
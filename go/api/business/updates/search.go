@@ -0,0 +1,178 @@
+package updates
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	DefaultDiffLimit = 1000
+	MaxDiffLimit     = 10_000
+)
+
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidVersions = errors.New("since must be before version")
+)
+
+type searchRepo interface {
+	GlobalUpdatesReport(ctx context.Context) ([]db.UpdateReportItem, error)
+	UpdatesDiff(ctx context.Context, since, version time.Time, filter db.Condition, limit int) (db.FlightScheduleDiff, error)
+	EquipmentChanges(ctx context.Context, since time.Time, filter db.Condition) (db.EquipmentChanges, error)
+}
+
+type Filter struct {
+	AirlineIataCodes []string
+	// AirportIataCode matches flights departing or arriving (before or after the change) at the airport
+	AirportIataCode string
+	// DepartureAirportIataCode and ArrivalAirportIataCode restrict the route; the arrival airport matches before or after the change
+	DepartureAirportIataCode string
+	ArrivalAirportIataCode   string
+}
+
+func (f Filter) condition() db.Condition {
+	condition := make(db.AndCondition, 0)
+	if len(f.AirlineIataCodes) > 0 {
+		condition = append(condition, db.NewInCondition("d.airline_iata_code", slices.Values(f.AirlineIataCodes)))
+	}
+
+	if f.AirportIataCode != "" {
+		condition = append(condition, db.OrCondition{
+			db.BaseCondition{Filter: "d.departure_airport_iata_code = ?", Params: []any{f.AirportIataCode}},
+			db.BaseCondition{Filter: "from_fv.arrival_airport_iata_code = ?", Params: []any{f.AirportIataCode}},
+			db.BaseCondition{Filter: "to_fv.arrival_airport_iata_code = ?", Params: []any{f.AirportIataCode}},
+		})
+	}
+
+	if f.DepartureAirportIataCode != "" {
+		condition = append(condition, db.BaseCondition{
+			Filter: "d.departure_airport_iata_code = ?",
+			Params: []any{f.DepartureAirportIataCode},
+		})
+	}
+
+	if f.ArrivalAirportIataCode != "" {
+		condition = append(condition, db.OrCondition{
+			db.BaseCondition{Filter: "from_fv.arrival_airport_iata_code = ?", Params: []any{f.ArrivalAirportIataCode}},
+			db.BaseCondition{Filter: "to_fv.arrival_airport_iata_code = ?", Params: []any{f.ArrivalAirportIataCode}},
+		})
+	}
+
+	if len(condition) < 1 {
+		return nil
+	}
+
+	return condition
+}
+
+type Search struct {
+	repo searchRepo
+}
+
+func NewSearch(repo searchRepo) *Search {
+	return &Search{repo: repo}
+}
+
+// Diff lists up to limit changed flight instances between two versions. If version is nil, the latest version is used.
+// If since is nil, the diff is taken against the version preceding version.
+func (s *Search) Diff(ctx context.Context, since, version *time.Time, filter Filter, limit int) (Diff, error) {
+	if since == nil || version == nil {
+		versions, err := s.versions(ctx)
+		if err != nil {
+			return Diff{}, err
+		}
+
+		if version == nil {
+			if len(versions) < 1 {
+				return Diff{}, ErrNotFound
+			}
+
+			version = &versions[len(versions)-1]
+		}
+
+		if since == nil {
+			idx, _ := slices.BinarySearchFunc(versions, *version, time.Time.Compare)
+			if idx < 1 {
+				return Diff{}, ErrNotFound
+			}
+
+			since = &versions[idx-1]
+		}
+	}
+
+	if !since.Before(*version) {
+		return Diff{}, ErrInvalidVersions
+	}
+
+	diff := Diff{
+		Since:   *since,
+		Version: *version,
+		Flights: make([]FlightDiff, 0),
+	}
+
+	var err error
+	diff.Truncated, err = s.diff(ctx, *since, *version, filter, limit, func(item db.FlightScheduleDiffItem, from, to *db.FlightScheduleVariant) {
+		changes := Classify(from, to)
+		if len(changes) < 1 {
+			return
+		}
+
+		diff.Flights = append(diff.Flights, FlightDiff{
+			FlightNumber:             item.FlightNumber.String(),
+			DepartureAirportIataCode: item.DepartureAirportIataCode,
+			DepartureDateLocal:       item.DepartureDateLocal,
+			Changes:                  changes,
+		})
+	})
+
+	return diff, err
+}
+
+// versions returns all known versions in ascending order
+func (s *Search) versions(ctx context.Context) ([]time.Time, error) {
+	report, err := s.repo.GlobalUpdatesReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]time.Time, 0, len(report))
+	for _, item := range report {
+		versions = append(versions, item.Version)
+	}
+
+	slices.SortFunc(versions, time.Time.Compare)
+
+	return slices.CompactFunc(versions, time.Time.Equal), nil
+}
+
+// diff calls fn for each changed flight instance and reports whether more than limit flight instances changed
+func (s *Search) diff(ctx context.Context, since, version time.Time, filter Filter, limit int, fn func(item db.FlightScheduleDiffItem, from, to *db.FlightScheduleVariant)) (bool, error) {
+	fsd, err := s.repo.UpdatesDiff(ctx, since, version, filter.condition(), limit)
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range fsd.Items {
+		fn(item, variant(fsd.Variants, item.FromFlightVariantId), variant(fsd.Variants, item.ToFlightVariantId))
+	}
+
+	return fsd.Truncated, nil
+}
+
+func variant(variants map[uuid.UUID]db.FlightScheduleVariant, id sql.Null[uuid.UUID]) *db.FlightScheduleVariant {
+	if !id.Valid {
+		return nil
+	}
+
+	if v, ok := variants[id.V]; ok {
+		return &v
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package updates
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/duckdb/duckdb-go/v2"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSince   = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testVersion = time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type testDatabase struct {
+	db *sql.DB
+}
+
+func (d testDatabase) Conn(ctx context.Context) (*sql.Conn, error) {
+	return d.db.Conn(ctx)
+}
+
+// newTestSearch creates a search on an in-memory database with the following changes introduced by testVersion:
+// LH400 FRA-JFK swapped from 359 to 74H, LH401 JFK-FRA cancelled and LH402 from FRA rerouted from MUC to BER.
+// The statements given are executed after the test data has been inserted.
+func newTestSearch(t *testing.T, statements ...string) *Search {
+	connector, err := duckdb.NewConnector("", nil)
+	require.NoError(t, err)
+
+	database := sql.OpenDB(connector)
+	t.Cleanup(func() { _ = database.Close() })
+
+	for _, q := range []string{
+		`CREATE TABLE flight_variants (id UUID, operating_airline_iata_code TEXT, operating_number USMALLINT, operating_suffix TEXT, departure_time_local TIME, departure_utc_offset_seconds INT, duration_seconds INT, arrival_airport_iata_code TEXT, arrival_utc_offset_seconds INT, service_type TEXT, aircraft_owner TEXT, aircraft_iata_code TEXT, seats_first USMALLINT, seats_business USMALLINT, seats_premium USMALLINT, seats_economy USMALLINT, aircraft_configuration_version TEXT, code_shares STRUCT(airline_iata_code TEXT, number USMALLINT, suffix TEXT)[], data_elements MAP(BIGINT, TEXT))`,
+		`INSERT INTO flight_variants VALUES
+			('00000000-0000-0000-0000-000000000001', 'LH', 400, '', '10:00:00', 7200, 32400, 'JFK', -14400, 'J', 'LH', '359', 0, 48, 21, 224, 'C48E21M224', [], MAP {}),
+			('00000000-0000-0000-0000-000000000002', 'LH', 400, '', '10:00:00', 7200, 32400, 'JFK', -14400, 'J', 'LH', '74H', 8, 80, 32, 244, 'F8C80E32M244', [], MAP {}),
+			('00000000-0000-0000-0000-000000000003', 'LH', 401, '', '13:30:00', -14400, 30000, 'FRA', 7200, 'J', 'LH', '359', 0, 48, 21, 224, 'C48E21M224', [], MAP {}),
+			('00000000-0000-0000-0000-000000000004', 'LH', 402, '', '08:00:00', 7200, 3600, 'MUC', 7200, 'J', 'LH', '320', 0, 12, 0, 156, 'C12M156', [], MAP {}),
+			('00000000-0000-0000-0000-000000000005', 'LH', 402, '', '08:00:00', 7200, 3600, 'BER', 7200, 'J', 'LH', '320', 0, 12, 0, 156, 'C12M156', [], MAP {})`,
+		`CREATE TABLE flight_variant_history (airline_iata_code TEXT, number_mod_10 USMALLINT, number USMALLINT, suffix TEXT, departure_airport_iata_code TEXT, departure_date_local DATE, flight_variant_id UUID, created_at TIMESTAMPTZ, replaced_at TIMESTAMPTZ)`,
+		`INSERT INTO flight_variant_history VALUES
+			('LH', 0, 400, '', 'FRA', '2026-05-01', '00000000-0000-0000-0000-000000000001', '2025-12-01T00:00:00Z', '2026-02-01T00:00:00Z'),
+			('LH', 0, 400, '', 'FRA', '2026-05-01', '00000000-0000-0000-0000-000000000002', '2026-02-01T00:00:00Z', NULL),
+			('LH', 0, 400, '', 'FRA', '2026-05-02', '00000000-0000-0000-0000-000000000001', '2025-12-01T00:00:00Z', NULL),
+			('LH', 1, 401, '', 'JFK', '2026-05-01', '00000000-0000-0000-0000-000000000003', '2025-12-01T00:00:00Z', '2026-02-01T00:00:00Z'),
+			('LH', 1, 401, '', 'JFK', '2026-05-01', NULL, '2026-02-01T00:00:00Z', NULL),
+			('LH', 2, 402, '', 'FRA', '2026-05-01', '00000000-0000-0000-0000-000000000004', '2025-12-01T00:00:00Z', '2026-02-01T00:00:00Z'),
+			('LH', 2, 402, '', 'FRA', '2026-05-01', '00000000-0000-0000-0000-000000000005', '2026-02-01T00:00:00Z', NULL)`,
+		`CREATE TABLE updates_report (airline_iata_code TEXT, number USMALLINT, suffix TEXT, created_at TIMESTAMPTZ, added INT, updated INT, removed INT)`,
+		`INSERT INTO updates_report VALUES
+			('LH', 400, '', '2025-12-01T00:00:00Z', 2, 0, 0),
+			('LH', 401, '', '2025-12-01T00:00:00Z', 1, 0, 0),
+			('LH', 402, '', '2025-12-01T00:00:00Z', 1, 0, 0),
+			('LH', 400, '', '2026-02-01T00:00:00Z', 0, 1, 0),
+			('LH', 401, '', '2026-02-01T00:00:00Z', 0, 0, 1),
+			('LH', 402, '', '2026-02-01T00:00:00Z', 0, 1, 0)`,
+	} {
+		_, err = database.Exec(q)
+		require.NoError(t, err, q)
+	}
+
+	for _, q := range statements {
+		_, err = database.Exec(q)
+		require.NoError(t, err, q)
+	}
+
+	return NewSearch(db.NewFlightRepo(testDatabase{database}))
+}
+
+func TestSearchDiffFilter(t *testing.T) {
+	s := newTestSearch(t)
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{"none", Filter{}, []string{"LH400", "LH401", "LH402"}},
+		{"airline", Filter{AirlineIataCodes: []string{"LH"}}, []string{"LH400", "LH401", "LH402"}},
+		{"other airline", Filter{AirlineIataCodes: []string{"UA"}}, []string{}},
+		{"airport departure or arrival", Filter{AirportIataCode: "JFK"}, []string{"LH400", "LH401"}},
+		{"airport arrival before the change", Filter{AirportIataCode: "MUC"}, []string{"LH402"}},
+		{"airport arrival after the change", Filter{AirportIataCode: "BER"}, []string{"LH402"}},
+		{"departure airport", Filter{DepartureAirportIataCode: "FRA"}, []string{"LH400", "LH402"}},
+		{"arrival airport", Filter{ArrivalAirportIataCode: "BER"}, []string{"LH402"}},
+		{"route of cancelled flight", Filter{DepartureAirportIataCode: "JFK", ArrivalAirportIataCode: "FRA"}, []string{"LH401"}},
+		{"airline and airport", Filter{AirlineIataCodes: []string{"LH"}, AirportIataCode: "MUC"}, []string{"LH402"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := s.Diff(t.Context(), &testSince, &testVersion, tt.filter, MaxDiffLimit)
+			require.NoError(t, err)
+			assert.False(t, diff.Truncated)
+
+			fns := make([]string, 0, len(diff.Flights))
+			for _, f := range diff.Flights {
+				fns = append(fns, f.FlightNumber)
+			}
+
+			assert.Equal(t, tt.expected, fns)
+		})
+	}
+}
+
+func TestSearchDiffLimit(t *testing.T) {
+	s := newTestSearch(t)
+
+	diff, err := s.Diff(t.Context(), &testSince, &testVersion, Filter{}, 2)
+	require.NoError(t, err)
+	assert.True(t, diff.Truncated)
+	require.Len(t, diff.Flights, 2)
+	assert.Equal(t, "LH400", diff.Flights[0].FlightNumber)
+	assert.Equal(t, "LH401", diff.Flights[1].FlightNumber)
+
+	diff, err = s.Diff(t.Context(), &testSince, &testVersion, Filter{}, 3)
+	require.NoError(t, err)
+	assert.False(t, diff.Truncated)
+	assert.Len(t, diff.Flights, 3)
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/explore-flights/monorepo/go/api/business/analytics"
+	"github.com/explore-flights/monorepo/go/api/business/seatmap"
+	"github.com/explore-flights/monorepo/go/api/business/updates"
+	"github.com/explore-flights/monorepo/go/api/web/model"
+	"github.com/explore-flights/monorepo/go/api/web/openapi"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+type ResponseStatusErr struct {
+	StatusCode int
+	Status     string
+}
+
+func (e ResponseStatusErr) Error() string {
+	return e.Status
+}
+
+type Schedule string
+
+const (
+	ScheduleAllegris  = Schedule("allegris")
+	ScheduleSwissA350 = Schedule("swiss350")
+	ScheduleLHA380    = Schedule("lh380")
+	ScheduleLHA340    = Schedule("lh340")
+	ScheduleLH747     = Schedule("lh747")
+)
+
+type Client struct {
+	httpClient *http.Client
+	baseUrl    string
+}
+
+type ClientOption func(c *Client)
+
+func WithHttpClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithBaseUrl(baseUrl string) ClientOption {
+	return func(c *Client) {
+		c.baseUrl = baseUrl
+	}
+}
+
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.httpClient = cmp.Or(c.httpClient, http.DefaultClient)
+	c.baseUrl = cmp.Or(c.baseUrl, "https://explore.flights")
+
+	return c
+}
+
+func (c *Client) OpenAPI(ctx context.Context) (openapi.Document, error) {
+	return doRequest[openapi.Document](ctx, c, http.MethodGet, "/api/openapi.json", nil, nil)
+}
+
+func (c *Client) Notifications(ctx context.Context) ([]model.Notification, error) {
+	return doRequest[[]model.Notification](ctx, c, http.MethodGet, "/api/notifications", nil, nil)
+}
+
+func (c *Client) Search(ctx context.Context, query string) (model.SearchResponse, error) {
+	q := make(url.Values)
+	q.Set("q", query)
+
+	return doRequest[model.SearchResponse](ctx, c, http.MethodGet, "/api/search", q, nil)
+}
+
+func (c *Client) ScheduleSearch(ctx context.Context, q url.Values) (model.FlightSchedulesMany, error) {
+	return doRequest[model.FlightSchedulesMany](ctx, c, http.MethodGet, "/api/schedule/search", q, nil)
+}
+
+func (c *Client) AnalyticsRouteFrequencies(ctx context.Context, q url.Values) ([]analytics.RouteFrequency, error) {
+	return doRequest[[]analytics.RouteFrequency](ctx, c, http.MethodGet, "/api/analytics/frequency", q, nil)
+}
+
+func (c *Client) AnalyticsCapacity(ctx context.Context, q url.Values) ([]analytics.Capacity, error) {
+	return doRequest[[]analytics.Capacity](ctx, c, http.MethodGet, "/api/analytics/capacity", q, nil)
+}
+
+func (c *Client) AnalyticsBusiestRoutes(ctx context.Context, q url.Values) ([]analytics.RouteFrequency, error) {
+	return doRequest[[]analytics.RouteFrequency](ctx, c, http.MethodGet, "/api/analytics/busiest-routes", q, nil)
+}
+
+func (c *Client) AnalyticsRouteChanges(ctx context.Context, q url.Values) (analytics.RouteChanges, error) {
+	return doRequest[analytics.RouteChanges](ctx, c, http.MethodGet, "/api/analytics/route-changes", q, nil)
+}
+
+func (c *Client) Connections(ctx context.Context, req model.ConnectionsSearchRequest) (model.ConnectionsSearchResponse, error) {
+	return doRequest[model.ConnectionsSearchResponse](ctx, c, http.MethodPost, "/api/connections/json", nil, req)
+}
+
+func (c *Client) ConnectionsShare(ctx context.Context, req model.ConnectionsSearchRequest) (model.ConnectionsShareResponse, error) {
+	return doRequest[model.ConnectionsShareResponse](ctx, c, http.MethodPost, "/api/connections/share", nil, req)
+}
+
+func (c *Client) Reachability(ctx context.Context, airport string, q url.Values) (model.ReachabilityResponse, error) {
+	return doRequest[model.ReachabilityResponse](ctx, c, http.MethodGet, "/api/connections/reachability/"+url.PathEscape(airport), q, nil)
+}
+
+func (c *Client) ConnectionGame(ctx context.Context, minFlights, maxFlights int, seed string) (model.ConnectionGameChallenge, error) {
+	q := make(url.Values)
+	if minFlights > 0 {
+		q.Set("minFlights", strconv.Itoa(minFlights))
+	}
+
+	if maxFlights > 0 {
+		q.Set("maxFlights", strconv.Itoa(maxFlights))
+	}
+
+	if seed != "" {
+		q.Set("seed", seed)
+	}
+
+	return doRequest[model.ConnectionGameChallenge](ctx, c, http.MethodGet, "/api/game/connection", q, nil)
+}
+
+func (c *Client) Airlines(ctx context.Context) ([]model.Airline, error) {
+	return doRequest[[]model.Airline](ctx, c, http.MethodGet, "/data/airlines.json", nil, nil)
+}
+
+func (c *Client) Airports(ctx context.Context) ([]model.Airport, error) {
+	return doRequest[[]model.Airport](ctx, c, http.MethodGet, "/data/airports.json", nil, nil)
+}
+
+func (c *Client) Aircraft(ctx context.Context) ([]model.Aircraft, error) {
+	return doRequest[[]model.Aircraft](ctx, c, http.MethodGet, "/data/aircraft.json", nil, nil)
+}
+
+// FlightSchedule loads the schedule of a flight number within the given year. An empty version loads the latest version.
+func (c *Client) FlightSchedule(ctx context.Context, year int, fn, version string) (model.FlightSchedules, error) {
+	path := "/data/" + strconv.Itoa(year) + "/flight/" + url.PathEscape(fn)
+	if version != "" {
+		path += "/" + url.PathEscape(version)
+	}
+
+	return doRequest[model.FlightSchedules](ctx, c, http.MethodGet, path, nil, nil)
+}
+
+func (c *Client) FlightScheduleVersions(ctx context.Context, fn, departureAirport string, departureDateLocal xtime.LocalDate) (model.FlightScheduleVersions, error) {
+	path := "/data/flight/" + url.PathEscape(fn) + "/versions/" + url.PathEscape(departureAirport) + "/" + departureDateLocal.String()
+	return doRequest[model.FlightScheduleVersions](ctx, c, http.MethodGet, path, nil, nil)
+}
+
+func (c *Client) SeatMap(ctx context.Context, fn, departureAirport string, departureDateLocal xtime.LocalDate) (seatmap.SeatMap, error) {
+	path := "/data/flight/" + url.PathEscape(fn) + "/seatmap/" + url.PathEscape(departureAirport) + "/" + departureDateLocal.String()
+	return doRequest[seatmap.SeatMap](ctx, c, http.MethodGet, path, nil, nil)
+}
+
+func (c *Client) Destinations(ctx context.Context, departureAirport string) ([]model.Airport, error) {
+	return doRequest[[]model.Airport](ctx, c, http.MethodGet, "/data/destinations/"+url.PathEscape(departureAirport), nil, nil)
+}
+
+func (c *Client) GlobalUpdates(ctx context.Context) ([]model.UpdateReportItem, error) {
+	return doRequest[[]model.UpdateReportItem](ctx, c, http.MethodGet, "/data/updates", nil, nil)
+}
+
+// UpdatesDiff loads the changed flights of a version. An empty version loads the latest version, an empty since compares against the preceding version.
+// A limit of 0 uses the default limit of the API.
+func (c *Client) UpdatesDiff(ctx context.Context, version, since string, airlines []string, airport string, limit int) (updates.Diff, error) {
+	q := make(url.Values)
+	if since != "" {
+		q.Set("since", since)
+	}
+
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	for _, airline := range airlines {
+		q.Add("airlineId", airline)
+	}
+
+	if airport != "" {
+		q.Set("airportId", airport)
+	}
+
+	return doRequest[updates.Diff](ctx, c, http.MethodGet, "/data/updates/"+url.PathEscape(cmp.Or(version, "latest"))+"/diff", q, nil)
+}
+
+func (c *Client) Alerts(ctx context.Context, q url.Values) ([]updates.Alert, error) {
+	return doRequest[[]updates.Alert](ctx, c, http.MethodGet, "/data/alerts", q, nil)
+}
+
+func (c *Client) Schedule(ctx context.Context, year int, schedule Schedule) (model.FlightSchedulesMany, error) {
+	path := "/data/" + strconv.Itoa(year) + "/schedule/" + url.PathEscape(string(schedule))
+	return doRequest[model.FlightSchedulesMany](ctx, c, http.MethodGet, path, nil, nil)
+}
+
+func doRequest[T any](ctx context.Context, c *Client, method, path string, q url.Values, body any) (T, error) {
+	var r T
+
+	surl := c.baseUrl + path
+	if len(q) > 0 {
+		surl += "?" + q.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return r, err
+		}
+
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, surl, reqBody)
+	if err != nil {
+		return r, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return r, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return r, ResponseStatusErr{
+			StatusCode: res.StatusCode,
+			Status:     res.Status,
+		}
+	}
+
+	return r, json.NewDecoder(res.Body).Decode(&r)
+}
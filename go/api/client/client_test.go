@@ -0,0 +1,682 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"log"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/updates"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/api/web"
+	"github.com/explore-flights/monorepo/go/api/web/model"
+	"github.com/explore-flights/monorepo/go/api/web/openapi"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testVersion   = time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	testVariantId = uuid.Must(uuid.FromString("0190b5a4-7a53-7c1e-9d1f-6a5e0d3e6b21"))
+	testSwapId    = uuid.Must(uuid.FromString("0190b5a4-7a53-7c1e-9d1f-6a5e0d3e6b22"))
+	testFn        = db.FlightNumber{AirlineIataCode: "LH", Number: 400}
+)
+
+type fakeRepo struct{}
+
+func (fakeRepo) Airlines(ctx context.Context) (map[string]db.Airline, error) {
+	return map[string]db.Airline{
+		"LH": {IataCode: "LH", IcaoCode: sql.NullString{String: "DLH", Valid: true}, Name: "Lufthansa"},
+		"UA": {IataCode: "UA", IcaoCode: sql.NullString{String: "UAL", Valid: true}, Name: "United"},
+	}, nil
+}
+
+func (fakeRepo) Airports(ctx context.Context) (map[string]db.Airport, error) {
+	return map[string]db.Airport{
+		"FRA": {IataCode: "FRA", IcaoCode: sql.NullString{String: "EDDF", Valid: true}, CountryCode: "DE", CityCode: "FRA", Type: "AIRPORT", Lng: 8.57, Lat: 50.03, Timezone: "Europe/Berlin", Name: "Frankfurt"},
+		"JFK": {IataCode: "JFK", IcaoCode: sql.NullString{String: "KJFK", Valid: true}, CountryCode: "US", CityCode: "NYC", Type: "AIRPORT", Lng: -73.78, Lat: 40.64, Timezone: "America/New_York", Name: "New York JFK"},
+	}, nil
+}
+
+func (fakeRepo) Aircraft(ctx context.Context) (map[string]db.Aircraft, error) {
+	return map[string]db.Aircraft{
+		"35X": {IataCode: "35X", Name: "Airbus A350", IsFamily: true},
+		"359": {IataCode: "359", ParentIataCode: sql.NullString{String: "35X", Valid: true}, IcaoCode: sql.NullString{String: "A359", Valid: true}, Name: "Airbus A350-900", Configurations: map[string][]string{"LH": {"C48E21M224"}}},
+	}, nil
+}
+
+func (fakeRepo) Flights(ctx context.Context, start, end xtime.LocalDate, asOf *time.Time) (map[xtime.LocalDate][]db.Flight, error) {
+	result := make(map[xtime.LocalDate][]db.Flight)
+	for d := start; d <= end; d++ {
+		departure := d.Time(nil).Add(10 * time.Hour)
+		result[d] = []db.Flight{{
+			FlightNumber:                 testFn,
+			DepartureTime:                departure,
+			DepartureAirportIataCode:     "FRA",
+			ArrivalTime:                  departure.Add(9 * time.Hour),
+			ArrivalAirportIataCode:       "JFK",
+			ServiceType:                  "J",
+			AircraftOwner:                "LH",
+			AircraftIataCode:             "359",
+			AircraftConfigurationVersion: "C48E21M224",
+			CodeShares:                   common.Set[db.FlightNumber]{{AirlineIataCode: "UA", Number: 9051}: {}},
+		}}
+	}
+
+	return result, nil
+}
+
+func (fakeRepo) FindFlightNumbers(ctx context.Context, query string, limit int) ([]db.FlightNumber, error) {
+	if strings.HasPrefix("LH400", query) {
+		return []db.FlightNumber{testFn}, nil
+	}
+
+	return nil, nil
+}
+
+func (fakeRepo) FindConnection(ctx context.Context, minFlights, maxFlights int, seed string) ([2]string, error) {
+	return [2]string{"FRA", "JFK"}, nil
+}
+
+func (fakeRepo) RelatedFlightNumbers(ctx context.Context, fn db.FlightNumber, version time.Time) (common.Set[db.FlightNumber], error) {
+	return common.Set[db.FlightNumber]{{AirlineIataCode: "UA", Number: 9051}: {}}, nil
+}
+
+func (r fakeRepo) RelatedFlightNumbersMany(ctx context.Context, fns []db.FlightNumber, version time.Time) (map[db.FlightNumber]common.Set[db.FlightNumber], error) {
+	result := make(map[db.FlightNumber]common.Set[db.FlightNumber], len(fns))
+	for _, fn := range fns {
+		related, err := r.RelatedFlightNumbers(ctx, fn, version)
+		if err != nil {
+			return nil, err
+		}
+
+		result[fn] = related
+	}
+
+	return result, nil
+}
+
+func (fakeRepo) FlightSchedules(ctx context.Context, fn db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedules, error) {
+	return db.FlightSchedules{
+		Items:    []db.FlightScheduleItem{testScheduleItem()},
+		Variants: map[uuid.UUID]db.FlightScheduleVariant{testVariantId: testVariant()},
+	}, nil
+}
+
+func (r fakeRepo) FlightSchedulesMany(ctx context.Context, fns []db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedulesMany, error) {
+	fsm := db.FlightSchedulesMany{
+		Schedules: make(map[db.FlightNumber][]db.FlightScheduleItem),
+		Variants:  make(map[uuid.UUID]db.FlightScheduleVariant),
+	}
+
+	for _, fn := range fns {
+		fs, err := r.FlightSchedules(ctx, fn, version, departureDateRangeLocal)
+		if err != nil {
+			return db.FlightSchedulesMany{}, err
+		}
+
+		fsm.Schedules[fn] = fs.Items
+		maps.Copy(fsm.Variants, fs.Variants)
+	}
+
+	return fsm, nil
+}
+
+func (r fakeRepo) FlightScheduleVersionsMany(ctx context.Context, keys []db.FlightInstanceKey) (db.FlightScheduleVersionsMany, error) {
+	fsvm := db.FlightScheduleVersionsMany{
+		Versions: make(map[db.FlightInstanceKey][]db.FlightScheduleVersion),
+		Variants: make(map[uuid.UUID]db.FlightScheduleVariant),
+	}
+
+	for _, key := range keys {
+		fsv, err := r.FlightScheduleVersions(ctx, key.FlightNumber, key.DepartureAirportIataCode, key.DepartureDateLocal)
+		if err != nil {
+			return db.FlightScheduleVersionsMany{}, err
+		}
+
+		fsvm.Versions[key] = fsv.Versions
+		maps.Copy(fsvm.Variants, fsv.Variants)
+	}
+
+	return fsvm, nil
+}
+
+func (fakeRepo) FlightScheduleVersions(ctx context.Context, fn db.FlightNumber, departureAirportIataCode string, departureDate xtime.LocalDate) (db.FlightScheduleVersions, error) {
+	return db.FlightScheduleVersions{
+		Versions: []db.FlightScheduleVersion{
+			{Version: testVersion, FlightVariantId: sql.Null[uuid.UUID]{V: testVariantId, Valid: true}},
+			{Version: testVersion.AddDate(0, 0, 1)},
+		},
+		Variants: map[uuid.UUID]db.FlightScheduleVariant{testVariantId: testVariant()},
+	}, nil
+}
+
+func (fakeRepo) GlobalUpdatesReport(ctx context.Context) ([]db.UpdateReportItem, error) {
+	return []db.UpdateReportItem{
+		{Version: testVersion, Added: 3, Updated: 2, Removed: 1},
+		{Version: testVersion.AddDate(0, 0, 1), Updated: 1},
+	}, nil
+}
+
+func (fakeRepo) UpdatesReport(ctx context.Context, fn db.FlightNumber, version time.Time) ([]db.UpdateReportItem, error) {
+	return []db.UpdateReportItem{{Version: testVersion, Added: 1}}, nil
+}
+
+func (r fakeRepo) UpdatesReportMany(ctx context.Context, fns []db.FlightNumber, version time.Time) (map[db.FlightNumber][]db.UpdateReportItem, error) {
+	result := make(map[db.FlightNumber][]db.UpdateReportItem, len(fns))
+	for _, fn := range fns {
+		items, err := r.UpdatesReport(ctx, fn, version)
+		if err != nil {
+			return nil, err
+		}
+
+		result[fn] = items
+	}
+
+	return result, nil
+}
+
+func (fakeRepo) UpdatesDiff(ctx context.Context, since, version time.Time, filter db.Condition, limit int) (db.FlightScheduleDiff, error) {
+	return db.FlightScheduleDiff{
+		Items: []db.FlightScheduleDiffItem{{
+			FlightInstanceKey: db.FlightInstanceKey{
+				FlightNumber:             testFn,
+				DepartureAirportIataCode: "FRA",
+				DepartureDateLocal:       xtime.NewLocalDateFromParts(2026, time.May, 1),
+			},
+			FromFlightVariantId: sql.Null[uuid.UUID]{V: testVariantId, Valid: true},
+		}, {
+			FlightInstanceKey: db.FlightInstanceKey{
+				FlightNumber:             testFn,
+				DepartureAirportIataCode: "FRA",
+				DepartureDateLocal:       xtime.NewLocalDateFromParts(2026, time.May, 2),
+			},
+			FromFlightVariantId: sql.Null[uuid.UUID]{V: testVariantId, Valid: true},
+			ToFlightVariantId:   sql.Null[uuid.UUID]{V: testSwapId, Valid: true},
+		}},
+		Variants: map[uuid.UUID]db.FlightScheduleVariant{testVariantId: testVariant(), testSwapId: testSwapVariant()},
+	}, nil
+}
+
+func (fakeRepo) EquipmentChanges(ctx context.Context, since time.Time, filter db.Condition) (db.EquipmentChanges, error) {
+	return db.EquipmentChanges{
+		Items: []db.EquipmentChange{{
+			FlightInstanceKey: db.FlightInstanceKey{
+				FlightNumber:             testFn,
+				DepartureAirportIataCode: "FRA",
+				DepartureDateLocal:       xtime.NewLocalDateFromParts(2026, time.May, 1),
+			},
+			Version:             testVersion.AddDate(0, 0, 1),
+			FromFlightVariantId: testVariantId,
+			ToFlightVariantId:   testSwapId,
+		}},
+		Variants: map[uuid.UUID]db.FlightScheduleVariant{testVariantId: testVariant(), testSwapId: testSwapVariant()},
+	}, nil
+}
+
+func (fakeRepo) Destinations(ctx context.Context, departureAirportIataCode string, asOf *time.Time) ([]string, error) {
+	return []string{"JFK"}, nil
+}
+
+func (fakeRepo) FlightSchedulesLatestRaw(ctx context.Context, filter db.Condition, asOf *time.Time) (db.FlightSchedulesMany, error) {
+	return db.FlightSchedulesMany{
+		Schedules: map[db.FlightNumber][]db.FlightScheduleItem{testFn: {testScheduleItem()}},
+		Variants:  map[uuid.UUID]db.FlightScheduleVariant{testVariantId: testVariant()},
+	}, nil
+}
+
+func (fakeRepo) IterFlightNumbers(ctx context.Context, airlineIataCode string, err *error) iter.Seq2[db.FlightNumber, time.Time] {
+	return func(yield func(db.FlightNumber, time.Time) bool) {
+		yield(testFn, testVersion)
+	}
+}
+
+func (fakeRepo) RouteStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.RouteStatistic, error) {
+	// the previous range of the route changes only served FRA-MUC
+	if end < xtime.NewLocalDateFromParts(2026, time.May, 1) {
+		return []db.RouteStatistic{
+			{Group: "LH", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "MUC", Flights: 20, Seats: db.CabinSeats{Business: 400, Economy: 2000}},
+		}, nil
+	}
+
+	return []db.RouteStatistic{
+		{Group: "LH", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK", Flights: 14, Seats: db.CabinSeats{Business: 672, Premium: 294, Economy: 3136}},
+		{Group: "LH", DepartureAirportIataCode: "JFK", ArrivalAirportIataCode: "FRA", Flights: 7, Seats: db.CabinSeats{Business: 336, Premium: 147, Economy: 1568}},
+	}, nil
+}
+
+func (fakeRepo) GroupStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.GroupStatistic, error) {
+	return []db.GroupStatistic{
+		{Group: "FRA", Flights: 14, Routes: 1, Destinations: 1, Seats: db.CabinSeats{Business: 672, Premium: 294, Economy: 3136}},
+		{Group: "JFK", Flights: 7, Routes: 1, Destinations: 1, Seats: db.CabinSeats{Business: 336, Premium: 147, Economy: 1568}},
+	}, nil
+}
+
+func testScheduleItem() db.FlightScheduleItem {
+	return db.FlightScheduleItem{
+		DepartureDateLocal:       xtime.NewLocalDateFromParts(2026, time.May, 1),
+		DepartureAirportIataCode: "FRA",
+		FlightVariantId:          sql.Null[uuid.UUID]{V: testVariantId, Valid: true},
+		Version:                  testVersion,
+		VersionCount:             1,
+	}
+}
+
+func testVariant() db.FlightScheduleVariant {
+	return db.FlightScheduleVariant{
+		Id:                           testVariantId,
+		OperatedAs:                   testFn,
+		DepartureTimeLocal:           xtime.LocalTime(10 * time.Hour),
+		DepartureUtcOffsetSeconds:    7200,
+		DurationSeconds:              9 * 60 * 60,
+		ArrivalAirportIataCode:       "JFK",
+		ArrivalUtcOffsetSeconds:      -14400,
+		ServiceType:                  "J",
+		AircraftOwner:                "LH",
+		AircraftIataCode:             "359",
+		AircraftConfigurationVersion: "C48E21M224",
+		CodeShares:                   common.Set[db.FlightNumber]{{AirlineIataCode: "UA", Number: 9051}: {}},
+		DataElements:                 map[int64]string{10: "UA 9051"},
+	}
+}
+
+type recordedExchange struct {
+	method    string
+	routePath string
+	body      []byte
+}
+
+type recorder struct {
+	mtx       sync.Mutex
+	exchanges []recordedExchange
+}
+
+func (r *recorder) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var buf bytes.Buffer
+		res := c.Response()
+		res.Writer = recordingWriter{ResponseWriter: res.Writer, w: &buf}
+
+		err := next(c)
+
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.exchanges = append(r.exchanges, recordedExchange{
+			method:    c.Request().Method,
+			routePath: c.Path(),
+			body:      buf.Bytes(),
+		})
+
+		return err
+	}
+}
+
+func (r *recorder) last() recordedExchange {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.exchanges[len(r.exchanges)-1]
+}
+
+type recordingWriter struct {
+	http.ResponseWriter
+	w io.Writer
+}
+
+func (w recordingWriter) Write(b []byte) (int, error) {
+	_, _ = w.w.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func newTestServer(t *testing.T) (*Client, *recorder) {
+	repo := fakeRepo{}
+	rec := &recorder{}
+
+	e := echo.New()
+	e.Use(rec.middleware, web.ErrorLogAndMaskMiddleware(log.New(io.Discard, "", 0)))
+
+	web.RegisterRoutes(e, repo, func() string { return testVersion.Format(time.RFC3339) }, nil, nil)
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return NewClient(WithBaseUrl(srv.URL), WithHttpClient(srv.Client())), rec
+}
+
+func testSwapVariant() db.FlightScheduleVariant {
+	v := testVariant()
+	v.Id = testSwapId
+	v.AircraftIataCode = "388"
+	v.AircraftConfigurationVersion = "F8C68E52M371"
+
+	return v
+}
+
+func TestClientConformance(t *testing.T) {
+	ctx := context.Background()
+	c, rec := newTestServer(t)
+
+	doc, err := c.OpenAPI(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	cases := map[string]func(t *testing.T){
+		"Notifications": func(t *testing.T) {
+			_, err := c.Notifications(ctx)
+			require.NoError(t, err)
+		},
+		"Search": func(t *testing.T) {
+			res, err := c.Search(ctx, "LH4")
+			require.NoError(t, err)
+			assert.Equal(t, []model.FlightNumber{{AirlineIataCode: "LH", Number: 400}}, res.FlightNumbers)
+		},
+		"ScheduleSearch": func(t *testing.T) {
+			q := make(url.Values)
+			q.Set("airlineId", "LH")
+			q.Set("aircraftId", "359")
+
+			res, err := c.ScheduleSearch(ctx, q)
+			require.NoError(t, err)
+			require.Len(t, res.Schedules, 1)
+			assert.Contains(t, res.Variants, model.UUID(testVariantId))
+		},
+		"Connections": func(t *testing.T) {
+			minDeparture := time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+			res, err := c.Connections(ctx, model.ConnectionsSearchRequest{
+				Origins:       []string{"FRA"},
+				Destinations:  []string{"JFK"},
+				MinDeparture:  minDeparture,
+				MaxDeparture:  minDeparture.Add(24 * time.Hour),
+				MaxFlights:    1,
+				MaxLayoverMS:  uint64((6 * time.Hour).Milliseconds()),
+				MaxDurationMS: uint64((24 * time.Hour).Milliseconds()),
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Data.Connections, 1)
+			assert.Contains(t, res.Data.Aircraft, "35X")
+		},
+		"Reachability": func(t *testing.T) {
+			q := make(url.Values)
+			q.Set("minDeparture", "2026-05-01T00:00:00Z")
+			q.Set("maxFlights", "1")
+
+			res, err := c.Reachability(ctx, "FRA", q)
+			require.NoError(t, err)
+			require.Len(t, res.Reachable, 1)
+			assert.Equal(t, "JFK", res.Reachable[0].AirportIataCode)
+			assert.Contains(t, res.Airports, "FRA")
+		},
+		"ConnectionsShare": func(t *testing.T) {
+			res, err := c.ConnectionsShare(ctx, model.ConnectionsSearchRequest{
+				Origins:      []string{"FRA"},
+				Destinations: []string{"JFK"},
+				MinDeparture: testVersion,
+				MaxDeparture: testVersion.Add(time.Hour),
+				MaxFlights:   1,
+			})
+			require.NoError(t, err)
+			assert.Contains(t, res.HtmlUrl, "/api/connections/share/")
+			assert.Contains(t, res.ImageUrl, "/api/connections/png/")
+		},
+		"ConnectionGame": func(t *testing.T) {
+			res, err := c.ConnectionGame(ctx, 2, 0, "seed")
+			require.NoError(t, err)
+			assert.Equal(t, model.ConnectionGameChallenge{Seed: "seed", DepartureAirportIataCode: "FRA", ArrivalAirportIataCode: "JFK"}, res)
+		},
+		"Airlines": func(t *testing.T) {
+			res, err := c.Airlines(ctx)
+			require.NoError(t, err)
+			assert.Len(t, res, 2)
+		},
+		"Airports": func(t *testing.T) {
+			res, err := c.Airports(ctx)
+			require.NoError(t, err)
+			assert.Len(t, res, 2)
+		},
+		"Aircraft": func(t *testing.T) {
+			res, err := c.Aircraft(ctx)
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+
+			for _, ac := range res {
+				switch ac.Id {
+				case "35X":
+					assert.NotNil(t, ac.AircraftFamily)
+				case "359":
+					require.NotNil(t, ac.AircraftType)
+					assert.Equal(t, "A359", ac.AircraftType.IcaoCode)
+				}
+			}
+		},
+		"FlightSchedule": func(t *testing.T) {
+			res, err := c.FlightSchedule(ctx, 2026, "LH400", "")
+			require.NoError(t, err)
+			assert.Equal(t, model.FlightNumber{AirlineIataCode: "LH", Number: 400}, res.FlightNumber)
+			assert.Len(t, res.Items, 1)
+		},
+		"FlightScheduleVersion": func(t *testing.T) {
+			_, err := c.FlightSchedule(ctx, 2026, "DLH400", testVersion.Format(time.RFC3339))
+			require.NoError(t, err)
+		},
+		"FlightScheduleVersions": func(t *testing.T) {
+			res, err := c.FlightScheduleVersions(ctx, "LH400", "FRA", xtime.NewLocalDateFromParts(2026, time.May, 1))
+			require.NoError(t, err)
+			assert.Len(t, res.Versions, 2)
+		},
+		"Destinations": func(t *testing.T) {
+			res, err := c.Destinations(ctx, "FRA")
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, "JFK", res[0].IataCode)
+		},
+		"GlobalUpdates": func(t *testing.T) {
+			res, err := c.GlobalUpdates(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []model.UpdateReportItem{{Version: testVersion, Added: 3, Updated: 2, Removed: 1}, {Version: testVersion.AddDate(0, 0, 1), Updated: 1}}, res)
+		},
+		"UpdatesDiff": func(t *testing.T) {
+			res, err := c.UpdatesDiff(ctx, testVersion.Add(time.Hour).Format(time.RFC3339), "", []string{"LH"}, "JFK", 10)
+			require.NoError(t, err)
+			assert.Equal(t, testVersion, res.Since)
+			require.Len(t, res.Flights, 2)
+			assert.Equal(t, []updates.Change{{Type: updates.ChangeTypeCancellation}}, res.Flights[0].Changes)
+			assert.Equal(t, []updates.Change{{Type: updates.ChangeTypeAircraftSwap, From: "359", To: "388"}}, res.Flights[1].Changes)
+		},
+		"Alerts": func(t *testing.T) {
+			q := make(url.Values)
+			q.Set("airlineId", "DLH")
+
+			res, err := c.Alerts(ctx, q)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, []updates.AlertType{updates.AlertTypeEquipmentSwap}, res[0].Types)
+			assert.Equal(t, "BC Retrofit 2026", res[0].To.AircraftConfigurationName)
+		},
+		"AnalyticsRouteFrequencies": func(t *testing.T) {
+			res, err := c.AnalyticsRouteFrequencies(ctx, analyticsQuery("airline"))
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+			assert.Equal(t, "FRA", res[0].DepartureAirportIataCode)
+			assert.Equal(t, 7.0, res[0].WeeklyFrequency)
+			assert.Equal(t, 4102, res[0].Seats.Total)
+		},
+		"AnalyticsCapacity": func(t *testing.T) {
+			res, err := c.AnalyticsCapacity(ctx, analyticsQuery("airport"))
+			require.NoError(t, err)
+			require.Len(t, res, 2)
+			assert.Equal(t, "FRA", res[0].Group)
+			assert.Equal(t, 1, res[0].Destinations)
+		},
+		"AnalyticsBusiestRoutes": func(t *testing.T) {
+			q := analyticsQuery("airline")
+			q.Set("limit", "1")
+
+			res, err := c.AnalyticsBusiestRoutes(ctx, q)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			assert.Equal(t, "JFK", res[0].ArrivalAirportIataCode)
+		},
+		"AnalyticsRouteChanges": func(t *testing.T) {
+			res, err := c.AnalyticsRouteChanges(ctx, analyticsQuery("airline"))
+			require.NoError(t, err)
+			assert.Equal(t, xtime.NewLocalDateFromParts(2026, time.April, 17), res.PreviousStart)
+			assert.Len(t, res.New, 2)
+			require.Len(t, res.Dropped, 1)
+			assert.Equal(t, "MUC", res.Dropped[0].ArrivalAirportIataCode)
+		},
+		"Schedule": func(t *testing.T) {
+			_, err := c.Schedule(ctx, 2026, ScheduleLH747)
+			require.NoError(t, err)
+		},
+	}
+
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			fn(t)
+
+			exchange := rec.last()
+			path := openAPIPath(exchange.routePath)
+			item, ok := doc.Paths[path]
+			require.True(t, ok, "path %q is not documented", path)
+
+			op, ok := item[strings.ToLower(exchange.method)]
+			require.True(t, ok, "operation %s %q is not documented", exchange.method, path)
+
+			content, ok := op.Responses["200"].Content[echo.MIMEApplicationJSON]
+			require.True(t, ok, "operation %s %q has no json response", exchange.method, path)
+
+			var v any
+			require.NoError(t, json.Unmarshal(exchange.body, &v))
+			assert.NoError(t, validate(doc, content.Schema, v, "$"))
+		})
+	}
+}
+
+func analyticsQuery(groupBy string) url.Values {
+	q := make(url.Values)
+	q.Set("start", "2026-05-01")
+	q.Set("end", "2026-05-14")
+	q.Set("groupBy", groupBy)
+	q.Set("airlineId", "DLH")
+
+	return q
+}
+
+func TestClientReturnsStatusErr(t *testing.T) {
+	c, _ := newTestServer(t)
+
+	_, err := c.FlightSchedule(context.Background(), 26, "LH400", "")
+
+	var statusErr ResponseStatusErr
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+}
+
+func openAPIPath(echoPath string) string {
+	parts := strings.Split(echoPath, "/")
+	for i, part := range parts {
+		if name, ok := strings.CutPrefix(part, ":"); ok {
+			parts[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+func validate(doc openapi.Document, schema *openapi.Schema, v any, path string) error {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[name]
+		if !ok {
+			return fmt.Errorf("%s: unresolved reference %q", path, schema.Ref)
+		}
+
+		return validate(doc, resolved, v, path)
+	}
+
+	if v == nil {
+		if schema.Type == "" || schema.Nullable {
+			return nil
+		}
+
+		return fmt.Errorf("%s: unexpected null", path)
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, v)
+		}
+
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+
+		for name, value := range obj {
+			propSchema, ok := schema.Properties[name]
+			if !ok {
+				propSchema = schema.AdditionalProperties
+			}
+
+			if propSchema == nil {
+				return fmt.Errorf("%s: undocumented property %q", path, name)
+			}
+
+			if err := validate(doc, propSchema, value, path+"."+name); err != nil {
+				return err
+			}
+		}
+
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, v)
+		}
+
+		for i, value := range arr {
+			if err := validate(doc, schema.Items, value, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, v)
+		}
+
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, v) {
+			return fmt.Errorf("%s: %v is not one of %v", path, v, schema.Enum)
+		}
+
+	case "integer", "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", path, v)
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, v)
+		}
+	}
+
+	return nil
+}
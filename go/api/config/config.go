@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/explore-flights/monorepo/go/api/db"
 	"github.com/explore-flights/monorepo/go/api/web"
@@ -18,6 +19,7 @@ type S3Client interface {
 
 type Accessor interface {
 	EchoPort() int
+	GrpcPort() int
 	S3Client(ctx context.Context) (S3Client, error)
 	DataBucket() (string, error)
 	ParquetBucket() (string, error)
@@ -25,4 +27,6 @@ type Accessor interface {
 	LufthansaClient() (*lufthansa.Client, error)
 	Database() (*db.Database, error)
 	Version() (string, error)
+	DatasetSource(ctx context.Context) (db.DatasetSource, error)
+	DatasetReloadInterval() time.Duration
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/explore-flights/monorepo/go/api/auth"
 	"github.com/explore-flights/monorepo/go/api/db"
 	"github.com/explore-flights/monorepo/go/api/web"
+	"github.com/explore-flights/monorepo/go/common/adapt"
 	"github.com/explore-flights/monorepo/go/common/lufthansa"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/time/rate"
@@ -72,6 +73,12 @@ func (*accessor) EchoPort() int {
 	return cmp.Or(port, 8080)
 }
 
+// GrpcPort returns 0 unless FLIGHTS_GRPC_PORT is set, since the lambda web adapter only forwards HTTP/1.1
+func (*accessor) GrpcPort() int {
+	port, _ := strconv.Atoi(os.Getenv("FLIGHTS_GRPC_PORT"))
+	return port
+}
+
 func (a *accessor) S3Client(ctx context.Context) (S3Client, error) {
 	cfg, err := a.awsConfig()
 	if err != nil {
@@ -182,6 +189,72 @@ func (*accessor) Version() (string, error) {
 	return string(b), nil
 }
 
+func (a *accessor) DatasetSource(ctx context.Context) (db.DatasetSource, error) {
+	s3c, err := a.S3Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dataBucketName, err := a.DataBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	parquetBucketName, err := a.ParquetBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3DatasetSource{
+		s3c:               s3c,
+		dataBucketName:    dataBucketName,
+		parquetBucketName: parquetBucketName,
+	}, nil
+}
+
+// DatasetReloadInterval returns 0 (disabled) unless FLIGHTS_DATASET_RELOAD_INTERVAL is set
+func (*accessor) DatasetReloadInterval() time.Duration {
+	d, _ := time.ParseDuration(os.Getenv("FLIGHTS_DATASET_RELOAD_INTERVAL"))
+	return d
+}
+
+// s3DatasetSource reads the version published by the update_lambda_layer action and reads all files of that version from S3,
+// including the copy of the base data stored alongside the parquet files of the version
+type s3DatasetSource struct {
+	s3c               adapt.S3Getter
+	dataBucketName    string
+	parquetBucketName string
+}
+
+func (s *s3DatasetSource) Version(ctx context.Context) (string, error) {
+	resp, err := s.s3c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.dataBucketName),
+		Key:    aws.String("processed/version.txt"),
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func (s *s3DatasetSource) Database(ctx context.Context, version string) (*db.Database, error) {
+	return db.NewDatabase(
+		fmt.Sprintf("s3://%s/%s/basedata.db", s.parquetBucketName, version),
+		fmt.Sprintf("s3://%s/%s/variants.parquet", s.parquetBucketName, version),
+		fmt.Sprintf("s3://%s/%s/connections.parquet", s.parquetBucketName, version),
+		fmt.Sprintf("s3://%s/%s/history", s.parquetBucketName, version),
+		fmt.Sprintf("s3://%s/%s/latest", s.parquetBucketName, version),
+		fmt.Sprintf("s3://%s/%s/updates_report", s.parquetBucketName, version),
+	), nil
+}
+
 func (a *accessor) getSsmParams() (map[string]string, error) {
 	<-a.ssmParamsDone
 	return a.ssmParams, a.ssmParamsErr
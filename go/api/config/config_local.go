@@ -29,6 +29,10 @@ func (accessor) EchoPort() int {
 	return 8080
 }
 
+func (accessor) GrpcPort() int {
+	return 8081
+}
+
 func (accessor) S3Client(ctx context.Context) (S3Client, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -125,3 +129,26 @@ func (accessor) Version() (string, error) {
 
 	return string(b), nil
 }
+
+func (a accessor) DatasetSource(ctx context.Context) (db.DatasetSource, error) {
+	return localDatasetSource{a}, nil
+}
+
+// DatasetReloadInterval returns 0 (disabled) unless FLIGHTS_DATASET_RELOAD_INTERVAL is set
+func (accessor) DatasetReloadInterval() time.Duration {
+	d, _ := time.ParseDuration(os.Getenv("FLIGHTS_DATASET_RELOAD_INTERVAL"))
+	return d
+}
+
+// localDatasetSource picks up a new version.txt in the local s3 folder; the files themselves are replaced in place
+type localDatasetSource struct {
+	a accessor
+}
+
+func (s localDatasetSource) Version(ctx context.Context) (string, error) {
+	return s.a.Version()
+}
+
+func (s localDatasetSource) Database(ctx context.Context, version string) (*db.Database, error) {
+	return s.a.Database()
+}
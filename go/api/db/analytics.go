@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+type AnalyticsGrouping string
+
+const (
+	AnalyticsGroupingNone    = AnalyticsGrouping("")
+	AnalyticsGroupingAirline = AnalyticsGrouping("airline")
+	// AnalyticsGroupingAirport and AnalyticsGroupingCountry group by the departure airport
+	AnalyticsGroupingAirport  = AnalyticsGrouping("airport")
+	AnalyticsGroupingCountry  = AnalyticsGrouping("country")
+	AnalyticsGroupingAircraft = AnalyticsGrouping("aircraft")
+)
+
+func (g AnalyticsGrouping) Valid() bool {
+	switch g {
+	case AnalyticsGroupingNone, AnalyticsGroupingAirline, AnalyticsGroupingAirport, AnalyticsGroupingCountry, AnalyticsGroupingAircraft:
+		return true
+	}
+
+	return false
+}
+
+func (g AnalyticsGrouping) expression() string {
+	switch g {
+	case AnalyticsGroupingAirline:
+		return "fvhl.airline_iata_code"
+
+	case AnalyticsGroupingAirport:
+		return "fvhl.departure_airport_iata_code"
+
+	case AnalyticsGroupingCountry:
+		return "COALESCE(dep.country_code, '')"
+
+	case AnalyticsGroupingAircraft:
+		return "fvhl.aircraft_iata_code"
+	}
+
+	return "''"
+}
+
+// RouteStatistics aggregates the operating flights departing within [start, end] (local) per group and route.
+// The filter may reference the latest history as fvhl and the departure airport as dep.
+func (fr *FlightRepo) RouteStatistics(ctx context.Context, start, end xtime.LocalDate, grouping AnalyticsGrouping, filter Condition) ([]RouteStatistic, error) {
+	result := make([]RouteStatistic, 0)
+	err := fr.analyticsQuery(
+		ctx,
+		`
+SELECT
+    :group,
+    fvhl.departure_airport_iata_code,
+    fvhl.arrival_airport_iata_code,
+    COUNT(*),
+    CAST(SUM(fvhl.seats_first) AS BIGINT),
+    CAST(SUM(fvhl.seats_business) AS BIGINT),
+    CAST(SUM(fvhl.seats_premium) AS BIGINT),
+    CAST(SUM(fvhl.seats_economy) AS BIGINT)
+FROM flight_variant_history_latest fvhl
+LEFT JOIN airports dep
+ON fvhl.departure_airport_iata_code = dep.iata_code
+WHERE :filter
+GROUP BY ALL
+`,
+		start,
+		end,
+		grouping,
+		filter,
+		func(rows *sql.Rows) error {
+			var rs RouteStatistic
+			err := rows.Scan(
+				&rs.Group,
+				&rs.DepartureAirportIataCode,
+				&rs.ArrivalAirportIataCode,
+				&rs.Flights,
+				&rs.Seats.First,
+				&rs.Seats.Business,
+				&rs.Seats.Premium,
+				&rs.Seats.Economy,
+			)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, rs)
+			return nil
+		},
+	)
+
+	return result, err
+}
+
+// GroupStatistics aggregates the operating flights departing within [start, end] (local) per group.
+// The filter may reference the latest history as fvhl and the departure airport as dep.
+func (fr *FlightRepo) GroupStatistics(ctx context.Context, start, end xtime.LocalDate, grouping AnalyticsGrouping, filter Condition) ([]GroupStatistic, error) {
+	result := make([]GroupStatistic, 0)
+	err := fr.analyticsQuery(
+		ctx,
+		`
+SELECT
+    :group,
+    COUNT(*),
+    COUNT(DISTINCT CONCAT(fvhl.departure_airport_iata_code, '-', fvhl.arrival_airport_iata_code)),
+    COUNT(DISTINCT fvhl.arrival_airport_iata_code),
+    CAST(SUM(fvhl.seats_first) AS BIGINT),
+    CAST(SUM(fvhl.seats_business) AS BIGINT),
+    CAST(SUM(fvhl.seats_premium) AS BIGINT),
+    CAST(SUM(fvhl.seats_economy) AS BIGINT)
+FROM flight_variant_history_latest fvhl
+LEFT JOIN airports dep
+ON fvhl.departure_airport_iata_code = dep.iata_code
+WHERE :filter
+GROUP BY ALL
+`,
+		start,
+		end,
+		grouping,
+		filter,
+		func(rows *sql.Rows) error {
+			var gs GroupStatistic
+			err := rows.Scan(
+				&gs.Group,
+				&gs.Flights,
+				&gs.Routes,
+				&gs.Destinations,
+				&gs.Seats.First,
+				&gs.Seats.Business,
+				&gs.Seats.Premium,
+				&gs.Seats.Economy,
+			)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, gs)
+			return nil
+		},
+	)
+
+	return result, err
+}
+
+func (fr *FlightRepo) analyticsQuery(ctx context.Context, query string, start, end xtime.LocalDate, grouping AnalyticsGrouping, filter Condition, scan func(rows *sql.Rows) error) error {
+	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	condition := AndCondition{
+		// the local departure date is at most one day off the UTC departure date; allows pruning the hive partitions
+		BaseCondition{
+			Filter: "MAKE_DATE(fvhl.year_utc, fvhl.month_utc, fvhl.day_utc) BETWEEN CAST(? AS DATE) AND CAST(? AS DATE)",
+			Params: []any{(start - 1).String(), (end + 1).String()},
+		},
+		BaseCondition{
+			Filter: "fvhl.departure_date_local BETWEEN CAST(? AS DATE) AND CAST(? AS DATE)",
+			Params: []any{start.String(), end.String()},
+		},
+	}
+
+	if filter != nil {
+		condition = append(condition, filter)
+	}
+
+	filterStr, params := condition.Condition()
+	query = strings.Replace(query, ":group", grouping.expression(), 1)
+	query = strings.Replace(query, ":filter", filterStr, 1)
+
+	rows, err := conn.QueryContext(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
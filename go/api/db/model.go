@@ -143,6 +143,17 @@ type FlightScheduleVersions struct {
 	Variants map[uuid.UUID]FlightScheduleVariant
 }
 
+type FlightInstanceKey struct {
+	FlightNumber
+	DepartureAirportIataCode string
+	DepartureDateLocal       xtime.LocalDate
+}
+
+type FlightScheduleVersionsMany struct {
+	Versions map[FlightInstanceKey][]FlightScheduleVersion
+	Variants map[uuid.UUID]FlightScheduleVariant
+}
+
 type FlightScheduleVersion struct {
 	Version         time.Time
 	FlightVariantId sql.Null[uuid.UUID]
@@ -161,3 +172,52 @@ type UpdateReportItem struct {
 	Added   int
 	Updated int
 }
+
+type FlightScheduleDiff struct {
+	Items    []FlightScheduleDiffItem
+	Variants map[uuid.UUID]FlightScheduleVariant
+	// Truncated is true if more flight instances than requested changed
+	Truncated bool
+}
+
+type FlightScheduleDiffItem struct {
+	FlightInstanceKey
+	FromFlightVariantId sql.Null[uuid.UUID]
+	ToFlightVariantId   sql.Null[uuid.UUID]
+}
+
+type EquipmentChanges struct {
+	Items    []EquipmentChange
+	Variants map[uuid.UUID]FlightScheduleVariant
+}
+
+// EquipmentChange is a change of the aircraft or its configuration of an operating flight instance, introduced by Version
+type EquipmentChange struct {
+	FlightInstanceKey
+	Version             time.Time
+	FromFlightVariantId uuid.UUID
+	ToFlightVariantId   uuid.UUID
+}
+
+type CabinSeats struct {
+	First    int
+	Business int
+	Premium  int
+	Economy  int
+}
+
+type RouteStatistic struct {
+	Group                    string
+	DepartureAirportIataCode string
+	ArrivalAirportIataCode   string
+	Flights                  int
+	Seats                    CabinSeats
+}
+
+type GroupStatistic struct {
+	Group        string
+	Flights      int
+	Routes       int
+	Destinations int
+	Seats        CabinSeats
+}
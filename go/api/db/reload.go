@@ -0,0 +1,326 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+var ErrClosed = errors.New("flight repo closed")
+
+type DatasetSource interface {
+	// Version returns the most recently published dataset version
+	Version(ctx context.Context) (string, error)
+	// Database builds the database of the given dataset version
+	Database(ctx context.Context, version string) (*Database, error)
+}
+
+type flightRepoVersion struct {
+	version  string
+	database *Database
+	fr       *FlightRepo
+	mtx      sync.RWMutex
+	closed   bool
+}
+
+// drain blocks until all in-flight calls on this version have finished and closes its database afterwards
+func (v *flightRepoVersion) drain() error {
+	v.mtx.Lock()
+	closed := v.closed
+	v.closed = true
+	v.mtx.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	// the preloads might still be running on an unused version
+	_, _ = v.fr.Airlines(context.Background())
+	_, _ = v.fr.Airports(context.Background())
+	_, _ = v.fr.Aircraft(context.Background())
+
+	return v.database.Close()
+}
+
+// ReloadableFlightRepo serves all calls from the FlightRepo of the current dataset version.
+// Reload swaps to a new version atomically; calls already running on the previous version finish before its database is closed.
+type ReloadableFlightRepo struct {
+	current   atomic.Pointer[flightRepoVersion]
+	reloadMtx sync.Mutex
+}
+
+func NewReloadableFlightRepo(version string, database *Database) *ReloadableFlightRepo {
+	r := &ReloadableFlightRepo{}
+	r.current.Store(&flightRepoVersion{
+		version:  version,
+		database: database,
+		fr:       NewFlightRepo(database),
+	})
+
+	return r
+}
+
+func (r *ReloadableFlightRepo) Version() string {
+	return r.current.Load().version
+}
+
+// Watch polls the source for new dataset versions until ctx is done
+func (r *ReloadableFlightRepo) Watch(ctx context.Context, source DatasetSource, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		reloaded, err := r.Reload(ctx, source)
+		if err != nil {
+			slog.Error("failed to reload dataset", slog.String("err", err.Error()))
+		} else if reloaded {
+			slog.Info("reloaded dataset", slog.String("version", r.Version()))
+		}
+	}
+}
+
+// Reload builds the database of the latest version of the source if it differs from the current version.
+// The new version only replaces the current one once its reference data has been loaded successfully.
+func (r *ReloadableFlightRepo) Reload(ctx context.Context, source DatasetSource) (bool, error) {
+	r.reloadMtx.Lock()
+	defer r.reloadMtx.Unlock()
+
+	version, err := source.Version(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if version == r.Version() {
+		return false, nil
+	}
+
+	database, err := source.Database(ctx, version)
+	if err != nil {
+		return false, err
+	}
+
+	next := &flightRepoVersion{
+		version:  version,
+		database: database,
+		fr:       NewFlightRepo(database),
+	}
+
+	if err = warmup(ctx, next.fr); err != nil {
+		return false, errors.Join(err, next.drain())
+	}
+
+	prev := r.current.Swap(next)
+	return true, prev.drain()
+}
+
+func (r *ReloadableFlightRepo) Close() error {
+	r.reloadMtx.Lock()
+	defer r.reloadMtx.Unlock()
+
+	return r.current.Load().drain()
+}
+
+func (r *ReloadableFlightRepo) acquire() (*flightRepoVersion, error) {
+	for {
+		v := r.current.Load()
+		v.mtx.RLock()
+		if !v.closed {
+			return v, nil
+		}
+
+		v.mtx.RUnlock()
+
+		if r.current.Load() == v {
+			return nil, ErrClosed
+		}
+	}
+}
+
+func (r *ReloadableFlightRepo) Flights(ctx context.Context, start, end xtime.LocalDate, asOf *time.Time) (map[xtime.LocalDate][]Flight, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (map[xtime.LocalDate][]Flight, error) {
+		return fr.Flights(ctx, start, end, asOf)
+	})
+}
+
+func (r *ReloadableFlightRepo) Airlines(ctx context.Context) (map[string]Airline, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (map[string]Airline, error) {
+		return fr.Airlines(ctx)
+	})
+}
+
+func (r *ReloadableFlightRepo) Airports(ctx context.Context) (map[string]Airport, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (map[string]Airport, error) {
+		return fr.Airports(ctx)
+	})
+}
+
+func (r *ReloadableFlightRepo) Aircraft(ctx context.Context) (map[string]Aircraft, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (map[string]Aircraft, error) {
+		return fr.Aircraft(ctx)
+	})
+}
+
+func (r *ReloadableFlightRepo) FindFlightNumbers(ctx context.Context, query string, limit int) ([]FlightNumber, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([]FlightNumber, error) {
+		return fr.FindFlightNumbers(ctx, query, limit)
+	})
+}
+
+// IterFlightNumbers reads all flight numbers before yielding the first one, so a slow consumer does not block a reload from closing the version
+func (r *ReloadableFlightRepo) IterFlightNumbers(ctx context.Context, airlineIataCode string, outErr *error) iter.Seq2[FlightNumber, time.Time] {
+	return func(yield func(FlightNumber, time.Time) bool) {
+		flightNumbers, err := withFlightRepo(r, func(fr *FlightRepo) ([]common.Tuple[FlightNumber, time.Time], error) {
+			var err error
+			result := make([]common.Tuple[FlightNumber, time.Time], 0)
+			for fn, lastModified := range fr.IterFlightNumbers(ctx, airlineIataCode, &err) {
+				result = append(result, common.Tuple[FlightNumber, time.Time]{V1: fn, V2: lastModified})
+			}
+
+			return result, err
+		})
+
+		if err != nil {
+			*outErr = err
+			return
+		}
+
+		for _, t := range flightNumbers {
+			if !yield(t.V1, t.V2) {
+				return
+			}
+		}
+	}
+}
+
+func (r *ReloadableFlightRepo) RelatedFlightNumbers(ctx context.Context, fn FlightNumber, version time.Time) (common.Set[FlightNumber], error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (common.Set[FlightNumber], error) {
+		return fr.RelatedFlightNumbers(ctx, fn, version)
+	})
+}
+
+func (r *ReloadableFlightRepo) RelatedFlightNumbersMany(ctx context.Context, fns []FlightNumber, version time.Time) (map[FlightNumber]common.Set[FlightNumber], error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (map[FlightNumber]common.Set[FlightNumber], error) {
+		return fr.RelatedFlightNumbersMany(ctx, fns, version)
+	})
+}
+
+func (r *ReloadableFlightRepo) FlightSchedules(ctx context.Context, fn FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (FlightSchedules, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (FlightSchedules, error) {
+		return fr.FlightSchedules(ctx, fn, version, departureDateRangeLocal)
+	})
+}
+
+func (r *ReloadableFlightRepo) FlightSchedulesMany(ctx context.Context, fns []FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (FlightSchedulesMany, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (FlightSchedulesMany, error) {
+		return fr.FlightSchedulesMany(ctx, fns, version, departureDateRangeLocal)
+	})
+}
+
+func (r *ReloadableFlightRepo) GlobalUpdatesReport(ctx context.Context) ([]UpdateReportItem, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([]UpdateReportItem, error) {
+		return fr.GlobalUpdatesReport(ctx)
+	})
+}
+
+func (r *ReloadableFlightRepo) UpdatesReport(ctx context.Context, fn FlightNumber, version time.Time) ([]UpdateReportItem, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([]UpdateReportItem, error) {
+		return fr.UpdatesReport(ctx, fn, version)
+	})
+}
+
+func (r *ReloadableFlightRepo) UpdatesReportMany(ctx context.Context, fns []FlightNumber, version time.Time) (map[FlightNumber][]UpdateReportItem, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (map[FlightNumber][]UpdateReportItem, error) {
+		return fr.UpdatesReportMany(ctx, fns, version)
+	})
+}
+
+func (r *ReloadableFlightRepo) UpdatesDiff(ctx context.Context, since, version time.Time, filter Condition, limit int) (FlightScheduleDiff, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (FlightScheduleDiff, error) {
+		return fr.UpdatesDiff(ctx, since, version, filter, limit)
+	})
+}
+
+func (r *ReloadableFlightRepo) EquipmentChanges(ctx context.Context, since time.Time, filter Condition) (EquipmentChanges, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (EquipmentChanges, error) {
+		return fr.EquipmentChanges(ctx, since, filter)
+	})
+}
+
+func (r *ReloadableFlightRepo) RouteStatistics(ctx context.Context, start, end xtime.LocalDate, grouping AnalyticsGrouping, filter Condition) ([]RouteStatistic, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([]RouteStatistic, error) {
+		return fr.RouteStatistics(ctx, start, end, grouping, filter)
+	})
+}
+
+func (r *ReloadableFlightRepo) GroupStatistics(ctx context.Context, start, end xtime.LocalDate, grouping AnalyticsGrouping, filter Condition) ([]GroupStatistic, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([]GroupStatistic, error) {
+		return fr.GroupStatistics(ctx, start, end, grouping, filter)
+	})
+}
+
+func (r *ReloadableFlightRepo) FlightSchedulesLatestRaw(ctx context.Context, filter Condition, asOf *time.Time) (FlightSchedulesMany, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (FlightSchedulesMany, error) {
+		return fr.FlightSchedulesLatestRaw(ctx, filter, asOf)
+	})
+}
+
+func (r *ReloadableFlightRepo) FlightScheduleVersions(ctx context.Context, fn FlightNumber, departureAirportIataCode string, departureDate xtime.LocalDate) (FlightScheduleVersions, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (FlightScheduleVersions, error) {
+		return fr.FlightScheduleVersions(ctx, fn, departureAirportIataCode, departureDate)
+	})
+}
+
+func (r *ReloadableFlightRepo) FlightScheduleVersionsMany(ctx context.Context, keys []FlightInstanceKey) (FlightScheduleVersionsMany, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) (FlightScheduleVersionsMany, error) {
+		return fr.FlightScheduleVersionsMany(ctx, keys)
+	})
+}
+
+func (r *ReloadableFlightRepo) Destinations(ctx context.Context, departureAirportIataCode string, asOf *time.Time) ([]string, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([]string, error) {
+		return fr.Destinations(ctx, departureAirportIataCode, asOf)
+	})
+}
+
+func (r *ReloadableFlightRepo) FindConnection(ctx context.Context, minFlights, maxFlights int, seed string) ([2]string, error) {
+	return withFlightRepo(r, func(fr *FlightRepo) ([2]string, error) {
+		return fr.FindConnection(ctx, minFlights, maxFlights, seed)
+	})
+}
+
+func withFlightRepo[T any](r *ReloadableFlightRepo, fn func(fr *FlightRepo) (T, error)) (T, error) {
+	v, err := r.acquire()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer v.mtx.RUnlock()
+
+	return fn(v.fr)
+}
+
+func warmup(ctx context.Context, fr *FlightRepo) error {
+	if _, err := fr.Airlines(ctx); err != nil {
+		return err
+	}
+
+	if _, err := fr.Airports(ctx); err != nil {
+		return err
+	}
+
+	_, err := fr.Aircraft(ctx)
+	return err
+}
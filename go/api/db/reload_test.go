@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/duckdb/duckdb-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReloadTestDatabase creates an in-memory database whose only airline is named after the version
+func newReloadTestDatabase(t *testing.T, version string, withTables bool) *Database {
+	connector, err := duckdb.NewConnector("", nil)
+	require.NoError(t, err)
+
+	database := sql.OpenDB(connector)
+	if withTables {
+		for _, q := range []string{
+			`CREATE TABLE airlines (iata_code TEXT, icao_code TEXT, name TEXT)`,
+			fmt.Sprintf(`INSERT INTO airlines VALUES ('LH', 'DLH', '%s')`, version),
+			`CREATE TABLE airports (iata_code TEXT, icao_code TEXT, iata_area_code TEXT, country_code TEXT, city_code TEXT, type TEXT, lng DOUBLE, lat DOUBLE, timezone TEXT, name TEXT)`,
+			`CREATE TABLE aircraft (iata_code TEXT, parent_iata_code TEXT, icao_code TEXT, wtc TEXT, engine_count SMALLINT, engine_type TEXT, name TEXT)`,
+			`CREATE TABLE flight_variants (operating_airline_iata_code TEXT, aircraft_iata_code TEXT, aircraft_configuration_version TEXT)`,
+			`CREATE TABLE flight_numbers (airline_iata_code TEXT, number USMALLINT, suffix TEXT)`,
+			`INSERT INTO flight_numbers SELECT 'LH', range, '' FROM range(400, 420)`,
+			`CREATE TABLE flight_variant_history (airline_iata_code TEXT, number USMALLINT, suffix TEXT, created_at TIMESTAMPTZ)`,
+			`INSERT INTO flight_variant_history SELECT airline_iata_code, number, suffix, TIMESTAMPTZ '2026-01-01 00:00:00+00' FROM flight_numbers`,
+		} {
+			_, err = database.Exec(q)
+			require.NoError(t, err, q)
+		}
+	}
+
+	initDone := make(chan struct{})
+	close(initDone)
+
+	return &Database{
+		initDone:  initDone,
+		connector: connector,
+		database:  database,
+	}
+}
+
+type reloadTestSource struct {
+	t          *testing.T
+	mtx        sync.Mutex
+	version    string
+	withTables bool
+	databases  []*Database
+}
+
+func (s *reloadTestSource) publish(version string, withTables bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.version = version
+	s.withTables = withTables
+}
+
+func (s *reloadTestSource) Version(ctx context.Context) (string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.version, nil
+}
+
+func (s *reloadTestSource) Database(ctx context.Context, version string) (*Database, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	database := newReloadTestDatabase(s.t, version, s.withTables)
+	s.databases = append(s.databases, database)
+
+	return database, nil
+}
+
+func airlineName(t *testing.T, r *ReloadableFlightRepo) string {
+	airlines, err := r.Airlines(context.Background())
+	require.NoError(t, err)
+
+	return airlines["LH"].Name
+}
+
+func TestReloadableFlightRepo_Reload(t *testing.T) {
+	ctx := context.Background()
+	initial := newReloadTestDatabase(t, "v1", true)
+	r := NewReloadableFlightRepo("v1", initial)
+	t.Cleanup(func() { _ = r.Close() })
+
+	source := &reloadTestSource{t: t}
+	source.publish("v1", true)
+
+	reloaded, err := r.Reload(ctx, source)
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Equal(t, "v1", airlineName(t, r))
+
+	source.publish("v2", true)
+	reloaded, err = r.Reload(ctx, source)
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "v2", r.Version())
+	assert.Equal(t, "v2", airlineName(t, r))
+
+	// the previous version is closed once swapped out
+	_, err = initial.Conn(ctx)
+	assert.Error(t, err)
+}
+
+func TestReloadableFlightRepo_ReloadKeepsCurrentOnWarmupFailure(t *testing.T) {
+	ctx := context.Background()
+	r := NewReloadableFlightRepo("v1", newReloadTestDatabase(t, "v1", true))
+	t.Cleanup(func() { _ = r.Close() })
+
+	source := &reloadTestSource{t: t}
+	source.publish("v2", false)
+
+	reloaded, err := r.Reload(ctx, source)
+	assert.Error(t, err)
+	assert.False(t, reloaded)
+	assert.Equal(t, "v1", r.Version())
+	assert.Equal(t, "v1", airlineName(t, r))
+
+	require.Len(t, source.databases, 1)
+	_, err = source.databases[0].Conn(ctx)
+	assert.Error(t, err)
+}
+
+func TestReloadableFlightRepo_Close(t *testing.T) {
+	r := NewReloadableFlightRepo("v1", newReloadTestDatabase(t, "v1", true))
+	require.NoError(t, r.Close())
+
+	_, err := r.Airlines(context.Background())
+	assert.ErrorIs(t, err, ErrClosed)
+
+	var iterErr error
+	for range r.IterFlightNumbers(context.Background(), "LH", &iterErr) {
+		t.Fatal("no flight number expected")
+	}
+
+	assert.ErrorIs(t, iterErr, ErrClosed)
+}
+
+func TestReloadableFlightRepo_IterFlightNumbersDoesNotBlockReload(t *testing.T) {
+	ctx := context.Background()
+	r := NewReloadableFlightRepo("v1", newReloadTestDatabase(t, "v1", true))
+	t.Cleanup(func() { _ = r.Close() })
+
+	source := &reloadTestSource{t: t}
+	source.publish("v2", true)
+
+	var err error
+	count := 0
+	for fn := range r.IterFlightNumbers(ctx, "LH", &err) {
+		if count == 0 {
+			// a reload while the consumer is paused must not wait for the iteration to finish
+			done := make(chan error, 1)
+			go func() {
+				_, err := r.Reload(ctx, source)
+				done <- err
+			}()
+
+			select {
+			case reloadErr := <-done:
+				require.NoError(t, reloadErr)
+			case <-time.After(time.Second * 10):
+				t.Fatal("reload blocked by iteration")
+			}
+		}
+
+		assert.Equal(t, 400+count, fn.Number)
+		count++
+	}
+
+	require.NoError(t, err)
+	assert.Equal(t, 20, count)
+	assert.Equal(t, "v2", r.Version())
+}
+
+func TestReloadableFlightRepo_ConcurrentReload(t *testing.T) {
+	ctx := context.Background()
+	r := NewReloadableFlightRepo("v0", newReloadTestDatabase(t, "v0", true))
+	t.Cleanup(func() { _ = r.Close() })
+
+	source := &reloadTestSource{t: t}
+	stop := make(chan struct{})
+	errs := make(chan error, 16)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				airlines, err := r.Airlines(ctx)
+				if err != nil {
+					errs <- err
+					return
+				} else if len(airlines) != 1 {
+					errs <- errors.New("expected exactly one airline")
+					return
+				}
+
+				count := 0
+				for range r.IterFlightNumbers(ctx, "LH", &err) {
+					count++
+				}
+
+				if err != nil {
+					errs <- err
+					return
+				} else if count != 20 {
+					errs <- fmt.Errorf("expected 20 flight numbers, got %d", count)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range 10 {
+		source.publish(fmt.Sprintf("v%d", i+1), true)
+		reloaded, err := r.Reload(ctx, source)
+		require.NoError(t, err)
+		require.True(t, reloaded)
+	}
+
+	close(stop)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, "v10", airlineName(t, r))
+}
@@ -70,7 +70,13 @@ func NewFlightRepo(db flightRepoDatabase) *FlightRepo {
 	return &fr
 }
 
-func (fr *FlightRepo) Flights(ctx context.Context, start, end xtime.LocalDate) (map[xtime.LocalDate][]Flight, error) {
+// Flights returns all flights departing (UTC) between start and end (inclusive).
+// If asOf is set, flights are answered from the history as it was at that version instead of the latest state.
+func (fr *FlightRepo) Flights(ctx context.Context, start, end xtime.LocalDate, asOf *time.Time) (map[xtime.LocalDate][]Flight, error) {
+	if asOf != nil {
+		return fr.flightsAsOfInternal(ctx, start, end, *asOf)
+	}
+
 	var mtx sync.Mutex
 	result := make(map[xtime.LocalDate][]Flight)
 
@@ -480,60 +486,171 @@ ON (
 	return result, rows.Err()
 }
 
-func (fr *FlightRepo) FlightSchedules(ctx context.Context, fn FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (FlightSchedules, error) {
+// RelatedFlightNumbersMany resolves RelatedFlightNumbers for all given flight numbers with a single query
+func (fr *FlightRepo) RelatedFlightNumbersMany(ctx context.Context, fns []FlightNumber, version time.Time) (map[FlightNumber]common.Set[FlightNumber], error) {
 	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	airlines := make(common.Set[string])
+	numbers := make(common.Set[int])
+	numbersMod10 := make(common.Set[int])
+	for _, fn := range fns {
+		airlines.Add(fn.AirlineIataCode)
+		for _, number := range []int{fn.Number - 1, fn.Number, fn.Number + 1} {
+			numbers.Add(number)
+			numbersMod10.Add(((number % 10) + 10) % 10)
+		}
+	}
+
+	versionCondition := BaseCondition{
+		Filter: "fvh.created_at <= CAST(? AS TIMESTAMPTZ)",
+		Params: []any{version.Format(time.RFC3339)},
+	}
+
+	selfFilter, params := AndCondition{flightNumbersCondition("fvh.", fns, true), versionCondition}.Condition()
+	relatedFilter, relatedParams := AndCondition{
+		NewInCondition("fvh.airline_iata_code", maps.Keys(airlines)),
+		NewInCondition("fvh.number_mod_10", maps.Keys(numbersMod10)),
+		NewInCondition("fvh.number", maps.Keys(numbers)),
+		versionCondition,
+	}.Condition()
+
+	params = append(params, relatedParams...)
+	rows, err := conn.QueryContext(
+		ctx,
+		strings.NewReplacer(":selfFilter", selfFilter, ":relatedFilter", relatedFilter).Replace(`
+WITH self_routes AS (
+	SELECT DISTINCT fvh.airline_iata_code, fvh.number, fvh.suffix, fvh.departure_airport_iata_code, fv.arrival_airport_iata_code
+	FROM flight_variant_history fvh
+	INNER JOIN flight_variants fv
+	ON fvh.flight_variant_id = fv.id
+	WHERE :selfFilter
+), related_routes AS (
+	SELECT DISTINCT fvh.airline_iata_code, fvh.number, fvh.suffix, fvh.departure_airport_iata_code, fv.arrival_airport_iata_code
+	FROM flight_variant_history fvh
+	INNER JOIN flight_variants fv
+	ON fvh.flight_variant_id = fv.id
+	WHERE :relatedFilter
+)
+SELECT DISTINCT self.airline_iata_code, self.number, self.suffix, rel.airline_iata_code, rel.number, rel.suffix
+FROM self_routes self
+INNER JOIN related_routes rel
+ON rel.airline_iata_code = self.airline_iata_code
+AND (
+	( rel.number = self.number AND rel.suffix != self.suffix )
+	OR rel.number = self.number + 1
+	OR rel.number = self.number - 1
+)
+AND (
+	( rel.departure_airport_iata_code = self.departure_airport_iata_code AND rel.arrival_airport_iata_code = self.arrival_airport_iata_code )
+	OR
+	( rel.departure_airport_iata_code = self.arrival_airport_iata_code AND rel.arrival_airport_iata_code = self.departure_airport_iata_code )
+)
+`),
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[FlightNumber]common.Set[FlightNumber], len(fns))
+	for _, fn := range fns {
+		result[fn] = make(common.Set[FlightNumber])
+	}
+
+	for rows.Next() {
+		var fn, relFn FlightNumber
+		if err = rows.Scan(&fn.AirlineIataCode, &fn.Number, &fn.Suffix, &relFn.AirlineIataCode, &relFn.Number, &relFn.Suffix); err != nil {
+			return nil, err
+		}
+
+		if related, ok := result[fn]; ok {
+			related.Add(relFn)
+		}
+	}
+
+	return result, rows.Err()
+}
+
+func (fr *FlightRepo) FlightSchedules(ctx context.Context, fn FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (FlightSchedules, error) {
+	result, err := fr.FlightSchedulesMany(ctx, []FlightNumber{fn}, version, departureDateRangeLocal)
 	if err != nil {
 		return FlightSchedules{}, err
 	}
+
+	items := result.Schedules[fn]
+	if items == nil {
+		items = make([]FlightScheduleItem, 0)
+	}
+
+	return FlightSchedules{
+		Items:    items,
+		Variants: result.Variants,
+	}, nil
+}
+
+func (fr *FlightRepo) FlightSchedulesMany(ctx context.Context, fns []FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (FlightSchedulesMany, error) {
+	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return FlightSchedulesMany{}, err
+	}
 	defer conn.Close()
 
-	items := make([]FlightScheduleItem, 0)
+	result := make(map[FlightNumber][]FlightScheduleItem)
 	variantIds := make(common.Set[uuid.UUID])
 	err = func() error {
+		filter := AndCondition{
+			flightNumbersCondition("fvh.", fns, true),
+			BaseCondition{
+				Filter: "fvh.created_at <= CAST(? AS TIMESTAMPTZ)",
+				Params: []any{version.Format(time.RFC3339)},
+			},
+		}
+
+		if departureDateRangeLocal != nil {
+			filter = append(
+				filter,
+				BaseCondition{
+					Filter: "fvh.departure_date_local >= CAST(? AS DATE)",
+					Params: []any{departureDateRangeLocal[0].String()},
+				},
+				BaseCondition{
+					Filter: "fvh.departure_date_local < CAST(? AS DATE)",
+					Params: []any{departureDateRangeLocal[1].String()},
+				},
+			)
+		}
+
+		filterStr, params := filter.Condition()
 		query := `
 WITH filtered_flight_variant_history AS (
     SELECT
+        fvh.airline_iata_code,
+        fvh.number,
+        fvh.suffix,
         fvh.departure_date_local,
 		fvh.departure_airport_iata_code,
 		fvh.flight_variant_id,
 		fvh.created_at
     FROM flight_variant_history fvh
-	WHERE fvh.airline_iata_code = ?
-	AND fvh.number_mod_10 = (? % 10)
-	AND fvh.number = ?
-	AND fvh.suffix = ?
-	AND fvh.created_at <= CAST(? AS TIMESTAMPTZ)
-`
-		params := []any{
-			fn.AirlineIataCode,
-			fn.Number,
-			fn.Number,
-			fn.Suffix,
-			version.Format(time.RFC3339),
-		}
-
-		if departureDateRangeLocal != nil {
-			query += `
-	AND fvh.departure_date_local >= CAST(? AS DATE)
-	AND fvh.departure_date_local < CAST(? AS DATE)
-`
-			params = append(
-				params,
-				departureDateRangeLocal[0].String(),
-				departureDateRangeLocal[1].String(),
-			)
-		}
-
-		query += `), ranked_flight_variant_history AS (
+	WHERE :filter
+), ranked_flight_variant_history AS (
 	SELECT
 		*,
 		DENSE_RANK() OVER (
-			PARTITION BY departure_date_local, departure_airport_iata_code
+			PARTITION BY airline_iata_code, number, suffix, departure_date_local, departure_airport_iata_code
 			ORDER BY created_at DESC
 		) AS history_rank
 	FROM filtered_flight_variant_history
 )
 SELECT
+    airline_iata_code,
+    number,
+    suffix,
     departure_date_local,
     departure_airport_iata_code,
     FIRST(flight_variant_id ORDER BY created_at DESC),
@@ -541,19 +658,23 @@ SELECT
     FIRST(created_at ORDER BY created_at DESC),
     COUNT(DISTINCT created_at)
 FROM ranked_flight_variant_history
-GROUP BY departure_date_local, departure_airport_iata_code
-ORDER BY departure_date_local ASC
+GROUP BY airline_iata_code, number, suffix, departure_date_local, departure_airport_iata_code
+ORDER BY airline_iata_code ASC, number ASC, suffix ASC, departure_date_local ASC
 `
 
-		rows, err := conn.QueryContext(ctx, query, params...)
+		rows, err := conn.QueryContext(ctx, strings.Replace(query, ":filter", filterStr, 1), params...)
 		if err != nil {
 			return err
 		}
 		defer rows.Close()
 
 		for rows.Next() {
+			var fn FlightNumber
 			var fsi FlightScheduleItem
 			err = rows.Scan(
+				&fn.AirlineIataCode,
+				&fn.Number,
+				&fn.Suffix,
 				&fsi.DepartureDateLocal,
 				&fsi.DepartureAirportIataCode,
 				&fsi.FlightVariantId,
@@ -565,7 +686,7 @@ ORDER BY departure_date_local ASC
 				return err
 			}
 
-			items = append(items, fsi)
+			result[fn] = append(result[fn], fsi)
 
 			if fsi.FlightVariantId.Valid {
 				variantIds.Add(fsi.FlightVariantId.V)
@@ -578,17 +699,17 @@ ORDER BY departure_date_local ASC
 		return rows.Err()
 	}()
 	if err != nil {
-		return FlightSchedules{}, err
+		return FlightSchedulesMany{}, err
 	}
 
 	variants, err := fr.flightVariants(ctx, conn, variantIds)
 	if err != nil {
-		return FlightSchedules{}, err
+		return FlightSchedulesMany{}, err
 	}
 
-	return FlightSchedules{
-		Items:    items,
-		Variants: variants,
+	return FlightSchedulesMany{
+		Schedules: result,
+		Variants:  variants,
 	}, nil
 }
 
@@ -688,6 +809,312 @@ func (fr *FlightRepo) UpdatesReport(ctx context.Context, fn FlightNumber, versio
 	)
 }
 
+func (fr *FlightRepo) UpdatesReportMany(ctx context.Context, fns []FlightNumber, version time.Time) (map[FlightNumber][]UpdateReportItem, error) {
+	result := make(map[FlightNumber][]UpdateReportItem)
+	return result, fr.updatesReport(
+		ctx,
+		[]SelectExpression{
+			LiteralValueExpression("airline_iata_code"),
+			LiteralValueExpression("number"),
+			LiteralValueExpression("suffix"),
+			LiteralValueExpression("created_at"),
+			AggregationValueExpression{
+				Function: "SUM",
+				Expr:     LiteralValueExpression("added"),
+			},
+			AggregationValueExpression{
+				Function: "SUM",
+				Expr:     LiteralValueExpression("updated"),
+			},
+			AggregationValueExpression{
+				Function: "SUM",
+				Expr:     LiteralValueExpression("removed"),
+			},
+		},
+		AndCondition{
+			flightNumbersCondition("", fns, false),
+			BaseCondition{
+				Filter: "created_at <= CAST(? AS TIMESTAMPTZ)",
+				Params: []any{version.Format(time.RFC3339)},
+			},
+		},
+		[]ValueExpression{
+			LiteralValueExpression("airline_iata_code"),
+			LiteralValueExpression("number"),
+			LiteralValueExpression("suffix"),
+			LiteralValueExpression("created_at"),
+		},
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				var fn FlightNumber
+				var ri UpdateReportItem
+				if err := rows.Scan(&fn.AirlineIataCode, &fn.Number, &fn.Suffix, &ri.Version, &ri.Added, &ri.Updated, &ri.Removed); err != nil {
+					return err
+				}
+
+				result[fn] = append(result[fn], ri)
+			}
+
+			return nil
+		},
+	)
+}
+
+// UpdatesDiff returns up to limit flight instances whose flight variant differs between the versions since and version.
+// An invalid variant id on either side means the flight did not exist or was cancelled at that version.
+// The filter may reference the flight instance as d and the flight variants of both versions as from_fv and to_fv.
+func (fr *FlightRepo) UpdatesDiff(ctx context.Context, since, version time.Time, filter Condition, limit int) (FlightScheduleDiff, error) {
+	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return FlightScheduleDiff{}, err
+	}
+	defer conn.Close()
+
+	items := make([]FlightScheduleDiffItem, 0)
+	variantIds := make(common.Set[uuid.UUID])
+	truncated := false
+	err = func() error {
+		var filterStr string
+		var filterParams []any
+
+		if filter == nil {
+			filterStr = `TRUE`
+		} else {
+			filterStr, filterParams = filter.Condition()
+		}
+
+		sinceFilter, sinceParams := historyAsOfCondition("", since).Condition()
+		versionFilter, versionParams := historyAsOfCondition("", version).Condition()
+
+		// all entries which were active at any time between both versions
+		params := []any{version.Format(time.RFC3339), since.Format(time.RFC3339)}
+		params = append(params, sinceParams...)
+		params = append(params, versionParams...)
+		params = append(params, since.Format(time.RFC3339), version.Format(time.RFC3339))
+		params = append(params, filterParams...)
+		params = append(params, limit+1)
+
+		query := `
+WITH filtered_flight_variant_history AS (
+    SELECT
+        fvh.airline_iata_code,
+        fvh.number,
+        fvh.suffix,
+        fvh.departure_airport_iata_code,
+        fvh.departure_date_local,
+        fvh.flight_variant_id,
+        fvh.created_at,
+        fvh.replaced_at
+    FROM flight_variant_history fvh
+    WHERE fvh.created_at <= CAST(? AS TIMESTAMPTZ)
+    AND ( fvh.replaced_at IS NULL OR fvh.replaced_at > CAST(? AS TIMESTAMPTZ) )
+), diff AS (
+    SELECT
+        airline_iata_code,
+        number,
+        suffix,
+        departure_airport_iata_code,
+        departure_date_local,
+        FIRST(flight_variant_id) FILTER (WHERE :sinceFilter) AS from_flight_variant_id,
+        FIRST(flight_variant_id) FILTER (WHERE :versionFilter) AS to_flight_variant_id
+    FROM filtered_flight_variant_history
+    GROUP BY airline_iata_code, number, suffix, departure_airport_iata_code, departure_date_local
+    HAVING BOOL_OR(created_at > CAST(? AS TIMESTAMPTZ) AND created_at <= CAST(? AS TIMESTAMPTZ))
+)
+SELECT
+    d.airline_iata_code,
+    d.number,
+    d.suffix,
+    d.departure_airport_iata_code,
+    d.departure_date_local,
+    d.from_flight_variant_id,
+    d.to_flight_variant_id
+FROM diff d
+LEFT JOIN flight_variants from_fv
+ON d.from_flight_variant_id = from_fv.id
+LEFT JOIN flight_variants to_fv
+ON d.to_flight_variant_id = to_fv.id
+-- changed and reverted between both versions
+WHERE d.from_flight_variant_id IS DISTINCT FROM d.to_flight_variant_id
+AND ( :filter )
+ORDER BY d.airline_iata_code ASC, d.number ASC, d.suffix ASC, d.departure_date_local ASC, d.departure_airport_iata_code ASC
+LIMIT ?
+`
+
+		query = strings.NewReplacer(":filter", filterStr, ":sinceFilter", sinceFilter, ":versionFilter", versionFilter).Replace(query)
+		rows, err := conn.QueryContext(ctx, query, params...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item FlightScheduleDiffItem
+			err = rows.Scan(
+				&item.AirlineIataCode,
+				&item.Number,
+				&item.Suffix,
+				&item.DepartureAirportIataCode,
+				&item.DepartureDateLocal,
+				&item.FromFlightVariantId,
+				&item.ToFlightVariantId,
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(items) >= limit {
+				truncated = true
+				break
+			}
+
+			items = append(items, item)
+
+			if item.FromFlightVariantId.Valid {
+				variantIds.Add(item.FromFlightVariantId.V)
+			}
+			if item.ToFlightVariantId.Valid {
+				variantIds.Add(item.ToFlightVariantId.V)
+			}
+		}
+
+		return rows.Err()
+	}()
+	if err != nil {
+		return FlightScheduleDiff{}, err
+	}
+
+	variants, err := fr.flightVariants(ctx, conn, variantIds)
+	if err != nil {
+		return FlightScheduleDiff{}, err
+	}
+
+	return FlightScheduleDiff{
+		Items:     items,
+		Variants:  variants,
+		Truncated: truncated,
+	}, nil
+}
+
+// EquipmentChanges returns the changes of aircraft, aircraft owner or configuration of operating flight instances introduced by versions
+// created at or after since. The filter may reference the flight instance as d and the flight variants before and after the change as from_fv and to_fv.
+func (fr *FlightRepo) EquipmentChanges(ctx context.Context, since time.Time, filter Condition) (EquipmentChanges, error) {
+	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return EquipmentChanges{}, err
+	}
+	defer conn.Close()
+
+	items := make([]EquipmentChange, 0)
+	variantIds := make(common.Set[uuid.UUID])
+	err = func() error {
+		var filterStr string
+		var filterParams []any
+
+		if filter == nil {
+			filterStr = `TRUE`
+		} else {
+			filterStr, filterParams = filter.Condition()
+		}
+
+		params := []any{since.Format(time.RFC3339), since.Format(time.RFC3339)}
+		params = append(params, filterParams...)
+
+		query := `
+WITH history AS (
+    SELECT
+        fvh.airline_iata_code,
+        fvh.number,
+        fvh.suffix,
+        fvh.departure_airport_iata_code,
+        fvh.departure_date_local,
+        fvh.created_at,
+        fvh.flight_variant_id,
+        LAG(fvh.flight_variant_id) OVER w AS previous_flight_variant_id,
+        LAG(fvh.replaced_at) OVER w AS previous_replaced_at
+    FROM flight_variant_history fvh
+    -- the entries replaced by versions since, and the entries replacing them
+    WHERE fvh.replaced_at IS NULL OR fvh.replaced_at >= CAST(? AS TIMESTAMPTZ)
+    WINDOW w AS (
+        PARTITION BY fvh.airline_iata_code, fvh.number, fvh.suffix, fvh.departure_airport_iata_code, fvh.departure_date_local
+        ORDER BY fvh.created_at ASC
+    )
+)
+SELECT
+    d.airline_iata_code,
+    d.number,
+    d.suffix,
+    d.departure_airport_iata_code,
+    d.departure_date_local,
+    d.created_at,
+    d.previous_flight_variant_id,
+    d.flight_variant_id
+FROM history d
+INNER JOIN flight_variants from_fv
+ON d.previous_flight_variant_id = from_fv.id
+INNER JOIN flight_variants to_fv
+ON d.flight_variant_id = to_fv.id
+-- the previous entry was replaced by this one, not removed before
+WHERE d.created_at >= CAST(? AS TIMESTAMPTZ)
+AND d.previous_replaced_at = d.created_at
+-- codeshares would repeat the change of their operating flight
+AND d.airline_iata_code = to_fv.operating_airline_iata_code
+AND d.number = to_fv.operating_number
+AND d.suffix = to_fv.operating_suffix
+AND (
+    from_fv.aircraft_owner IS DISTINCT FROM to_fv.aircraft_owner
+    OR from_fv.aircraft_iata_code IS DISTINCT FROM to_fv.aircraft_iata_code
+    OR from_fv.aircraft_configuration_version IS DISTINCT FROM to_fv.aircraft_configuration_version
+)
+AND ( :filter )
+ORDER BY d.created_at DESC, d.airline_iata_code ASC, d.number ASC, d.suffix ASC, d.departure_date_local ASC, d.departure_airport_iata_code ASC
+`
+
+		query = strings.Replace(query, ":filter", filterStr, 1)
+		rows, err := conn.QueryContext(ctx, query, params...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item EquipmentChange
+			err = rows.Scan(
+				&item.AirlineIataCode,
+				&item.Number,
+				&item.Suffix,
+				&item.DepartureAirportIataCode,
+				&item.DepartureDateLocal,
+				&item.Version,
+				&item.FromFlightVariantId,
+				&item.ToFlightVariantId,
+			)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, item)
+			variantIds.Add(item.FromFlightVariantId)
+			variantIds.Add(item.ToFlightVariantId)
+		}
+
+		return rows.Err()
+	}()
+	if err != nil {
+		return EquipmentChanges{}, err
+	}
+
+	variants, err := fr.flightVariants(ctx, conn, variantIds)
+	if err != nil {
+		return EquipmentChanges{}, err
+	}
+
+	return EquipmentChanges{
+		Items:    items,
+		Variants: variants,
+	}, nil
+}
+
 func (fr *FlightRepo) updatesReport(ctx context.Context, selectFields []SelectExpression, filter Condition, groupBy []ValueExpression, scanner func(rows *sql.Rows) error) error {
 	if len(selectFields) < 1 {
 		return errors.New("at least one select field required")
@@ -746,8 +1173,12 @@ FROM updates_report
 	return rows.Err()
 }
 
-func (fr *FlightRepo) FlightSchedulesLatestRaw(ctx context.Context, filter Condition) (FlightSchedulesMany, error) {
+func (fr *FlightRepo) FlightSchedulesLatestRaw(ctx context.Context, filter Condition, asOf *time.Time) (FlightSchedulesMany, error) {
 	var combinedFilter Condition = NewIsNullCondition("fvh.replaced_at")
+	if asOf != nil {
+		combinedFilter = historyAsOfCondition("fvh.", *asOf)
+	}
+
 	if filter != nil {
 		combinedFilter = AndCondition{combinedFilter, filter}
 	}
@@ -854,36 +1285,65 @@ ORDER BY
 }
 
 func (fr *FlightRepo) FlightScheduleVersions(ctx context.Context, fn FlightNumber, departureAirportIataCode string, departureDate xtime.LocalDate) (FlightScheduleVersions, error) {
-	conn, err := fr.db.Conn(ctx)
+	key := FlightInstanceKey{
+		FlightNumber:             fn,
+		DepartureAirportIataCode: departureAirportIataCode,
+		DepartureDateLocal:       departureDate,
+	}
+
+	result, err := fr.FlightScheduleVersionsMany(ctx, []FlightInstanceKey{key})
 	if err != nil {
 		return FlightScheduleVersions{}, err
 	}
+
+	versions := result.Versions[key]
+	if versions == nil {
+		versions = make([]FlightScheduleVersion, 0)
+	}
+
+	return FlightScheduleVersions{
+		Versions: versions,
+		Variants: result.Variants,
+	}, nil
+}
+
+func (fr *FlightRepo) FlightScheduleVersionsMany(ctx context.Context, keys []FlightInstanceKey) (FlightScheduleVersionsMany, error) {
+	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return FlightScheduleVersionsMany{}, err
+	}
 	defer conn.Close()
 
-	versions := make([]FlightScheduleVersion, 0)
+	versions := make(map[FlightInstanceKey][]FlightScheduleVersion)
 	variantsIds := make(common.Set[uuid.UUID])
 	err = func() error {
+		fns := make([]FlightNumber, 0, len(keys))
+		keyConditions := make(OrCondition, 0, len(keys))
+		for _, key := range keys {
+			fns = append(fns, key.FlightNumber)
+			keyConditions = append(keyConditions, BaseCondition{
+				Filter: "airline_iata_code = ? AND number = ? AND suffix = ? AND departure_airport_iata_code = ? AND departure_date_local = CAST(? AS DATE)",
+				Params: []any{key.AirlineIataCode, key.Number, key.Suffix, key.DepartureAirportIataCode, key.DepartureDateLocal.String()},
+			})
+		}
+
+		filter, params := AndCondition{flightNumbersCondition("", fns, true), keyConditions}.Condition()
 		rows, err := conn.QueryContext(
 			ctx,
-			`
+			strings.Replace(`
 SELECT
+    airline_iata_code,
+    number,
+    suffix,
+    departure_airport_iata_code,
+    departure_date_local,
     created_at,
     flight_variant_id
 FROM flight_variant_history
-WHERE airline_iata_code = ?
-AND number_mod_10 = (? % 10)
-AND number = ?
-AND suffix = ?
-AND departure_airport_iata_code = ?
-AND departure_date_local = ?
+WHERE :filter
 ORDER BY created_at ASC
-`,
-			fn.AirlineIataCode,
-			fn.Number,
-			fn.Number,
-			fn.Suffix,
-			departureAirportIataCode,
-			departureDate.String(),
+`, ":filter", filter, 1),
+			params...,
 		)
 		if err != nil {
 			return err
@@ -891,12 +1351,22 @@ ORDER BY created_at ASC
 		defer rows.Close()
 
 		for rows.Next() {
+			var key FlightInstanceKey
 			var version FlightScheduleVersion
-			if err = rows.Scan(&version.Version, &version.FlightVariantId); err != nil {
+			err = rows.Scan(
+				&key.AirlineIataCode,
+				&key.Number,
+				&key.Suffix,
+				&key.DepartureAirportIataCode,
+				&key.DepartureDateLocal,
+				&version.Version,
+				&version.FlightVariantId,
+			)
+			if err != nil {
 				return err
 			}
 
-			versions = append(versions, version)
+			versions[key] = append(versions[key], version)
 
 			if version.FlightVariantId.Valid {
 				variantsIds.Add(version.FlightVariantId.V)
@@ -906,15 +1376,15 @@ ORDER BY created_at ASC
 		return rows.Err()
 	}()
 	if err != nil {
-		return FlightScheduleVersions{}, err
+		return FlightScheduleVersionsMany{}, err
 	}
 
 	variants, err := fr.flightVariants(ctx, conn, variantsIds)
 	if err != nil {
-		return FlightScheduleVersions{}, err
+		return FlightScheduleVersionsMany{}, err
 	}
 
-	return FlightScheduleVersions{
+	return FlightScheduleVersionsMany{
 		Versions: versions,
 		Variants: variants,
 	}, nil
@@ -1049,13 +1519,126 @@ AND day_utc = ?
 	}
 	defer rows.Close()
 
+	return scanFlights(rows)
+}
+
+func (fr *FlightRepo) flightsAsOfInternal(ctx context.Context, start, end xtime.LocalDate, asOf time.Time) (map[xtime.LocalDate][]Flight, error) {
+	conn, err := fr.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter, params := AndCondition{
+		historyAsOfCondition("fvh.", asOf),
+		BaseCondition{
+			Filter: "fvh.flight_variant_id IS NOT NULL",
+		},
+		// the local departure date is at most one day off the UTC departure date
+		BaseCondition{
+			Filter: "fvh.departure_date_local >= CAST(? AS DATE)",
+			Params: []any{(start - 1).String()},
+		},
+		BaseCondition{
+			Filter: "fvh.departure_date_local <= CAST(? AS DATE)",
+			Params: []any{(end + 1).String()},
+		},
+	}.Condition()
+
+	params = append(params, start.String(), (end + 1).String())
+	rows, err := conn.QueryContext(
+		ctx,
+		strings.Replace(
+			`
+SELECT
+    airline_iata_code,
+    number,
+    suffix,
+    departure_timestamp_utc,
+    departure_utc_offset_seconds,
+    departure_airport_iata_code,
+    duration_seconds,
+    arrival_utc_offset_seconds,
+    arrival_airport_iata_code,
+    service_type,
+    aircraft_owner,
+    aircraft_iata_code,
+    seats_first,
+    seats_business,
+    seats_premium,
+    seats_economy,
+    aircraft_configuration_version,
+    code_shares,
+    data_elements
+FROM (
+	SELECT
+		(fvh.departure_date_local + fv.departure_time_local - TO_SECONDS(fv.departure_utc_offset_seconds)) AS departure_timestamp_utc,
+		fvh.airline_iata_code,
+		fvh.number,
+		fvh.suffix,
+		fvh.departure_airport_iata_code,
+		fv.departure_utc_offset_seconds,
+		fv.duration_seconds,
+		fv.arrival_utc_offset_seconds,
+		fv.arrival_airport_iata_code,
+		fv.service_type,
+		fv.aircraft_owner,
+		fv.aircraft_iata_code,
+		fv.seats_first,
+		fv.seats_business,
+		fv.seats_premium,
+		fv.seats_economy,
+		fv.aircraft_configuration_version,
+		fv.code_shares,
+		fv.data_elements
+	FROM flight_variant_history fvh
+	INNER JOIN flight_variants fv
+	ON fvh.flight_variant_id = fv.id
+	AND fvh.airline_iata_code = fv.operating_airline_iata_code
+	AND fvh.number = fv.operating_number
+	AND fvh.suffix = fv.operating_suffix
+	WHERE :filter
+)
+WHERE departure_timestamp_utc >= CAST(? AS TIMESTAMP)
+AND departure_timestamp_utc < CAST(? AS TIMESTAMP)
+`,
+			":filter",
+			filter,
+			1,
+		),
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flights, err := scanFlights(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[xtime.LocalDate][]Flight)
+	for d := start; d <= end; d++ {
+		result[d] = make([]Flight, 0)
+	}
+
+	for _, f := range flights {
+		d := xtime.NewLocalDate(f.DepartureTime.UTC())
+		result[d] = append(result[d], f)
+	}
+
+	return result, nil
+}
+
+func scanFlights(rows *sql.Rows) ([]Flight, error) {
 	flights := make([]Flight, 0)
 	for rows.Next() {
 		var f Flight
 		var departureUtcOffsetSeconds, arrivalUtcOffsetSeconds, durationSeconds int
 		var codeShares xsql.SQLArray[FlightNumber, *FlightNumber]
 		var dataElements DuckDBMap[xsql.Int64, *xsql.Int64, xsql.String, *xsql.String]
-		err = rows.Scan(
+		err := rows.Scan(
 			&f.AirlineIataCode,
 			&f.Number,
 			&f.Suffix,
@@ -1097,30 +1680,63 @@ AND day_utc = ?
 		flights = append(flights, f)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
-
 	return flights, rows.Err()
 }
 
-func (fr *FlightRepo) Destinations(ctx context.Context, departureAirportIataCode string) ([]string, error) {
+// Destinations returns all airports served by direct flights from the given airport.
+// If asOf is set, the destinations are answered from the history as it was at that version instead of the latest state.
+func (fr *FlightRepo) Destinations(ctx context.Context, departureAirportIataCode string, asOf *time.Time) ([]string, error) {
 	conn, err := fr.db.Conn(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
 
-	rows, err := conn.QueryContext(
-		ctx,
-		`
+	var rows *sql.Rows
+	if asOf == nil {
+		rows, err = conn.QueryContext(
+			ctx,
+			`
 SELECT DISTINCT arrival_airport_iata_code
 FROM connections
 WHERE departure_airport_iata_code = ?
 AND min_flights = 1
 `,
-		departureAirportIataCode,
-	)
+			departureAirportIataCode,
+		)
+	} else {
+		filter, params := AndCondition{
+			historyAsOfCondition("fvh.", *asOf),
+			BaseCondition{
+				Filter: "fvh.departure_airport_iata_code = ?",
+				Params: []any{departureAirportIataCode},
+			},
+			BaseCondition{
+				Filter: "fvh.departure_date_local >= CAST(? AS DATE)",
+				Params: []any{xtime.NewLocalDate(asOf.UTC()).String()},
+			},
+		}.Condition()
+
+		rows, err = conn.QueryContext(
+			ctx,
+			strings.Replace(
+				`
+SELECT DISTINCT fv.arrival_airport_iata_code
+FROM flight_variant_history fvh
+INNER JOIN flight_variants fv
+ON fvh.flight_variant_id = fv.id
+AND fvh.airline_iata_code = fv.operating_airline_iata_code
+AND fvh.number = fv.operating_number
+AND fvh.suffix = fv.operating_suffix
+WHERE :filter
+`,
+				":filter",
+				filter,
+				1,
+			),
+			params...,
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1181,3 +1797,41 @@ LIMIT 1
 
 	return connection, rows.Err()
 }
+
+// historyAsOfCondition matches the history entries which were active at the given version.
+// flight_variant_history is only partitioned by airline and flight number, callers should combine this
+// with a condition on departure_date_local: the history is sorted by it within each partition, which
+// lets duckdb skip the row groups outside the requested range.
+func historyAsOfCondition(prefix string, asOf time.Time) Condition {
+	return BaseCondition{
+		Filter: fmt.Sprintf(
+			"%[1]screated_at <= CAST(? AS TIMESTAMPTZ) AND (%[1]sreplaced_at IS NULL OR %[1]sreplaced_at > CAST(? AS TIMESTAMPTZ))",
+			prefix,
+		),
+		Params: []any{asOf.Format(time.RFC3339), asOf.Format(time.RFC3339)},
+	}
+}
+
+// flightNumbersCondition matches any of the given flight numbers. The redundant IN conditions allow
+// duckdb to prune hive partitions which it does not do for the OR condition alone.
+func flightNumbersCondition(prefix string, fns []FlightNumber, numberMod10 bool) Condition {
+	airlines := make(common.Set[string])
+	numbersMod10 := make(common.Set[int])
+	fnConditions := make(OrCondition, 0, len(fns))
+
+	for _, fn := range fns {
+		airlines.Add(fn.AirlineIataCode)
+		numbersMod10.Add(fn.Number % 10)
+		fnConditions = append(fnConditions, BaseCondition{
+			Filter: fmt.Sprintf("%[1]sairline_iata_code = ? AND %[1]snumber = ? AND %[1]ssuffix = ?", prefix),
+			Params: []any{fn.AirlineIataCode, fn.Number, fn.Suffix},
+		})
+	}
+
+	cond := AndCondition{NewInCondition(prefix+"airline_iata_code", maps.Keys(airlines))}
+	if numberMod10 {
+		cond = append(cond, NewInCondition(prefix+"number_mod_10", maps.Keys(numbersMod10)))
+	}
+
+	return append(cond, fnConditions)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -17,9 +18,12 @@ import (
 	"github.com/explore-flights/monorepo/go/api/business/seatmap"
 	"github.com/explore-flights/monorepo/go/api/config"
 	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/api/pb"
 	"github.com/explore-flights/monorepo/go/api/web"
 	lwamw "github.com/its-felix/aws-lwa-go-middleware"
 	"github.com/labstack/echo/v4"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -45,16 +49,25 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	defer database.Close()
 
 	version, err := config.Config.Version()
 	if err != nil {
 		panic(err)
 	}
 
-	fr := db.NewFlightRepo(database)
-	connSearch := connections.NewSearch(fr)
-	sshHandler := web.NewScheduleSearchHandler(fr, schedulesearch.NewSearch(fr))
+	fr := db.NewReloadableFlightRepo(version, database)
+	defer fr.Close()
+
+	if interval := config.Config.DatasetReloadInterval(); interval > 0 {
+		source, err := config.Config.DatasetSource(ctx)
+		if err != nil {
+			panic(err)
+		}
+
+		go fr.Watch(ctx, source, interval)
+	}
+
+	logger := log.New(os.Stderr, "", log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)
 
 	e := echo.New()
 	defer e.Close()
@@ -63,99 +76,58 @@ func main() {
 			lwamw.WithMaskError(),
 			lwamw.WithRemoveHeaders(),
 		),
-		web.ErrorLogAndMaskMiddleware(log.New(os.Stderr, "", log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)),
+		web.ErrorLogAndMaskMiddleware(logger),
 		web.RecoverMiddleware(),
-		web.VersionHeaderMiddleware(version),
+		web.VersionHeaderMiddleware(fr.Version),
 		web.NoCacheOnErrorMiddleware(),
 		// authHandler.Middleware,
 	)
 
-	{
-		group := e.Group("/api")
-
-		connWebHandler := web.NewConnectionsHandler(fr, connSearch)
-		group.POST("/connections/json", connWebHandler.ConnectionsJSON)
-		group.GET("/connections/json/:payload", connWebHandler.ConnectionsJSON)
-		group.POST("/connections/png", connWebHandler.ConnectionsPNG)
-		group.GET("/connections/png/:payload/c.png", connWebHandler.ConnectionsPNG)
-		group.POST("/connections/share", connWebHandler.ConnectionsShareCreate)
-		group.GET("/connections/share/:payload", connWebHandler.ConnectionsShareHTML)
-
-		searchHandler := web.NewSearchHandler(fr)
-		group.GET("/search", searchHandler.Search)
-
-		group.GET("/schedule/search", sshHandler.Query)
-
-		gameHandler := web.NewGameHandler(fr)
-		group.GET("/game/connection", gameHandler.ConnectionGame)
-
-		notificationHandler := web.NewNotificationHandler(version)
-		group.GET("/notifications", notificationHandler.Notifications)
-	}
-
-	{
-		group := e.Group("/data")
-
-		dh := web.NewDataHandler(fr, seatmap.NewSearch(s3c, bucket, fr, lhc), raw.NewSearch(s3c, bucket))
-		group.GET("/airlines.json", dh.Airlines)
-		group.GET("/airports.json", dh.Airports)
-		group.GET("/aircraft.json", dh.Aircraft)
-		group.GET("/flight/:fn/versions/:departureAirport/:departureDateLocal", dh.FlightScheduleVersions)
-		group.GET("/flight/:fn/versions/:departureAirport/:departureDateLocal/feed.rss", dh.FlightScheduleVersionsRSSFeed)
-		group.GET("/flight/:fn/versions/:departureAirport/:departureDateLocal/feed.atom", dh.FlightScheduleVersionsAtomFeed)
-		group.GET("/flight/:fn/:version/:departureAirport/:departureDateLocal/raw.json", dh.FlightScheduleVersionRaw)
-		group.GET("/flight/:fn/seatmap/:departureAirport/:departureDateLocal", dh.SeatMap)
-		group.GET("/destinations/:departureAirport", dh.Destinations)
-		group.GET("/schedule/allegris/feed.rss", sshHandler.AllegrisRSSFeed)
-		group.GET("/schedule/allegris/feed.atom", sshHandler.AllegrisAtomFeed)
-		group.GET("/schedule/swiss350/feed.rss", sshHandler.SwissA350RSSFeed)
-		group.GET("/schedule/swiss350/feed.atom", sshHandler.SwissA350AtomFeed)
-		group.GET("/updates", dh.GlobalUpdates)
-
-		{
-			group := group.Group("/:year", web.YearMiddleware())
-			group.GET("/flight/:fn", dh.FlightSchedule)
-			group.GET("/flight/:fn/:version", dh.FlightSchedule)
-			group.GET("/schedule/allegris", sshHandler.Allegris)
-			group.GET("/schedule/swiss350", sshHandler.SwissA350)
-			group.GET("/schedule/lh380", sshHandler.LHA380)
-			group.GET("/schedule/lh340", sshHandler.LHA340)
-			group.GET("/schedule/lh747", sshHandler.LH747)
-		}
-
-		// region deprecated feed endpoints
-		group.GET("/:fn/:departureDate/:departureAirport/feed.rss", dh.LegacyFlightScheduleVersionsRSSFeed)
-		group.GET("/:fn/:departureDate/:departureAirport/feed.atom", dh.LegacyFlightScheduleVersionsAtomFeed)
-		// endregion
+	web.RegisterRoutes(e, fr, fr.Version, seatmap.NewSearch(s3c, bucket, fr, lhc), raw.NewSearch(s3c, bucket))
 
-		sitemapHandler := web.NewSitemapHandler(fr)
-		group.GET("/sitemap.xml", sitemapHandler.SitemapIndex)
-		group.GET("/sitemap/:airlineId/sitemap.xml", sitemapHandler.SitemapAirline)
-	}
+	gs := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(web.GrpcErrorLogAndMaskUnaryInterceptor(logger)),
+		grpc.ChainStreamInterceptor(web.GrpcErrorLogAndMaskStreamInterceptor(logger)),
+	)
+	pb.RegisterExploreFlightsServer(gs, web.NewGrpcServer(fr, connections.NewSearch(fr), schedulesearch.NewSearch(fr)))
 
-	if err := run(ctx, e); err != nil {
+	if err := run(ctx, e, gs); err != nil {
 		panic(err)
 	}
 }
 
-func run(ctx context.Context, e *echo.Echo) error {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+func run(ctx context.Context, e *echo.Echo, gs *grpc.Server) error {
+	g, ctx := errgroup.WithContext(ctx)
 
-	go func() {
+	g.Go(func() error {
 		<-ctx.Done()
+		gs.GracefulStop()
+
 		if err := e.Shutdown(context.Background()); err != nil {
 			slog.Error("error shutting down the echo server", slog.String("err", err.Error()))
 		}
-	}()
 
-	if err := e.Start(fmt.Sprintf(":%d", config.Config.EchoPort())); err != nil {
-		if errors.Is(err, http.ErrServerClosed) {
-			return nil
-		}
+		return nil
+	})
+
+	if port := config.Config.GrpcPort(); port > 0 {
+		g.Go(func() error {
+			l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+			if err != nil {
+				return err
+			}
 
-		return err
+			return gs.Serve(l)
+		})
 	}
 
-	return nil
+	g.Go(func() error {
+		if err := e.Start(fmt.Sprintf(":%d", config.Config.EchoPort())); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	})
+
+	return g.Wait()
 }
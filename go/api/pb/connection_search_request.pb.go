@@ -42,6 +42,8 @@ type ConnectionsSearchRequest struct {
 	ExcludeFlightNumber []string               `protobuf:"bytes,12,rep,name=exclude_flight_number,json=excludeFlightNumber,proto3" json:"exclude_flight_number,omitempty"`
 	IncludeAircraft     []string               `protobuf:"bytes,13,rep,name=include_aircraft,json=includeAircraft,proto3" json:"include_aircraft,omitempty"`
 	ExcludeAircraft     []string               `protobuf:"bytes,14,rep,name=exclude_aircraft,json=excludeAircraft,proto3" json:"exclude_aircraft,omitempty"`
+	// search the network as it was at this version instead of the latest one
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 }
 
 func (x *ConnectionsSearchRequest) Reset() {
@@ -181,6 +183,13 @@ func (x *ConnectionsSearchRequest) GetExcludeAircraft() []string {
 	return nil
 }
 
+func (x *ConnectionsSearchRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
 var File_connection_search_request_proto protoreflect.FileDescriptor
 
 var file_connection_search_request_proto_rawDesc = []byte{
@@ -191,7 +200,7 @@ var file_connection_search_request_proto_rawDesc = []byte{
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
 	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x82, 0x06, 0x0a,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x06, 0x0a,
 	0x18, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x53, 0x65, 0x61, 0x72,
 	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x69,
 	0x67, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x69, 0x67,
@@ -238,10 +247,13 @@ var file_connection_search_request_proto_rawDesc = []byte{
 	0x09, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x69, 0x72, 0x63, 0x72, 0x61,
 	0x66, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x61, 0x69,
 	0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78,
-	0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x42, 0x12, 0x0a,
-	0x10, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x6c, 0x65,
-	0x67, 0x42, 0x0b, 0x5a, 0x09, 0x67, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x69, 0x72, 0x63, 0x72, 0x61, 0x66, 0x74, 0x12, 0x2f, 0x0a,
+	0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x6c,
+	0x65, 0x67, 0x42, 0x0b, 0x5a, 0x09, 0x67, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -268,11 +280,12 @@ var file_connection_search_request_proto_depIdxs = []int32{
 	2, // 2: explore_flights.protobuf.ConnectionsSearchRequest.min_layover:type_name -> google.protobuf.Duration
 	2, // 3: explore_flights.protobuf.ConnectionsSearchRequest.max_layover:type_name -> google.protobuf.Duration
 	2, // 4: explore_flights.protobuf.ConnectionsSearchRequest.max_duration:type_name -> google.protobuf.Duration
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	1, // 5: explore_flights.protobuf.ConnectionsSearchRequest.as_of:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_connection_search_request_proto_init() }
@@ -0,0 +1,1105 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.28.3
+// source: explore_flights_service.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FlightNumber struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AirlineId     string                 `protobuf:"bytes,1,opt,name=airline_id,json=airlineId,proto3" json:"airline_id,omitempty"`
+	Number        uint32                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Suffix        string                 `protobuf:"bytes,3,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlightNumber) Reset() {
+	*x = FlightNumber{}
+	mi := &file_explore_flights_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightNumber) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightNumber) ProtoMessage() {}
+
+func (x *FlightNumber) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightNumber.ProtoReflect.Descriptor instead.
+func (*FlightNumber) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FlightNumber) GetAirlineId() string {
+	if x != nil {
+		return x.AirlineId
+	}
+	return ""
+}
+
+func (x *FlightNumber) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *FlightNumber) GetSuffix() string {
+	if x != nil {
+		return x.Suffix
+	}
+	return ""
+}
+
+type ConnectionFlight struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	FlightNumber                 *FlightNumber          `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	DepartureAirportId           string                 `protobuf:"bytes,2,opt,name=departure_airport_id,json=departureAirportId,proto3" json:"departure_airport_id,omitempty"`
+	DepartureTime                *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	ArrivalAirportId             string                 `protobuf:"bytes,4,opt,name=arrival_airport_id,json=arrivalAirportId,proto3" json:"arrival_airport_id,omitempty"`
+	ArrivalTime                  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
+	ServiceType                  string                 `protobuf:"bytes,6,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	AircraftOwner                string                 `protobuf:"bytes,7,opt,name=aircraft_owner,json=aircraftOwner,proto3" json:"aircraft_owner,omitempty"`
+	AircraftId                   string                 `protobuf:"bytes,8,opt,name=aircraft_id,json=aircraftId,proto3" json:"aircraft_id,omitempty"`
+	AircraftConfigurationVersion string                 `protobuf:"bytes,9,opt,name=aircraft_configuration_version,json=aircraftConfigurationVersion,proto3" json:"aircraft_configuration_version,omitempty"`
+	CodeShares                   []*FlightNumber        `protobuf:"bytes,10,rep,name=code_shares,json=codeShares,proto3" json:"code_shares,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *ConnectionFlight) Reset() {
+	*x = ConnectionFlight{}
+	mi := &file_explore_flights_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionFlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionFlight) ProtoMessage() {}
+
+func (x *ConnectionFlight) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionFlight.ProtoReflect.Descriptor instead.
+func (*ConnectionFlight) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConnectionFlight) GetFlightNumber() *FlightNumber {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return nil
+}
+
+func (x *ConnectionFlight) GetDepartureAirportId() string {
+	if x != nil {
+		return x.DepartureAirportId
+	}
+	return ""
+}
+
+func (x *ConnectionFlight) GetDepartureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return nil
+}
+
+func (x *ConnectionFlight) GetArrivalAirportId() string {
+	if x != nil {
+		return x.ArrivalAirportId
+	}
+	return ""
+}
+
+func (x *ConnectionFlight) GetArrivalTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivalTime
+	}
+	return nil
+}
+
+func (x *ConnectionFlight) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *ConnectionFlight) GetAircraftOwner() string {
+	if x != nil {
+		return x.AircraftOwner
+	}
+	return ""
+}
+
+func (x *ConnectionFlight) GetAircraftId() string {
+	if x != nil {
+		return x.AircraftId
+	}
+	return ""
+}
+
+func (x *ConnectionFlight) GetAircraftConfigurationVersion() string {
+	if x != nil {
+		return x.AircraftConfigurationVersion
+	}
+	return ""
+}
+
+func (x *ConnectionFlight) GetCodeShares() []*FlightNumber {
+	if x != nil {
+		return x.CodeShares
+	}
+	return nil
+}
+
+type Connection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flight        *ConnectionFlight      `protobuf:"bytes,1,opt,name=flight,proto3" json:"flight,omitempty"`
+	Outgoing      []*Connection          `protobuf:"bytes,2,rep,name=outgoing,proto3" json:"outgoing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_explore_flights_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Connection) GetFlight() *ConnectionFlight {
+	if x != nil {
+		return x.Flight
+	}
+	return nil
+}
+
+func (x *Connection) GetOutgoing() []*Connection {
+	if x != nil {
+		return x.Outgoing
+	}
+	return nil
+}
+
+// ScheduleSearchRequest matches schedules satisfying any value of every non-empty filter. At least two filters are required.
+type ScheduleSearchRequest struct {
+	state                         protoimpl.MessageState `protogen:"open.v1"`
+	AirlineIds                    []string               `protobuf:"bytes,1,rep,name=airline_ids,json=airlineIds,proto3" json:"airline_ids,omitempty"`
+	AircraftIds                   []string               `protobuf:"bytes,2,rep,name=aircraft_ids,json=aircraftIds,proto3" json:"aircraft_ids,omitempty"`
+	AircraftConfigurationVersions []string               `protobuf:"bytes,3,rep,name=aircraft_configuration_versions,json=aircraftConfigurationVersions,proto3" json:"aircraft_configuration_versions,omitempty"`
+	DepartureAirportIds           []string               `protobuf:"bytes,4,rep,name=departure_airport_ids,json=departureAirportIds,proto3" json:"departure_airport_ids,omitempty"`
+	ArrivalAirportIds             []string               `protobuf:"bytes,5,rep,name=arrival_airport_ids,json=arrivalAirportIds,proto3" json:"arrival_airport_ids,omitempty"`
+	MinDepartureTime              *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=min_departure_time,json=minDepartureTime,proto3" json:"min_departure_time,omitempty"`
+	MaxDepartureTime              *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=max_departure_time,json=maxDepartureTime,proto3" json:"max_departure_time,omitempty"`
+	// defaults to the latest version
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleSearchRequest) Reset() {
+	*x = ScheduleSearchRequest{}
+	mi := &file_explore_flights_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleSearchRequest) ProtoMessage() {}
+
+func (x *ScheduleSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleSearchRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleSearchRequest) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScheduleSearchRequest) GetAirlineIds() []string {
+	if x != nil {
+		return x.AirlineIds
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetAircraftIds() []string {
+	if x != nil {
+		return x.AircraftIds
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetAircraftConfigurationVersions() []string {
+	if x != nil {
+		return x.AircraftConfigurationVersions
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetDepartureAirportIds() []string {
+	if x != nil {
+		return x.DepartureAirportIds
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetArrivalAirportIds() []string {
+	if x != nil {
+		return x.ArrivalAirportIds
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetMinDepartureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MinDepartureTime
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetMaxDepartureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MaxDepartureTime
+	}
+	return nil
+}
+
+func (x *ScheduleSearchRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type FlightScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IATA or ICAO flight number, e.g. LH400 or DLH400
+	FlightNumber string `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	Year         int32  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	// defaults to the latest version
+	Version       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlightScheduleRequest) Reset() {
+	*x = FlightScheduleRequest{}
+	mi := &file_explore_flights_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightScheduleRequest) ProtoMessage() {}
+
+func (x *FlightScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightScheduleRequest.ProtoReflect.Descriptor instead.
+func (*FlightScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FlightScheduleRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *FlightScheduleRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *FlightScheduleRequest) GetVersion() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+type FlightScheduleVersionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IATA or ICAO flight number, e.g. LH400 or DLH400
+	FlightNumber string `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	// IATA or ICAO airport code
+	DepartureAirportId string `protobuf:"bytes,2,opt,name=departure_airport_id,json=departureAirportId,proto3" json:"departure_airport_id,omitempty"`
+	// YYYY-MM-DD in airport local time
+	DepartureDateLocal string `protobuf:"bytes,3,opt,name=departure_date_local,json=departureDateLocal,proto3" json:"departure_date_local,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FlightScheduleVersionsRequest) Reset() {
+	*x = FlightScheduleVersionsRequest{}
+	mi := &file_explore_flights_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightScheduleVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightScheduleVersionsRequest) ProtoMessage() {}
+
+func (x *FlightScheduleVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightScheduleVersionsRequest.ProtoReflect.Descriptor instead.
+func (*FlightScheduleVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FlightScheduleVersionsRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *FlightScheduleVersionsRequest) GetDepartureAirportId() string {
+	if x != nil {
+		return x.DepartureAirportId
+	}
+	return ""
+}
+
+func (x *FlightScheduleVersionsRequest) GetDepartureDateLocal() string {
+	if x != nil {
+		return x.DepartureDateLocal
+	}
+	return ""
+}
+
+type FlightScheduleItem struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DepartureDateLocal string                 `protobuf:"bytes,1,opt,name=departure_date_local,json=departureDateLocal,proto3" json:"departure_date_local,omitempty"`
+	DepartureAirportId string                 `protobuf:"bytes,2,opt,name=departure_airport_id,json=departureAirportId,proto3" json:"departure_airport_id,omitempty"`
+	FlightVariantId    string                 `protobuf:"bytes,3,opt,name=flight_variant_id,json=flightVariantId,proto3" json:"flight_variant_id,omitempty"`
+	Version            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	VersionCount       uint32                 `protobuf:"varint,5,opt,name=version_count,json=versionCount,proto3" json:"version_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FlightScheduleItem) Reset() {
+	*x = FlightScheduleItem{}
+	mi := &file_explore_flights_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightScheduleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightScheduleItem) ProtoMessage() {}
+
+func (x *FlightScheduleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightScheduleItem.ProtoReflect.Descriptor instead.
+func (*FlightScheduleItem) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FlightScheduleItem) GetDepartureDateLocal() string {
+	if x != nil {
+		return x.DepartureDateLocal
+	}
+	return ""
+}
+
+func (x *FlightScheduleItem) GetDepartureAirportId() string {
+	if x != nil {
+		return x.DepartureAirportId
+	}
+	return ""
+}
+
+func (x *FlightScheduleItem) GetFlightVariantId() string {
+	if x != nil {
+		return x.FlightVariantId
+	}
+	return ""
+}
+
+func (x *FlightScheduleItem) GetVersion() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *FlightScheduleItem) GetVersionCount() uint32 {
+	if x != nil {
+		return x.VersionCount
+	}
+	return 0
+}
+
+type FlightScheduleVariant struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	Id                           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OperatedAs                   *FlightNumber          `protobuf:"bytes,2,opt,name=operated_as,json=operatedAs,proto3" json:"operated_as,omitempty"`
+	DepartureTimeLocal           string                 `protobuf:"bytes,3,opt,name=departure_time_local,json=departureTimeLocal,proto3" json:"departure_time_local,omitempty"`
+	DepartureUtcOffsetSeconds    int64                  `protobuf:"varint,4,opt,name=departure_utc_offset_seconds,json=departureUtcOffsetSeconds,proto3" json:"departure_utc_offset_seconds,omitempty"`
+	DurationSeconds              int64                  `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	ArrivalAirportId             string                 `protobuf:"bytes,6,opt,name=arrival_airport_id,json=arrivalAirportId,proto3" json:"arrival_airport_id,omitempty"`
+	ArrivalUtcOffsetSeconds      int64                  `protobuf:"varint,7,opt,name=arrival_utc_offset_seconds,json=arrivalUtcOffsetSeconds,proto3" json:"arrival_utc_offset_seconds,omitempty"`
+	ServiceType                  string                 `protobuf:"bytes,8,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	AircraftOwner                string                 `protobuf:"bytes,9,opt,name=aircraft_owner,json=aircraftOwner,proto3" json:"aircraft_owner,omitempty"`
+	AircraftId                   string                 `protobuf:"bytes,10,opt,name=aircraft_id,json=aircraftId,proto3" json:"aircraft_id,omitempty"`
+	AircraftConfigurationVersion string                 `protobuf:"bytes,11,opt,name=aircraft_configuration_version,json=aircraftConfigurationVersion,proto3" json:"aircraft_configuration_version,omitempty"`
+	CodeShares                   []*FlightNumber        `protobuf:"bytes,12,rep,name=code_shares,json=codeShares,proto3" json:"code_shares,omitempty"`
+	DataElements                 map[int64]string       `protobuf:"bytes,13,rep,name=data_elements,json=dataElements,proto3" json:"data_elements,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *FlightScheduleVariant) Reset() {
+	*x = FlightScheduleVariant{}
+	mi := &file_explore_flights_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightScheduleVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightScheduleVariant) ProtoMessage() {}
+
+func (x *FlightScheduleVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightScheduleVariant.ProtoReflect.Descriptor instead.
+func (*FlightScheduleVariant) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FlightScheduleVariant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetOperatedAs() *FlightNumber {
+	if x != nil {
+		return x.OperatedAs
+	}
+	return nil
+}
+
+func (x *FlightScheduleVariant) GetDepartureTimeLocal() string {
+	if x != nil {
+		return x.DepartureTimeLocal
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetDepartureUtcOffsetSeconds() int64 {
+	if x != nil {
+		return x.DepartureUtcOffsetSeconds
+	}
+	return 0
+}
+
+func (x *FlightScheduleVariant) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *FlightScheduleVariant) GetArrivalAirportId() string {
+	if x != nil {
+		return x.ArrivalAirportId
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetArrivalUtcOffsetSeconds() int64 {
+	if x != nil {
+		return x.ArrivalUtcOffsetSeconds
+	}
+	return 0
+}
+
+func (x *FlightScheduleVariant) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetAircraftOwner() string {
+	if x != nil {
+		return x.AircraftOwner
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetAircraftId() string {
+	if x != nil {
+		return x.AircraftId
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetAircraftConfigurationVersion() string {
+	if x != nil {
+		return x.AircraftConfigurationVersion
+	}
+	return ""
+}
+
+func (x *FlightScheduleVariant) GetCodeShares() []*FlightNumber {
+	if x != nil {
+		return x.CodeShares
+	}
+	return nil
+}
+
+func (x *FlightScheduleVariant) GetDataElements() map[int64]string {
+	if x != nil {
+		return x.DataElements
+	}
+	return nil
+}
+
+type FlightSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FlightNumber  *FlightNumber          `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	Items         []*FlightScheduleItem  `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlightSchedule) Reset() {
+	*x = FlightSchedule{}
+	mi := &file_explore_flights_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightSchedule) ProtoMessage() {}
+
+func (x *FlightSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightSchedule.ProtoReflect.Descriptor instead.
+func (*FlightSchedule) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FlightSchedule) GetFlightNumber() *FlightNumber {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return nil
+}
+
+func (x *FlightSchedule) GetItems() []*FlightScheduleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type FlightSchedulesResponse struct {
+	state         protoimpl.MessageState            `protogen:"open.v1"`
+	Schedules     []*FlightSchedule                 `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	Variants      map[string]*FlightScheduleVariant `protobuf:"bytes,2,rep,name=variants,proto3" json:"variants,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlightSchedulesResponse) Reset() {
+	*x = FlightSchedulesResponse{}
+	mi := &file_explore_flights_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightSchedulesResponse) ProtoMessage() {}
+
+func (x *FlightSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*FlightSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FlightSchedulesResponse) GetSchedules() []*FlightSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+func (x *FlightSchedulesResponse) GetVariants() map[string]*FlightScheduleVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type FlightScheduleVersion struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Version         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	FlightVariantId string                 `protobuf:"bytes,2,opt,name=flight_variant_id,json=flightVariantId,proto3" json:"flight_variant_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FlightScheduleVersion) Reset() {
+	*x = FlightScheduleVersion{}
+	mi := &file_explore_flights_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightScheduleVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightScheduleVersion) ProtoMessage() {}
+
+func (x *FlightScheduleVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightScheduleVersion.ProtoReflect.Descriptor instead.
+func (*FlightScheduleVersion) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FlightScheduleVersion) GetVersion() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *FlightScheduleVersion) GetFlightVariantId() string {
+	if x != nil {
+		return x.FlightVariantId
+	}
+	return ""
+}
+
+type FlightScheduleVersionsResponse struct {
+	state         protoimpl.MessageState            `protogen:"open.v1"`
+	FlightNumber  *FlightNumber                     `protobuf:"bytes,1,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	Versions      []*FlightScheduleVersion          `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	Variants      map[string]*FlightScheduleVariant `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlightScheduleVersionsResponse) Reset() {
+	*x = FlightScheduleVersionsResponse{}
+	mi := &file_explore_flights_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightScheduleVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightScheduleVersionsResponse) ProtoMessage() {}
+
+func (x *FlightScheduleVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_flights_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightScheduleVersionsResponse.ProtoReflect.Descriptor instead.
+func (*FlightScheduleVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_flights_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FlightScheduleVersionsResponse) GetFlightNumber() *FlightNumber {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return nil
+}
+
+func (x *FlightScheduleVersionsResponse) GetVersions() []*FlightScheduleVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *FlightScheduleVersionsResponse) GetVariants() map[string]*FlightScheduleVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+var File_explore_flights_service_proto protoreflect.FileDescriptor
+
+const file_explore_flights_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1dexplore_flights_service.proto\x12\x18explore_flights.protobuf\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1fconnection_search_request.proto\"]\n" +
+	"\fFlightNumber\x12\x1d\n" +
+	"\n" +
+	"airline_id\x18\x01 \x01(\tR\tairlineId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\rR\x06number\x12\x16\n" +
+	"\x06suffix\x18\x03 \x01(\tR\x06suffix\"\xbb\x04\n" +
+	"\x10ConnectionFlight\x12K\n" +
+	"\rflight_number\x18\x01 \x01(\v2&.explore_flights.protobuf.FlightNumberR\fflightNumber\x120\n" +
+	"\x14departure_airport_id\x18\x02 \x01(\tR\x12departureAirportId\x12A\n" +
+	"\x0edeparture_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12,\n" +
+	"\x12arrival_airport_id\x18\x04 \x01(\tR\x10arrivalAirportId\x12=\n" +
+	"\farrival_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\varrivalTime\x12!\n" +
+	"\fservice_type\x18\x06 \x01(\tR\vserviceType\x12%\n" +
+	"\x0eaircraft_owner\x18\a \x01(\tR\raircraftOwner\x12\x1f\n" +
+	"\vaircraft_id\x18\b \x01(\tR\n" +
+	"aircraftId\x12D\n" +
+	"\x1eaircraft_configuration_version\x18\t \x01(\tR\x1caircraftConfigurationVersion\x12G\n" +
+	"\vcode_shares\x18\n" +
+	" \x03(\v2&.explore_flights.protobuf.FlightNumberR\n" +
+	"codeShares\"\x92\x01\n" +
+	"\n" +
+	"Connection\x12B\n" +
+	"\x06flight\x18\x01 \x01(\v2*.explore_flights.protobuf.ConnectionFlightR\x06flight\x12@\n" +
+	"\boutgoing\x18\x02 \x03(\v2$.explore_flights.protobuf.ConnectionR\boutgoing\"\xcc\x03\n" +
+	"\x15ScheduleSearchRequest\x12\x1f\n" +
+	"\vairline_ids\x18\x01 \x03(\tR\n" +
+	"airlineIds\x12!\n" +
+	"\faircraft_ids\x18\x02 \x03(\tR\vaircraftIds\x12F\n" +
+	"\x1faircraft_configuration_versions\x18\x03 \x03(\tR\x1daircraftConfigurationVersions\x122\n" +
+	"\x15departure_airport_ids\x18\x04 \x03(\tR\x13departureAirportIds\x12.\n" +
+	"\x13arrival_airport_ids\x18\x05 \x03(\tR\x11arrivalAirportIds\x12H\n" +
+	"\x12min_departure_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10minDepartureTime\x12H\n" +
+	"\x12max_departure_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10maxDepartureTime\x12/\n" +
+	"\x05as_of\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\x86\x01\n" +
+	"\x15FlightScheduleRequest\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\x05R\x04year\x124\n" +
+	"\aversion\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aversion\"\xa8\x01\n" +
+	"\x1dFlightScheduleVersionsRequest\x12#\n" +
+	"\rflight_number\x18\x01 \x01(\tR\fflightNumber\x120\n" +
+	"\x14departure_airport_id\x18\x02 \x01(\tR\x12departureAirportId\x120\n" +
+	"\x14departure_date_local\x18\x03 \x01(\tR\x12departureDateLocal\"\xff\x01\n" +
+	"\x12FlightScheduleItem\x120\n" +
+	"\x14departure_date_local\x18\x01 \x01(\tR\x12departureDateLocal\x120\n" +
+	"\x14departure_airport_id\x18\x02 \x01(\tR\x12departureAirportId\x12*\n" +
+	"\x11flight_variant_id\x18\x03 \x01(\tR\x0fflightVariantId\x124\n" +
+	"\aversion\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aversion\x12#\n" +
+	"\rversion_count\x18\x05 \x01(\rR\fversionCount\"\x9c\x06\n" +
+	"\x15FlightScheduleVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
+	"\voperated_as\x18\x02 \x01(\v2&.explore_flights.protobuf.FlightNumberR\n" +
+	"operatedAs\x120\n" +
+	"\x14departure_time_local\x18\x03 \x01(\tR\x12departureTimeLocal\x12?\n" +
+	"\x1cdeparture_utc_offset_seconds\x18\x04 \x01(\x03R\x19departureUtcOffsetSeconds\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x03R\x0fdurationSeconds\x12,\n" +
+	"\x12arrival_airport_id\x18\x06 \x01(\tR\x10arrivalAirportId\x12;\n" +
+	"\x1aarrival_utc_offset_seconds\x18\a \x01(\x03R\x17arrivalUtcOffsetSeconds\x12!\n" +
+	"\fservice_type\x18\b \x01(\tR\vserviceType\x12%\n" +
+	"\x0eaircraft_owner\x18\t \x01(\tR\raircraftOwner\x12\x1f\n" +
+	"\vaircraft_id\x18\n" +
+	" \x01(\tR\n" +
+	"aircraftId\x12D\n" +
+	"\x1eaircraft_configuration_version\x18\v \x01(\tR\x1caircraftConfigurationVersion\x12G\n" +
+	"\vcode_shares\x18\f \x03(\v2&.explore_flights.protobuf.FlightNumberR\n" +
+	"codeShares\x12f\n" +
+	"\rdata_elements\x18\r \x03(\v2A.explore_flights.protobuf.FlightScheduleVariant.DataElementsEntryR\fdataElements\x1a?\n" +
+	"\x11DataElementsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x0eFlightSchedule\x12K\n" +
+	"\rflight_number\x18\x01 \x01(\v2&.explore_flights.protobuf.FlightNumberR\fflightNumber\x12B\n" +
+	"\x05items\x18\x02 \x03(\v2,.explore_flights.protobuf.FlightScheduleItemR\x05items\"\xac\x02\n" +
+	"\x17FlightSchedulesResponse\x12F\n" +
+	"\tschedules\x18\x01 \x03(\v2(.explore_flights.protobuf.FlightScheduleR\tschedules\x12[\n" +
+	"\bvariants\x18\x02 \x03(\v2?.explore_flights.protobuf.FlightSchedulesResponse.VariantsEntryR\bvariants\x1al\n" +
+	"\rVariantsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12E\n" +
+	"\x05value\x18\x02 \x01(\v2/.explore_flights.protobuf.FlightScheduleVariantR\x05value:\x028\x01\"y\n" +
+	"\x15FlightScheduleVersion\x124\n" +
+	"\aversion\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\aversion\x12*\n" +
+	"\x11flight_variant_id\x18\x02 \x01(\tR\x0fflightVariantId\"\x8c\x03\n" +
+	"\x1eFlightScheduleVersionsResponse\x12K\n" +
+	"\rflight_number\x18\x01 \x01(\v2&.explore_flights.protobuf.FlightNumberR\fflightNumber\x12K\n" +
+	"\bversions\x18\x02 \x03(\v2/.explore_flights.protobuf.FlightScheduleVersionR\bversions\x12b\n" +
+	"\bvariants\x18\x03 \x03(\v2F.explore_flights.protobuf.FlightScheduleVersionsResponse.VariantsEntryR\bvariants\x1al\n" +
+	"\rVariantsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12E\n" +
+	"\x05value\x18\x02 \x01(\v2/.explore_flights.protobuf.FlightScheduleVariantR\x05value:\x028\x012\x82\x04\n" +
+	"\x0eExploreFlights\x12o\n" +
+	"\x11SearchConnections\x122.explore_flights.protobuf.ConnectionsSearchRequest\x1a$.explore_flights.protobuf.Connection0\x01\x12u\n" +
+	"\x0fSearchSchedules\x12/.explore_flights.protobuf.ScheduleSearchRequest\x1a1.explore_flights.protobuf.FlightSchedulesResponse\x12w\n" +
+	"\x11GetFlightSchedule\x12/.explore_flights.protobuf.FlightScheduleRequest\x1a1.explore_flights.protobuf.FlightSchedulesResponse\x12\x8e\x01\n" +
+	"\x19GetFlightScheduleVersions\x127.explore_flights.protobuf.FlightScheduleVersionsRequest\x1a8.explore_flights.protobuf.FlightScheduleVersionsResponseB\vZ\tgo/api/pbb\x06proto3"
+
+var (
+	file_explore_flights_service_proto_rawDescOnce sync.Once
+	file_explore_flights_service_proto_rawDescData []byte
+)
+
+func file_explore_flights_service_proto_rawDescGZIP() []byte {
+	file_explore_flights_service_proto_rawDescOnce.Do(func() {
+		file_explore_flights_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_explore_flights_service_proto_rawDesc), len(file_explore_flights_service_proto_rawDesc)))
+	})
+	return file_explore_flights_service_proto_rawDescData
+}
+
+var file_explore_flights_service_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_explore_flights_service_proto_goTypes = []any{
+	(*FlightNumber)(nil),                   // 0: explore_flights.protobuf.FlightNumber
+	(*ConnectionFlight)(nil),               // 1: explore_flights.protobuf.ConnectionFlight
+	(*Connection)(nil),                     // 2: explore_flights.protobuf.Connection
+	(*ScheduleSearchRequest)(nil),          // 3: explore_flights.protobuf.ScheduleSearchRequest
+	(*FlightScheduleRequest)(nil),          // 4: explore_flights.protobuf.FlightScheduleRequest
+	(*FlightScheduleVersionsRequest)(nil),  // 5: explore_flights.protobuf.FlightScheduleVersionsRequest
+	(*FlightScheduleItem)(nil),             // 6: explore_flights.protobuf.FlightScheduleItem
+	(*FlightScheduleVariant)(nil),          // 7: explore_flights.protobuf.FlightScheduleVariant
+	(*FlightSchedule)(nil),                 // 8: explore_flights.protobuf.FlightSchedule
+	(*FlightSchedulesResponse)(nil),        // 9: explore_flights.protobuf.FlightSchedulesResponse
+	(*FlightScheduleVersion)(nil),          // 10: explore_flights.protobuf.FlightScheduleVersion
+	(*FlightScheduleVersionsResponse)(nil), // 11: explore_flights.protobuf.FlightScheduleVersionsResponse
+	nil,                                    // 12: explore_flights.protobuf.FlightScheduleVariant.DataElementsEntry
+	nil,                                    // 13: explore_flights.protobuf.FlightSchedulesResponse.VariantsEntry
+	nil,                                    // 14: explore_flights.protobuf.FlightScheduleVersionsResponse.VariantsEntry
+	(*timestamppb.Timestamp)(nil),          // 15: google.protobuf.Timestamp
+	(*ConnectionsSearchRequest)(nil),       // 16: explore_flights.protobuf.ConnectionsSearchRequest
+}
+var file_explore_flights_service_proto_depIdxs = []int32{
+	0,  // 0: explore_flights.protobuf.ConnectionFlight.flight_number:type_name -> explore_flights.protobuf.FlightNumber
+	15, // 1: explore_flights.protobuf.ConnectionFlight.departure_time:type_name -> google.protobuf.Timestamp
+	15, // 2: explore_flights.protobuf.ConnectionFlight.arrival_time:type_name -> google.protobuf.Timestamp
+	0,  // 3: explore_flights.protobuf.ConnectionFlight.code_shares:type_name -> explore_flights.protobuf.FlightNumber
+	1,  // 4: explore_flights.protobuf.Connection.flight:type_name -> explore_flights.protobuf.ConnectionFlight
+	2,  // 5: explore_flights.protobuf.Connection.outgoing:type_name -> explore_flights.protobuf.Connection
+	15, // 6: explore_flights.protobuf.ScheduleSearchRequest.min_departure_time:type_name -> google.protobuf.Timestamp
+	15, // 7: explore_flights.protobuf.ScheduleSearchRequest.max_departure_time:type_name -> google.protobuf.Timestamp
+	15, // 8: explore_flights.protobuf.ScheduleSearchRequest.as_of:type_name -> google.protobuf.Timestamp
+	15, // 9: explore_flights.protobuf.FlightScheduleRequest.version:type_name -> google.protobuf.Timestamp
+	15, // 10: explore_flights.protobuf.FlightScheduleItem.version:type_name -> google.protobuf.Timestamp
+	0,  // 11: explore_flights.protobuf.FlightScheduleVariant.operated_as:type_name -> explore_flights.protobuf.FlightNumber
+	0,  // 12: explore_flights.protobuf.FlightScheduleVariant.code_shares:type_name -> explore_flights.protobuf.FlightNumber
+	12, // 13: explore_flights.protobuf.FlightScheduleVariant.data_elements:type_name -> explore_flights.protobuf.FlightScheduleVariant.DataElementsEntry
+	0,  // 14: explore_flights.protobuf.FlightSchedule.flight_number:type_name -> explore_flights.protobuf.FlightNumber
+	6,  // 15: explore_flights.protobuf.FlightSchedule.items:type_name -> explore_flights.protobuf.FlightScheduleItem
+	8,  // 16: explore_flights.protobuf.FlightSchedulesResponse.schedules:type_name -> explore_flights.protobuf.FlightSchedule
+	13, // 17: explore_flights.protobuf.FlightSchedulesResponse.variants:type_name -> explore_flights.protobuf.FlightSchedulesResponse.VariantsEntry
+	15, // 18: explore_flights.protobuf.FlightScheduleVersion.version:type_name -> google.protobuf.Timestamp
+	0,  // 19: explore_flights.protobuf.FlightScheduleVersionsResponse.flight_number:type_name -> explore_flights.protobuf.FlightNumber
+	10, // 20: explore_flights.protobuf.FlightScheduleVersionsResponse.versions:type_name -> explore_flights.protobuf.FlightScheduleVersion
+	14, // 21: explore_flights.protobuf.FlightScheduleVersionsResponse.variants:type_name -> explore_flights.protobuf.FlightScheduleVersionsResponse.VariantsEntry
+	7,  // 22: explore_flights.protobuf.FlightSchedulesResponse.VariantsEntry.value:type_name -> explore_flights.protobuf.FlightScheduleVariant
+	7,  // 23: explore_flights.protobuf.FlightScheduleVersionsResponse.VariantsEntry.value:type_name -> explore_flights.protobuf.FlightScheduleVariant
+	16, // 24: explore_flights.protobuf.ExploreFlights.SearchConnections:input_type -> explore_flights.protobuf.ConnectionsSearchRequest
+	3,  // 25: explore_flights.protobuf.ExploreFlights.SearchSchedules:input_type -> explore_flights.protobuf.ScheduleSearchRequest
+	4,  // 26: explore_flights.protobuf.ExploreFlights.GetFlightSchedule:input_type -> explore_flights.protobuf.FlightScheduleRequest
+	5,  // 27: explore_flights.protobuf.ExploreFlights.GetFlightScheduleVersions:input_type -> explore_flights.protobuf.FlightScheduleVersionsRequest
+	2,  // 28: explore_flights.protobuf.ExploreFlights.SearchConnections:output_type -> explore_flights.protobuf.Connection
+	9,  // 29: explore_flights.protobuf.ExploreFlights.SearchSchedules:output_type -> explore_flights.protobuf.FlightSchedulesResponse
+	9,  // 30: explore_flights.protobuf.ExploreFlights.GetFlightSchedule:output_type -> explore_flights.protobuf.FlightSchedulesResponse
+	11, // 31: explore_flights.protobuf.ExploreFlights.GetFlightScheduleVersions:output_type -> explore_flights.protobuf.FlightScheduleVersionsResponse
+	28, // [28:32] is the sub-list for method output_type
+	24, // [24:28] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_explore_flights_service_proto_init() }
+func file_explore_flights_service_proto_init() {
+	if File_explore_flights_service_proto != nil {
+		return
+	}
+	file_connection_search_request_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_explore_flights_service_proto_rawDesc), len(file_explore_flights_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_explore_flights_service_proto_goTypes,
+		DependencyIndexes: file_explore_flights_service_proto_depIdxs,
+		MessageInfos:      file_explore_flights_service_proto_msgTypes,
+	}.Build()
+	File_explore_flights_service_proto = out.File
+	file_explore_flights_service_proto_goTypes = nil
+	file_explore_flights_service_proto_depIdxs = nil
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.28.3
+// source: explore_flights_service.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExploreFlights_SearchConnections_FullMethodName         = "/explore_flights.protobuf.ExploreFlights/SearchConnections"
+	ExploreFlights_SearchSchedules_FullMethodName           = "/explore_flights.protobuf.ExploreFlights/SearchSchedules"
+	ExploreFlights_GetFlightSchedule_FullMethodName         = "/explore_flights.protobuf.ExploreFlights/GetFlightSchedule"
+	ExploreFlights_GetFlightScheduleVersions_FullMethodName = "/explore_flights.protobuf.ExploreFlights/GetFlightScheduleVersions"
+)
+
+// ExploreFlightsClient is the client API for ExploreFlights service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExploreFlightsClient interface {
+	// SearchConnections streams every connection tree as soon as it is complete
+	SearchConnections(ctx context.Context, in *ConnectionsSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Connection], error)
+	SearchSchedules(ctx context.Context, in *ScheduleSearchRequest, opts ...grpc.CallOption) (*FlightSchedulesResponse, error)
+	GetFlightSchedule(ctx context.Context, in *FlightScheduleRequest, opts ...grpc.CallOption) (*FlightSchedulesResponse, error)
+	GetFlightScheduleVersions(ctx context.Context, in *FlightScheduleVersionsRequest, opts ...grpc.CallOption) (*FlightScheduleVersionsResponse, error)
+}
+
+type exploreFlightsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExploreFlightsClient(cc grpc.ClientConnInterface) ExploreFlightsClient {
+	return &exploreFlightsClient{cc}
+}
+
+func (c *exploreFlightsClient) SearchConnections(ctx context.Context, in *ConnectionsSearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Connection], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreFlights_ServiceDesc.Streams[0], ExploreFlights_SearchConnections_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConnectionsSearchRequest, Connection]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreFlights_SearchConnectionsClient = grpc.ServerStreamingClient[Connection]
+
+func (c *exploreFlightsClient) SearchSchedules(ctx context.Context, in *ScheduleSearchRequest, opts ...grpc.CallOption) (*FlightSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlightSchedulesResponse)
+	err := c.cc.Invoke(ctx, ExploreFlights_SearchSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreFlightsClient) GetFlightSchedule(ctx context.Context, in *FlightScheduleRequest, opts ...grpc.CallOption) (*FlightSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlightSchedulesResponse)
+	err := c.cc.Invoke(ctx, ExploreFlights_GetFlightSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreFlightsClient) GetFlightScheduleVersions(ctx context.Context, in *FlightScheduleVersionsRequest, opts ...grpc.CallOption) (*FlightScheduleVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlightScheduleVersionsResponse)
+	err := c.cc.Invoke(ctx, ExploreFlights_GetFlightScheduleVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExploreFlightsServer is the server API for ExploreFlights service.
+// All implementations must embed UnimplementedExploreFlightsServer
+// for forward compatibility.
+type ExploreFlightsServer interface {
+	// SearchConnections streams every connection tree as soon as it is complete
+	SearchConnections(*ConnectionsSearchRequest, grpc.ServerStreamingServer[Connection]) error
+	SearchSchedules(context.Context, *ScheduleSearchRequest) (*FlightSchedulesResponse, error)
+	GetFlightSchedule(context.Context, *FlightScheduleRequest) (*FlightSchedulesResponse, error)
+	GetFlightScheduleVersions(context.Context, *FlightScheduleVersionsRequest) (*FlightScheduleVersionsResponse, error)
+	mustEmbedUnimplementedExploreFlightsServer()
+}
+
+// UnimplementedExploreFlightsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExploreFlightsServer struct{}
+
+func (UnimplementedExploreFlightsServer) SearchConnections(*ConnectionsSearchRequest, grpc.ServerStreamingServer[Connection]) error {
+	return status.Error(codes.Unimplemented, "method SearchConnections not implemented")
+}
+func (UnimplementedExploreFlightsServer) SearchSchedules(context.Context, *ScheduleSearchRequest) (*FlightSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchSchedules not implemented")
+}
+func (UnimplementedExploreFlightsServer) GetFlightSchedule(context.Context, *FlightScheduleRequest) (*FlightSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFlightSchedule not implemented")
+}
+func (UnimplementedExploreFlightsServer) GetFlightScheduleVersions(context.Context, *FlightScheduleVersionsRequest) (*FlightScheduleVersionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFlightScheduleVersions not implemented")
+}
+func (UnimplementedExploreFlightsServer) mustEmbedUnimplementedExploreFlightsServer() {}
+func (UnimplementedExploreFlightsServer) testEmbeddedByValue()                        {}
+
+// UnsafeExploreFlightsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExploreFlightsServer will
+// result in compilation errors.
+type UnsafeExploreFlightsServer interface {
+	mustEmbedUnimplementedExploreFlightsServer()
+}
+
+func RegisterExploreFlightsServer(s grpc.ServiceRegistrar, srv ExploreFlightsServer) {
+	// If the following call panics, it indicates UnimplementedExploreFlightsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExploreFlights_ServiceDesc, srv)
+}
+
+func _ExploreFlights_SearchConnections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConnectionsSearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreFlightsServer).SearchConnections(m, &grpc.GenericServerStream[ConnectionsSearchRequest, Connection]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreFlights_SearchConnectionsServer = grpc.ServerStreamingServer[Connection]
+
+func _ExploreFlights_SearchSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreFlightsServer).SearchSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreFlights_SearchSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreFlightsServer).SearchSchedules(ctx, req.(*ScheduleSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreFlights_GetFlightSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlightScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreFlightsServer).GetFlightSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreFlights_GetFlightSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreFlightsServer).GetFlightSchedule(ctx, req.(*FlightScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreFlights_GetFlightScheduleVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlightScheduleVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreFlightsServer).GetFlightScheduleVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreFlights_GetFlightScheduleVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreFlightsServer).GetFlightScheduleVersions(ctx, req.(*FlightScheduleVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExploreFlights_ServiceDesc is the grpc.ServiceDesc for ExploreFlights service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExploreFlights_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "explore_flights.protobuf.ExploreFlights",
+	HandlerType: (*ExploreFlightsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchSchedules",
+			Handler:    _ExploreFlights_SearchSchedules_Handler,
+		},
+		{
+			MethodName: "GetFlightSchedule",
+			Handler:    _ExploreFlights_GetFlightSchedule_Handler,
+		},
+		{
+			MethodName: "GetFlightScheduleVersions",
+			Handler:    _ExploreFlights_GetFlightScheduleVersions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchConnections",
+			Handler:       _ExploreFlights_SearchConnections_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "explore_flights_service.proto",
+}
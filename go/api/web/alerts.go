@@ -0,0 +1,281 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/updates"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/gorilla/feeds"
+	"github.com/labstack/echo/v4"
+)
+
+var alertTypeNames = map[updates.AlertType]string{
+	updates.AlertTypeEquipmentSwap:         "Aircraft swap",
+	updates.AlertTypeConfigurationChange:   "Configuration change",
+	updates.AlertTypeAllegrisRemoved:       "Allegris removed",
+	updates.AlertTypeFirstRemoved:          "First Class removed",
+	updates.AlertTypeBusinessRemoved:       "Business Class removed",
+	updates.AlertTypePremiumEconomyRemoved: "Premium Economy removed",
+	updates.AlertTypeDowngauge:             "Downgauge",
+}
+
+type alertsHandlerRepo interface {
+	Airlines(ctx context.Context) (map[string]db.Airline, error)
+	Airports(ctx context.Context) (map[string]db.Airport, error)
+}
+
+type AlertsHandler struct {
+	repo   alertsHandlerRepo
+	search *updates.Search
+}
+
+func NewAlertsHandler(repo alertsHandlerRepo, search *updates.Search) *AlertsHandler {
+	return &AlertsHandler{
+		repo:   repo,
+		search: search,
+	}
+}
+
+func (h *AlertsHandler) Alerts(c echo.Context) error {
+	ctx := c.Request().Context()
+	q := c.QueryParams()
+
+	numVersions := updates.DefaultAlertVersions
+	if raw := q.Get("versions"); raw != "" {
+		var err error
+		if numVersions, err = strconv.Atoi(raw); err != nil || numVersions < 1 || numVersions > updates.MaxAlertVersions {
+			return NewHTTPError(http.StatusBadRequest, WithMessage(fmt.Sprintf("versions must be between 1 and %d", updates.MaxAlertVersions)))
+		}
+	}
+
+	var filter updates.Filter
+	for _, raw := range q["airlineId"] {
+		airline, err := h.parseAirline(ctx, raw)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+
+		filter.AirlineIataCodes = append(filter.AirlineIataCodes, airline)
+	}
+
+	if raw := q.Get("departureAirportId"); raw != "" {
+		var err error
+		if filter.DepartureAirportIataCode, err = h.parseAirport(ctx, raw); err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+	}
+
+	if raw := q.Get("arrivalAirportId"); raw != "" {
+		var err error
+		if filter.ArrivalAirportIataCode, err = h.parseAirport(ctx, raw); err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+	}
+
+	alerts, err := h.search.Alerts(ctx, filter, numVersions)
+	if err != nil {
+		if errors.Is(err, updates.ErrAlertsFilterRequired) {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err), WithUnmaskedCause())
+		}
+
+		return err
+	}
+
+	if q.Has("downgradesOnly") {
+		alerts = filterDowngrades(alerts)
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSON(http.StatusOK, alerts)
+}
+
+func (h *AlertsHandler) AirlineRSSFeed(c echo.Context) error {
+	return h.airlineFeed(c, mimeRSS, (*feeds.Feed).WriteRss)
+}
+
+func (h *AlertsHandler) AirlineAtomFeed(c echo.Context) error {
+	return h.airlineFeed(c, mimeAtom, (*feeds.Feed).WriteAtom)
+}
+
+func (h *AlertsHandler) AirlineJSONFeed(c echo.Context) error {
+	return h.airlineFeed(c, mimeJSONFeed, (*feeds.Feed).WriteJSON)
+}
+
+func (h *AlertsHandler) airlineFeed(c echo.Context, contentType string, writer func(*feeds.Feed, io.Writer) error) error {
+	ctx := c.Request().Context()
+	airline, err := h.parseAirline(ctx, c.Param("airlineId"))
+	if err != nil {
+		return NewHTTPError(http.StatusNotFound, WithCause(err))
+	}
+
+	alerts, err := h.search.Alerts(ctx, updates.Filter{AirlineIataCodes: []string{airline}}, updates.DefaultAlertVersions)
+	if err != nil {
+		return err
+	}
+
+	feed := buildAlertsFeed(
+		alerts,
+		"https://explore.flights/alerts/airline/"+airline,
+		fmt.Sprintf("Aircraft swaps and downgrades of %s flights", airline),
+	)
+
+	return writeFeed(c, feed, contentType, writer)
+}
+
+func (h *AlertsHandler) RouteRSSFeed(c echo.Context) error {
+	return h.routeFeed(c, mimeRSS, (*feeds.Feed).WriteRss)
+}
+
+func (h *AlertsHandler) RouteAtomFeed(c echo.Context) error {
+	return h.routeFeed(c, mimeAtom, (*feeds.Feed).WriteAtom)
+}
+
+func (h *AlertsHandler) RouteJSONFeed(c echo.Context) error {
+	return h.routeFeed(c, mimeJSONFeed, (*feeds.Feed).WriteJSON)
+}
+
+func (h *AlertsHandler) routeFeed(c echo.Context, contentType string, writer func(*feeds.Feed, io.Writer) error) error {
+	ctx := c.Request().Context()
+	departureAirport, err := h.parseAirport(ctx, c.Param("departureAirport"))
+	if err != nil {
+		return NewHTTPError(http.StatusNotFound, WithCause(err))
+	}
+
+	arrivalAirport, err := h.parseAirport(ctx, c.Param("arrivalAirport"))
+	if err != nil {
+		return NewHTTPError(http.StatusNotFound, WithCause(err))
+	}
+
+	filter := updates.Filter{
+		DepartureAirportIataCode: departureAirport,
+		ArrivalAirportIataCode:   arrivalAirport,
+	}
+
+	alerts, err := h.search.Alerts(ctx, filter, updates.DefaultAlertVersions)
+	if err != nil {
+		return err
+	}
+
+	feed := buildAlertsFeed(
+		alerts,
+		fmt.Sprintf("https://explore.flights/alerts/route/%s/%s", departureAirport, arrivalAirport),
+		fmt.Sprintf("Aircraft swaps and downgrades of flights from %s to %s", departureAirport, arrivalAirport),
+	)
+
+	return writeFeed(c, feed, contentType, writer)
+}
+
+func (h *AlertsHandler) parseAirline(ctx context.Context, raw string) (string, error) {
+	return util{}.parseAirline(ctx, raw, h.repo.Airlines)
+}
+
+func (h *AlertsHandler) parseAirport(ctx context.Context, raw string) (string, error) {
+	return util{}.parseAirport(ctx, raw, h.repo.Airports)
+}
+
+func filterDowngrades(alerts []updates.Alert) []updates.Alert {
+	result := make([]updates.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		if alert.Downgrade() {
+			result = append(result, alert)
+		}
+	}
+
+	return result
+}
+
+// buildAlertsFeed expects the alerts to be sorted newest first
+func buildAlertsFeed(alerts []updates.Alert, feedId, title string) *feeds.Feed {
+	const maxSize = 50
+
+	equipmentName := func(e updates.Equipment) string {
+		configName := e.AircraftConfigurationVersion
+		if e.AircraftConfigurationName != "" {
+			configName = e.AircraftConfigurationName
+		}
+
+		return fmt.Sprintf("%s (%s)", e.AircraftIataCode, configName)
+	}
+
+	seatsName := func(e updates.Equipment) string {
+		return fmt.Sprintf("F%d C%d E%d M%d", e.SeatsFirst, e.SeatsBusiness, e.SeatsPremium, e.SeatsEconomy)
+	}
+
+	feed := &feeds.Feed{
+		Id:    feedId,
+		Title: title,
+		Link: &feeds.Link{
+			Href: feedId,
+			Rel:  "alternate",
+			Type: "text/html",
+		},
+		Created: common.ProjectCreationTime(),
+		Updated: common.ProjectCreationTime(),
+	}
+
+	if len(alerts) > maxSize {
+		alerts = alerts[:maxSize]
+	}
+
+	for _, alert := range alerts {
+		firstDate := alert.DepartureDatesLocal[0]
+		lastDate := alert.DepartureDatesLocal[len(alert.DepartureDatesLocal)-1]
+		itemId := fmt.Sprintf("https://explore.flights/flight/%s/versions/%s/%s#%s", alert.FlightNumber, alert.DepartureAirportIataCode, firstDate.String(), alert.Version.Format(time.RFC3339))
+
+		typeNames := make([]string, 0, len(alert.Types))
+		for _, t := range alert.Types {
+			typeNames = append(typeNames, alertTypeNames[t])
+		}
+
+		item := &feeds.Item{
+			Id:          itemId,
+			IsPermaLink: "false",
+			Link: &feeds.Link{
+				Href: itemId,
+				Rel:  "alternate",
+				Type: "text/html",
+			},
+			Title: fmt.Sprintf("%s %s-%s: %s", alert.FlightNumber, alert.DepartureAirportIataCode, alert.ArrivalAirportIataCode, strings.Join(typeNames, ", ")),
+			Content: strings.TrimSpace(fmt.Sprintf(
+				`
+Aircraft: old=%s new=%s
+Seats: old=%s new=%s
+Affected departures: %s until %s for a total of %d flights
+`,
+				equipmentName(alert.From),
+				equipmentName(alert.To),
+				seatsName(alert.From),
+				seatsName(alert.To),
+				firstDate.String(),
+				lastDate.String(),
+				len(alert.DepartureDatesLocal),
+			)),
+			Created: alert.Version,
+			Updated: alert.Version,
+		}
+
+		item.Description = item.Content
+		feed.Items = append(feed.Items, item)
+
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+	}
+
+	return feed
+}
+
+func writeFeed(c echo.Context, feed *feeds.Feed, contentType string, writer func(*feeds.Feed, io.Writer) error) error {
+	c.Response().Header().Add(echo.HeaderContentType, contentType)
+	addExpirationHeaders(c, time.Now(), time.Hour)
+
+	return writer(feed, c.Response())
+}
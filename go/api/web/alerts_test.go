@@ -0,0 +1,38 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/updates"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAlertsFeed(t *testing.T) {
+	version := time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC)
+	alerts := []updates.Alert{{
+		Version:                  version,
+		FlightNumber:             "LH400",
+		DepartureAirportIataCode: "FRA",
+		ArrivalAirportIataCode:   "JFK",
+		DepartureDatesLocal:      []xtime.LocalDate{xtime.NewLocalDateFromParts(2026, time.May, 1), xtime.NewLocalDateFromParts(2026, time.May, 3)},
+		Types:                    []updates.AlertType{updates.AlertTypeConfigurationChange, updates.AlertTypeAllegrisRemoved},
+		From:                     updates.Equipment{AircraftIataCode: "359", AircraftConfigurationVersion: "F4C38E24M201", AircraftConfigurationName: "Allegris", SeatsFirst: 4, SeatsBusiness: 38, SeatsPremium: 24, SeatsEconomy: 201},
+		To:                       updates.Equipment{AircraftIataCode: "359", AircraftConfigurationVersion: "C48E21M224", SeatsBusiness: 48, SeatsPremium: 21, SeatsEconomy: 224},
+	}}
+
+	feed := buildAlertsFeed(alerts, "https://explore.flights/alerts/airline/LH", "LH")
+	assert.Equal(t, version, feed.Updated)
+	require.Len(t, feed.Items, 1)
+
+	item := feed.Items[0]
+	assert.Equal(t, "https://explore.flights/flight/LH400/versions/FRA/2026-05-01#2026-03-02T12:00:00Z", item.Id)
+	assert.Equal(t, "LH400 FRA-JFK: Configuration change, Allegris removed", item.Title)
+	assert.Contains(t, item.Content, "Aircraft: old=359 (Allegris) new=359 (C48E21M224)")
+	assert.Contains(t, item.Content, "2026-05-01 until 2026-05-03 for a total of 2 flights")
+
+	_, err := feed.ToJSON()
+	require.NoError(t, err)
+}
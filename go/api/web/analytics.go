@@ -0,0 +1,162 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/analytics"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultBusiestRoutesLimit = 20
+	maxBusiestRoutesLimit     = 100
+)
+
+type analyticsHandlerRepo interface {
+	Airlines(ctx context.Context) (map[string]db.Airline, error)
+	Airports(ctx context.Context) (map[string]db.Airport, error)
+}
+
+type AnalyticsHandler struct {
+	repo   analyticsHandlerRepo
+	search *analytics.Search
+}
+
+func NewAnalyticsHandler(repo analyticsHandlerRepo, search *analytics.Search) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		repo:   repo,
+		search: search,
+	}
+}
+
+func (h *AnalyticsHandler) RouteFrequencies(c echo.Context) error {
+	q, err := h.parseQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.search.RouteFrequencies(c.Request().Context(), q)
+	return h.respond(c, result, err)
+}
+
+func (h *AnalyticsHandler) Capacity(c echo.Context) error {
+	q, err := h.parseQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.search.Capacity(c.Request().Context(), q)
+	return h.respond(c, result, err)
+}
+
+func (h *AnalyticsHandler) BusiestRoutes(c echo.Context) error {
+	q, err := h.parseQuery(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultBusiestRoutesLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxBusiestRoutesLimit {
+			return NewHTTPError(http.StatusBadRequest, WithMessage(fmt.Sprintf("limit must be between 1 and %d", maxBusiestRoutesLimit)))
+		}
+	}
+
+	var bySeats bool
+	switch c.QueryParam("sortBy") {
+	case "", "flights":
+		break
+
+	case "seats":
+		bySeats = true
+
+	default:
+		return NewHTTPError(http.StatusBadRequest, WithMessage("sortBy must be flights or seats"))
+	}
+
+	result, err := h.search.BusiestRoutes(c.Request().Context(), q, limit, bySeats)
+	return h.respond(c, result, err)
+}
+
+func (h *AnalyticsHandler) RouteChanges(c echo.Context) error {
+	q, err := h.parseQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.search.RouteChanges(c.Request().Context(), q)
+	return h.respond(c, result, err)
+}
+
+func (h *AnalyticsHandler) parseQuery(c echo.Context) (analytics.Query, error) {
+	ctx := c.Request().Context()
+	params := c.QueryParams()
+
+	var q analytics.Query
+	var err error
+	if q.Start, err = xtime.ParseLocalDate(params.Get("start")); err != nil {
+		return q, NewHTTPError(http.StatusBadRequest, WithMessage("invalid start"), WithCause(err))
+	}
+
+	if q.End, err = xtime.ParseLocalDate(params.Get("end")); err != nil {
+		return q, NewHTTPError(http.StatusBadRequest, WithMessage("invalid end"), WithCause(err))
+	}
+
+	q.GroupBy = db.AnalyticsGrouping(params.Get("groupBy"))
+
+	for _, raw := range params["airlineId"] {
+		airline, err := h.parseAirline(ctx, raw)
+		if err != nil {
+			return q, NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+
+		q.AirlineIataCodes = append(q.AirlineIataCodes, airline)
+	}
+
+	for _, raw := range params["aircraftId"] {
+		q.AircraftIataCodes = append(q.AircraftIataCodes, strings.ToUpper(raw))
+	}
+
+	if raw := params.Get("departureAirportId"); raw != "" {
+		if q.DepartureAirportIataCode, err = h.parseAirport(ctx, raw); err != nil {
+			return q, NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+	}
+
+	if raw := params.Get("arrivalAirportId"); raw != "" {
+		if q.ArrivalAirportIataCode, err = h.parseAirport(ctx, raw); err != nil {
+			return q, NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+	}
+
+	return q, nil
+}
+
+func (h *AnalyticsHandler) parseAirline(ctx context.Context, raw string) (string, error) {
+	return util{}.parseAirline(ctx, raw, h.repo.Airlines)
+}
+
+func (h *AnalyticsHandler) parseAirport(ctx context.Context, raw string) (string, error) {
+	return util{}.parseAirport(ctx, raw, h.repo.Airports)
+}
+
+func (h *AnalyticsHandler) respond(c echo.Context, result any, err error) error {
+	if err != nil {
+		if errors.Is(err, analytics.ErrInvalidRange) || errors.Is(err, analytics.ErrInvalidGrouping) {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err), WithUnmaskedCause())
+		}
+
+		return err
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSON(http.StatusOK, result)
+}
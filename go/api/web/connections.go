@@ -74,15 +74,6 @@ func (ch *ConnectionsHandler) connections(c echo.Context, export string) error {
 	maxLayover := time.Duration(req.MaxLayoverMS) * time.Millisecond
 	maxDuration := time.Duration(req.MaxDurationMS) * time.Millisecond
 
-	options := make([]connections.SearchOption, 0)
-	options = append(options, connections.WithCountMultiLeg(req.CountMultiLeg))
-	options = appendStringOptions[connections.WithIncludeAirport, connections.WithIncludeAirportGlob](options, req.IncludeAirport)
-	options = appendSliceOptions[connections.WithExcludeAirport, connections.WithExcludeAirportGlob](options, req.ExcludeAirport)
-	options = appendStringOptions[connections.WithIncludeFlightNumber, connections.WithIncludeFlightNumberGlob](options, req.IncludeFlightNumber)
-	options = appendSliceOptions[connections.WithExcludeFlightNumber, connections.WithExcludeFlightNumberGlob](options, req.ExcludeFlightNumber)
-	options = appendStringOptions[connections.WithIncludeAircraft, connections.WithIncludeAircraftGlob](options, req.IncludeAircraft)
-	options = appendSliceOptions[connections.WithExcludeAircraft, connections.WithExcludeAircraftGlob](options, req.ExcludeAircraft)
-
 	conns, err := ch.search.FindConnections(
 		ctx,
 		req.Origins,
@@ -93,7 +84,7 @@ func (ch *ConnectionsHandler) connections(c echo.Context, export string) error {
 		minLayover,
 		maxLayover,
 		maxDuration,
-		options...,
+		connectionsSearchOptions(req)...,
 	)
 
 	if err != nil {
@@ -145,9 +136,9 @@ func (ch *ConnectionsHandler) ConnectionsShareCreate(c echo.Context) error {
 	scheme, host := contextSchemeAndHost(c)
 	payload := base64.RawURLEncoding.EncodeToString(b)
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"htmlUrl":  ch.shareHtmlUrl(scheme, host, payload),
-		"imageUrl": ch.shareImageUrl(scheme, host, payload),
+	return c.JSON(http.StatusOK, model.ConnectionsShareResponse{
+		HtmlUrl:  ch.shareHtmlUrl(scheme, host, payload),
+		ImageUrl: ch.shareImageUrl(scheme, host, payload),
 	})
 }
 
@@ -209,7 +200,7 @@ func (ch *ConnectionsHandler) parseAndValidateRequest(c echo.Context) (model.Con
 		return model.ConnectionsSearchRequest{}, err
 	}
 
-	if err = ch.validateRequest(req); err != nil {
+	if err = validateConnectionsSearchRequest(req); err != nil {
 		return model.ConnectionsSearchRequest{}, err
 	}
 
@@ -235,28 +226,12 @@ func (ch *ConnectionsHandler) parseRequest(c echo.Context) (model.ConnectionsSea
 			return model.ConnectionsSearchRequest{}, err
 		}
 
-		countMultiLeg := true // multi-leg flights were counted before this option was added
-		if pbReq.CountMultiLeg != nil {
-			countMultiLeg = *pbReq.CountMultiLeg
-		}
+		req = connectionsSearchRequestFromPb(&pbReq)
+	}
 
-		req = model.ConnectionsSearchRequest{
-			Origins:             pbReq.Origins,
-			Destinations:        pbReq.Destinations,
-			MinDeparture:        pbReq.MinDeparture.AsTime(),
-			MaxDeparture:        pbReq.MaxDeparture.AsTime(),
-			MaxFlights:          pbReq.MaxFlights,
-			MinLayoverMS:        uint64(pbReq.MinLayover.AsDuration().Milliseconds()),
-			MaxLayoverMS:        uint64(pbReq.MaxLayover.AsDuration().Milliseconds()),
-			MaxDurationMS:       uint64(pbReq.MaxDuration.AsDuration().Milliseconds()),
-			CountMultiLeg:       countMultiLeg,
-			IncludeAirport:      pbReq.IncludeAirport,
-			ExcludeAirport:      pbReq.ExcludeAirport,
-			IncludeFlightNumber: pbReq.IncludeFlightNumber,
-			ExcludeFlightNumber: pbReq.ExcludeFlightNumber,
-			IncludeAircraft:     pbReq.IncludeAircraft,
-			ExcludeAircraft:     pbReq.ExcludeAircraft,
-		}
+	// a version within the request takes precedence over the asOf query parameter
+	if req.AsOf == nil {
+		req.AsOf = requestContextAsOf(c.Request().Context())
 	}
 
 	return req, nil
@@ -331,17 +306,7 @@ func (ch *ConnectionsHandler) buildConnectionsResponse(conns []connections.Conne
 			referencedAirports.Add(conn.Flight.ArrivalAirportIataCode)
 			referencedAircraft.Add(conn.Flight.AircraftIataCode)
 
-			flights[fid] = model.ConnectionFlightResponse{
-				FlightNumber:             model.FlightNumberFromDb(conn.Flight.FlightNumber),
-				DepartureTime:            conn.Flight.DepartureTime,
-				DepartureAirportIataCode: conn.Flight.DepartureAirportIataCode,
-				ArrivalTime:              conn.Flight.ArrivalTime,
-				ArrivalAirportIataCode:   conn.Flight.ArrivalAirportIataCode,
-				AircraftOwner:            conn.Flight.AircraftOwner,
-				AircraftIataCode:         conn.Flight.AircraftIataCode,
-				AircraftConfiguration:    conn.Flight.AircraftConfigurationVersion,
-				CodeShares:               ch.convertCodeShares(conn.Flight.CodeShares, referencedAirlines),
-			}
+			flights[fid] = ch.convertFlight(conn.Flight, referencedAirlines)
 		}
 
 		outgoing, err := ch.buildConnectionsResponse(conn.Outgoing, flights, uuidByFlight, referencedAirlines, referencedAirports, referencedAircraft)
@@ -358,6 +323,20 @@ func (ch *ConnectionsHandler) buildConnectionsResponse(conns []connections.Conne
 	return r, nil
 }
 
+func (ch *ConnectionsHandler) convertFlight(f *connections.Flight, referencedAirlines common.Set[string]) model.ConnectionFlightResponse {
+	return model.ConnectionFlightResponse{
+		FlightNumber:             model.FlightNumberFromDb(f.FlightNumber),
+		DepartureTime:            f.DepartureTime,
+		DepartureAirportIataCode: f.DepartureAirportIataCode,
+		ArrivalTime:              f.ArrivalTime,
+		ArrivalAirportIataCode:   f.ArrivalAirportIataCode,
+		AircraftOwner:            f.AircraftOwner,
+		AircraftIataCode:         f.AircraftIataCode,
+		AircraftConfiguration:    f.AircraftConfigurationVersion,
+		CodeShares:               ch.convertCodeShares(f.CodeShares, referencedAirlines),
+	}
+}
+
 func (ch *ConnectionsHandler) convertCodeShares(inp common.Set[db.FlightNumber], referencedAirlines common.Set[string]) []model.FlightNumber {
 	r := make([]model.FlightNumber, 0, len(inp))
 	for fn := range inp {
@@ -465,7 +444,56 @@ func (ch *ConnectionsHandler) buildNodeLabel(f *connections.Flight, airline db.A
 	return fmt.Sprintf("%s\n%s\u2014%s\n%s", f.FlightNumber.String(), departureAirport.IataCode, arrivalAirport.IataCode, aircraftStr)
 }
 
-func (ch *ConnectionsHandler) validateRequest(req model.ConnectionsSearchRequest) error {
+func connectionsSearchRequestFromPb(pbReq *pb.ConnectionsSearchRequest) model.ConnectionsSearchRequest {
+	countMultiLeg := true // multi-leg flights were counted before this option was added
+	if pbReq.CountMultiLeg != nil {
+		countMultiLeg = *pbReq.CountMultiLeg
+	}
+
+	var asOf *time.Time
+	if pbReq.AsOf != nil {
+		t := pbReq.AsOf.AsTime()
+		asOf = &t
+	}
+
+	return model.ConnectionsSearchRequest{
+		Origins:             pbReq.Origins,
+		Destinations:        pbReq.Destinations,
+		MinDeparture:        pbReq.MinDeparture.AsTime(),
+		MaxDeparture:        pbReq.MaxDeparture.AsTime(),
+		MaxFlights:          pbReq.MaxFlights,
+		MinLayoverMS:        uint64(pbReq.MinLayover.AsDuration().Milliseconds()),
+		MaxLayoverMS:        uint64(pbReq.MaxLayover.AsDuration().Milliseconds()),
+		MaxDurationMS:       uint64(pbReq.MaxDuration.AsDuration().Milliseconds()),
+		CountMultiLeg:       countMultiLeg,
+		IncludeAirport:      pbReq.IncludeAirport,
+		ExcludeAirport:      pbReq.ExcludeAirport,
+		IncludeFlightNumber: pbReq.IncludeFlightNumber,
+		ExcludeFlightNumber: pbReq.ExcludeFlightNumber,
+		IncludeAircraft:     pbReq.IncludeAircraft,
+		ExcludeAircraft:     pbReq.ExcludeAircraft,
+		AsOf:                asOf,
+	}
+}
+
+func connectionsSearchOptions(req model.ConnectionsSearchRequest) []connections.SearchOption {
+	options := make([]connections.SearchOption, 0)
+	options = append(options, connections.WithCountMultiLeg(req.CountMultiLeg))
+	options = appendStringOptions[connections.WithIncludeAirport, connections.WithIncludeAirportGlob](options, req.IncludeAirport)
+	options = appendSliceOptions[connections.WithExcludeAirport, connections.WithExcludeAirportGlob](options, req.ExcludeAirport)
+	options = appendStringOptions[connections.WithIncludeFlightNumber, connections.WithIncludeFlightNumberGlob](options, req.IncludeFlightNumber)
+	options = appendSliceOptions[connections.WithExcludeFlightNumber, connections.WithExcludeFlightNumberGlob](options, req.ExcludeFlightNumber)
+	options = appendStringOptions[connections.WithIncludeAircraft, connections.WithIncludeAircraftGlob](options, req.IncludeAircraft)
+	options = appendSliceOptions[connections.WithExcludeAircraft, connections.WithExcludeAircraftGlob](options, req.ExcludeAircraft)
+
+	if req.AsOf != nil {
+		options = append(options, connections.WithAsOf(*req.AsOf))
+	}
+
+	return options
+}
+
+func validateConnectionsSearchRequest(req model.ConnectionsSearchRequest) error {
 	maxDuration := time.Duration(req.MaxDurationMS) * time.Millisecond
 
 	if len(req.Origins) < 1 || len(req.Origins) > 10 {
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/web/model"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionsParseRequestAsOf(t *testing.T) {
+	queryAsOf := time.Date(2025, time.February, 28, 23, 0, 0, 0, time.UTC)
+	bodyAsOf := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name     string
+		query    string
+		body     string
+		expected *time.Time
+	}{
+		{"latest", "", `{}`, nil},
+		{"query", "?asOf=2025-02-28T23:00:00Z", `{}`, &queryAsOf},
+		{"body", "", `{"asOf":"2025-01-01T00:00:00Z"}`, &bodyAsOf},
+		{"bodyTakesPrecedence", "?asOf=2025-02-28T23:00:00Z", `{"asOf":"2025-01-01T00:00:00Z"}`, &bodyAsOf},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/connections/json"+tc.query, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			ch := NewConnectionsHandler(nil, nil)
+			var parsed model.ConnectionsSearchRequest
+			err := AsOfMiddleware()(func(c echo.Context) error {
+				var err error
+				parsed, err = ch.parseRequest(c)
+				return err
+			})(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed.AsOf)
+		})
+	}
+}
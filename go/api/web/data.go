@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"errors"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/explore-flights/monorepo/go/api/business/raw"
 	"github.com/explore-flights/monorepo/go/api/business/seatmap"
+	"github.com/explore-flights/monorepo/go/api/business/updates"
 	"github.com/explore-flights/monorepo/go/api/data"
 	"github.com/explore-flights/monorepo/go/api/db"
 	"github.com/explore-flights/monorepo/go/api/web/model"
@@ -40,20 +42,22 @@ type dataHandlerRepo interface {
 	FlightScheduleVersions(ctx context.Context, fn db.FlightNumber, departureAirportIataCode string, departureDate xtime.LocalDate) (db.FlightScheduleVersions, error)
 	GlobalUpdatesReport(ctx context.Context) ([]db.UpdateReportItem, error)
 	UpdatesReport(ctx context.Context, fn db.FlightNumber, version time.Time) ([]db.UpdateReportItem, error)
-	Destinations(ctx context.Context, departureAirportIataCode string) ([]string, error)
+	Destinations(ctx context.Context, departureAirportIataCode string, asOf *time.Time) ([]string, error)
 }
 
 type DataHandler struct {
 	repo      dataHandlerRepo
 	smSearch  *seatmap.Search
 	rawSearch *raw.Search
+	updSearch *updates.Search
 }
 
-func NewDataHandler(repo dataHandlerRepo, smSearch *seatmap.Search, rawSearch *raw.Search) *DataHandler {
+func NewDataHandler(repo dataHandlerRepo, smSearch *seatmap.Search, rawSearch *raw.Search, updSearch *updates.Search) *DataHandler {
 	return &DataHandler{
 		repo:      repo,
 		smSearch:  smSearch,
 		rawSearch: rawSearch,
+		updSearch: updSearch,
 	}
 }
 
@@ -292,6 +296,53 @@ func (dh *DataHandler) FlightScheduleVersionRaw(c echo.Context) error {
 }
 
 func (dh *DataHandler) SeatMap(c echo.Context) error {
+	sm, err := dh.loadSeatMap(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, sm)
+}
+
+func (dh *DataHandler) SeatMapSVG(c echo.Context) error {
+	sm, err := dh.loadSeatMap(c)
+	if err != nil {
+		return err
+	}
+
+	var opts []seatmap.RenderOption
+	for _, highlight := range c.QueryParams()["highlight"] {
+		opts = append(opts, seatmap.WithHighlightFeatures(strings.Split(highlight, ",")))
+	}
+
+	var buf bytes.Buffer
+	if err := seatmap.RenderSVG(&buf, sm, opts...); err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, mimeSVG, buf.Bytes())
+}
+
+func (dh *DataHandler) SeatMapScores(c echo.Context) error {
+	profile, err := seatmap.ParseScoringProfile(c.Param("profile"))
+	if err != nil {
+		return NewHTTPError(http.StatusBadRequest, WithCause(err), WithUnmaskedCause())
+	}
+
+	sm, err := dh.loadSeatMap(c)
+	if err != nil {
+		return err
+	}
+
+	scores, err := seatmap.ScoreSeats(sm, profile)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, scores)
+}
+
+func (dh *DataHandler) loadSeatMap(c echo.Context) (seatmap.SeatMap, error) {
 	ctx := c.Request().Context()
 
 	fnRaw := c.Param("fn")
@@ -300,20 +351,62 @@ func (dh *DataHandler) SeatMap(c echo.Context) error {
 
 	fn, err := dh.parseFlightNumber(ctx, fnRaw)
 	if err != nil {
-		return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		return seatmap.SeatMap{}, NewHTTPError(http.StatusBadRequest, WithCause(err))
 	}
 
 	departureAirportIataCode, err := dh.parseAirport(ctx, departureAirportRaw)
 	if err != nil {
-		return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		return seatmap.SeatMap{}, NewHTTPError(http.StatusBadRequest, WithCause(err))
 	}
 
 	var departureDateLocal xtime.LocalDate
 	if departureDateLocal, err = xtime.ParseLocalDate(departureDateLocalRaw); err != nil {
-		return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		return seatmap.SeatMap{}, NewHTTPError(http.StatusBadRequest, WithCause(err))
 	}
 
 	sm, err := dh.smSearch.SeatMap(ctx, fn, departureAirportIataCode, departureDateLocal)
+	if err != nil {
+		if errors.Is(err, seatmap.ErrNotFound) {
+			return seatmap.SeatMap{}, NewHTTPError(http.StatusNotFound, WithCause(err))
+		}
+
+		return seatmap.SeatMap{}, err
+	}
+
+	return sm, nil
+}
+
+func (dh *DataHandler) SeatMapVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	key, err := dh.parseSeatMapConfigurationKey(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := dh.smSearch.Versions(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSON(http.StatusOK, versions)
+}
+
+func (dh *DataHandler) SeatMapVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	key, err := dh.parseSeatMapConfigurationKey(c)
+	if err != nil {
+		return err
+	}
+
+	version, err := dh.parseSeatMapVersion(ctx, key, c.Param("version"))
+	if err != nil {
+		return err
+	}
+
+	sm, err := dh.smSearch.SeatMapVersion(ctx, key, version)
 	if err != nil {
 		if errors.Is(err, seatmap.ErrNotFound) {
 			return NewHTTPError(http.StatusNotFound, WithCause(err))
@@ -322,9 +415,70 @@ func (dh *DataHandler) SeatMap(c echo.Context) error {
 		return err
 	}
 
+	addExpirationHeaders(c, time.Now(), time.Hour)
 	return c.JSON(http.StatusOK, sm)
 }
 
+func (dh *DataHandler) SeatMapDiff(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	key, err := dh.parseSeatMapConfigurationKey(c)
+	if err != nil {
+		return err
+	}
+
+	from, err := dh.parseSeatMapVersion(ctx, key, c.Param("from"))
+	if err != nil {
+		return err
+	}
+
+	to, err := dh.parseSeatMapVersion(ctx, key, c.Param("to"))
+	if err != nil {
+		return err
+	}
+
+	diff, err := dh.smSearch.Diff(ctx, key, from, to)
+	if err != nil {
+		if errors.Is(err, seatmap.ErrNotFound) {
+			return NewHTTPError(http.StatusNotFound, WithCause(err))
+		}
+
+		return err
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSON(http.StatusOK, diff)
+}
+
+func (dh *DataHandler) SeatMapCompare(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var keys [2]seatmap.ConfigurationKey
+	for i, param := range []string{"a", "b"} {
+		parts := strings.Split(c.QueryParam(param), "/")
+		if len(parts) != 3 {
+			return NewHTTPError(http.StatusBadRequest, WithMessage(fmt.Sprintf("Query param %q must be formatted as airline/aircraft/configuration", param)))
+		}
+
+		var err error
+		if keys[i], err = dh.resolveSeatMapConfigurationKey(ctx, parts[0], parts[1], parts[2]); err != nil {
+			return err
+		}
+	}
+
+	comparison, err := dh.smSearch.Compare(ctx, keys[0], keys[1])
+	if err != nil {
+		if errors.Is(err, seatmap.ErrNotFound) {
+			return NewHTTPError(http.StatusNotFound, WithCause(err))
+		}
+
+		return err
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSON(http.StatusOK, comparison)
+}
+
 func (dh *DataHandler) FlightScheduleVersionsRSSFeed(c echo.Context) error {
 	return dh.flightScheduleVersionsFeed(c, "application/rss+xml", (*feeds.Feed).WriteRss)
 }
@@ -594,7 +748,7 @@ func (dh *DataHandler) Destinations(c echo.Context) error {
 		g, ctx := errgroup.WithContext(ctx)
 		g.Go(func() error {
 			var err error
-			destinationAirportIataCodes, err = dh.repo.Destinations(ctx, departureAirportIataCode)
+			destinationAirportIataCodes, err = dh.repo.Destinations(ctx, departureAirportIataCode, requestContextAsOf(ctx))
 			return err
 		})
 
@@ -639,6 +793,63 @@ func (dh *DataHandler) GlobalUpdates(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+func (dh *DataHandler) UpdatesDiff(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var version *time.Time
+	if versionRaw := c.Param("version"); versionRaw != "latest" {
+		v, err := time.Parse(time.RFC3339, versionRaw)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithMessage("Invalid version format"), WithCause(err))
+		}
+
+		version = &v
+	}
+
+	var since *time.Time
+	if sinceRaw := c.QueryParam("since"); sinceRaw != "" {
+		v, err := time.Parse(time.RFC3339, sinceRaw)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithMessage("Invalid since format"), WithCause(err))
+		}
+
+		since = &v
+	}
+
+	filter := updates.Filter{
+		AirlineIataCodes: c.QueryParams()["airlineId"],
+	}
+
+	if airportRaw := c.QueryParam("airportId"); airportRaw != "" {
+		var err error
+		if filter.AirportIataCode, err = dh.parseAirport(ctx, airportRaw); err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+	}
+
+	limit := updates.DefaultDiffLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > updates.MaxDiffLimit {
+			return NewHTTPError(http.StatusBadRequest, WithMessage(fmt.Sprintf("limit must be between 1 and %d", updates.MaxDiffLimit)))
+		}
+	}
+
+	diff, err := dh.updSearch.Diff(ctx, since, version, filter, limit)
+	if err != nil {
+		if errors.Is(err, updates.ErrNotFound) {
+			return NewHTTPError(http.StatusNotFound, WithCause(err))
+		} else if errors.Is(err, updates.ErrInvalidVersions) {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err), WithUnmaskedCause())
+		}
+
+		return err
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSON(http.StatusOK, diff)
+}
+
 func (dh *DataHandler) LegacyFlightScheduleVersionsRSSFeed(c echo.Context) error {
 	return dh.legacyFlightScheduleVersionsFeed(c, "application/rss+xml", (*feeds.Feed).WriteRss)
 }
@@ -904,6 +1115,94 @@ func (dh *DataHandler) parseAndResolveFlightNumber(ctx context.Context, raw stri
 	return db.FlightNumber{}, db.Airline{}, fmt.Errorf("airline not found: %q", fn.AirlineIataCode)
 }
 
+func (dh *DataHandler) parseSeatMapConfigurationKey(c echo.Context) (seatmap.ConfigurationKey, error) {
+	return dh.resolveSeatMapConfigurationKey(
+		c.Request().Context(),
+		c.Param("airlineId"),
+		c.Param("aircraftId"),
+		c.Param("aircraftConfigurationVersion"),
+	)
+}
+
+func (dh *DataHandler) resolveSeatMapConfigurationKey(ctx context.Context, airlineRaw, aircraftRaw, configurationRaw string) (seatmap.ConfigurationKey, error) {
+	airlineRaw = strings.ToUpper(airlineRaw)
+	aircraftRaw = strings.ToUpper(aircraftRaw)
+
+	var airlines map[string]db.Airline
+	var aircraft map[string]db.Aircraft
+	{
+		g, ctx := errgroup.WithContext(ctx)
+		g.Go(func() error {
+			var err error
+			airlines, err = dh.repo.Airlines(ctx)
+			return err
+		})
+
+		g.Go(func() error {
+			var err error
+			aircraft, err = dh.repo.Aircraft(ctx)
+			return err
+		})
+
+		if err := g.Wait(); err != nil {
+			return seatmap.ConfigurationKey{}, err
+		}
+	}
+
+	airlineIataCode := ""
+	if _, ok := airlines[airlineRaw]; ok {
+		airlineIataCode = airlineRaw
+	} else {
+		for _, airline := range airlines {
+			if airline.IcaoCode.Valid && airline.IcaoCode.String == airlineRaw {
+				airlineIataCode = airline.IataCode
+				break
+			}
+		}
+	}
+
+	if airlineIataCode == "" {
+		return seatmap.ConfigurationKey{}, NewHTTPError(http.StatusBadRequest, WithMessage("Invalid airline"))
+	}
+
+	ac, ok := aircraft[aircraftRaw]
+	if !ok {
+		return seatmap.ConfigurationKey{}, NewHTTPError(http.StatusBadRequest, WithMessage("Invalid aircraft"))
+	}
+
+	if !slices.Contains(ac.Configurations[airlineIataCode], configurationRaw) {
+		return seatmap.ConfigurationKey{}, NewHTTPError(http.StatusNotFound, WithMessage("Unknown aircraft configuration"))
+	}
+
+	return seatmap.ConfigurationKey{
+		AirlineIataCode:              airlineIataCode,
+		AircraftIataCode:             ac.IataCode,
+		AircraftConfigurationVersion: configurationRaw,
+	}, nil
+}
+
+func (dh *DataHandler) parseSeatMapVersion(ctx context.Context, key seatmap.ConfigurationKey, raw string) (time.Time, error) {
+	if raw != "latest" {
+		version, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, NewHTTPError(http.StatusBadRequest, WithMessage("Invalid version format"), WithCause(err))
+		}
+
+		return version, nil
+	}
+
+	version, ok, err := dh.smSearch.LatestVersion(ctx, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !ok {
+		return time.Time{}, NewHTTPError(http.StatusNotFound)
+	}
+
+	return version, nil
+}
+
 func (dh *DataHandler) parseAirport(ctx context.Context, raw string) (string, error) {
 	return util{}.parseAirport(ctx, raw, dh.repo.Airports)
 }
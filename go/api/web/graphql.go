@@ -0,0 +1,223 @@
+package web
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/api/web/model"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed graphql.graphqls
+var graphQLSchema string
+
+type graphQLHandlerRepo interface {
+	Airlines(ctx context.Context) (map[string]db.Airline, error)
+	Airports(ctx context.Context) (map[string]db.Airport, error)
+	Aircraft(ctx context.Context) (map[string]db.Aircraft, error)
+	FindFlightNumbers(ctx context.Context, query string, limit int) ([]db.FlightNumber, error)
+	RelatedFlightNumbersMany(ctx context.Context, fns []db.FlightNumber, version time.Time) (map[db.FlightNumber]common.Set[db.FlightNumber], error)
+	FlightSchedulesMany(ctx context.Context, fns []db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedulesMany, error)
+	FlightScheduleVersionsMany(ctx context.Context, keys []db.FlightInstanceKey) (db.FlightScheduleVersionsMany, error)
+	GlobalUpdatesReport(ctx context.Context) ([]db.UpdateReportItem, error)
+	UpdatesReportMany(ctx context.Context, fns []db.FlightNumber, version time.Time) (map[db.FlightNumber][]db.UpdateReportItem, error)
+	Destinations(ctx context.Context, departureAirportIataCode string, asOf *time.Time) ([]string, error)
+}
+
+// graphQLMaxParallelism limits the resolvers running concurrently. Since resolvers waiting for a loader occupy one of these slots,
+// a batch can not grow beyond it and is dispatched as soon as it reaches that size instead of waiting for the loader window to end.
+const graphQLMaxParallelism = 100
+
+type GraphQLHandler struct {
+	repo           graphQLHandlerRepo
+	schema         *graphql.Schema
+	loaderWait     time.Duration
+	loaderMaxBatch int
+}
+
+func NewGraphQLHandler(repo graphQLHandlerRepo) *GraphQLHandler {
+	return &GraphQLHandler{
+		repo: repo,
+		schema: graphql.MustParseSchema(
+			graphQLSchema,
+			&graphQLQueryResolver{repo: repo},
+			graphql.MaxDepth(10),
+			graphql.MaxParallelism(graphQLMaxParallelism),
+			graphql.MaxQueryLength(1<<14),
+		),
+		loaderWait:     time.Millisecond * 2,
+		loaderMaxBatch: graphQLMaxParallelism,
+	}
+}
+
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	var req model.GraphQLRequest
+	if c.Request().Method == http.MethodGet {
+		q := c.QueryParams()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return NewHTTPError(http.StatusBadRequest, WithMessage("Invalid variables"), WithCause(err))
+			}
+		}
+	} else if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		return NewHTTPError(http.StatusBadRequest, WithMessage("Invalid request body"), WithCause(err))
+	}
+
+	ctx := withGraphQLLoaders(c.Request().Context(), h.repo, h.loaderWait, h.loaderMaxBatch)
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	noCache(c)
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+type graphQLLoadersContextKey struct{}
+
+type graphQLScheduleKey struct {
+	fn      db.FlightNumber
+	version time.Time
+	year    int
+}
+
+type graphQLSchedule struct {
+	items    []db.FlightScheduleItem
+	variants map[uuid.UUID]db.FlightScheduleVariant
+}
+
+type graphQLVersions struct {
+	versions []db.FlightScheduleVersion
+	variants map[uuid.UUID]db.FlightScheduleVariant
+}
+
+type graphQLFlightNumberVersionKey struct {
+	fn      db.FlightNumber
+	version time.Time
+}
+
+// graphQLLoaders batches the per flight number loads of a single request into one query per kind
+type graphQLLoaders struct {
+	schedules *loader[graphQLScheduleKey, graphQLSchedule]
+	versions  *loader[db.FlightInstanceKey, graphQLVersions]
+	updates   *loader[graphQLFlightNumberVersionKey, []db.UpdateReportItem]
+	related   *loader[graphQLFlightNumberVersionKey, common.Set[db.FlightNumber]]
+}
+
+func withGraphQLLoaders(ctx context.Context, repo graphQLHandlerRepo, wait time.Duration, maxBatch int) context.Context {
+	loaders := &graphQLLoaders{
+		schedules: newLoader(ctx, wait, maxBatch, func(ctx context.Context, keys []graphQLScheduleKey) (map[graphQLScheduleKey]graphQLSchedule, error) {
+			type group struct {
+				version time.Time
+				year    int
+			}
+
+			fnsByGroup := make(map[group][]db.FlightNumber)
+			for _, key := range keys {
+				g := group{key.version, key.year}
+				fnsByGroup[g] = append(fnsByGroup[g], key.fn)
+			}
+
+			result := make(map[graphQLScheduleKey]graphQLSchedule, len(keys))
+			for g, fns := range fnsByGroup {
+				departureDateRangeLocal := xtime.LocalDateRange{
+					xtime.NewLocalDateFromParts(g.year, time.January, 1),
+					xtime.NewLocalDateFromParts(g.year+1, time.January, 1),
+				}
+
+				schedules, err := repo.FlightSchedulesMany(ctx, fns, g.version, &departureDateRangeLocal)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, fn := range fns {
+					result[graphQLScheduleKey{fn, g.version, g.year}] = graphQLSchedule{
+						items:    schedules.Schedules[fn],
+						variants: schedules.Variants,
+					}
+				}
+			}
+
+			return result, nil
+		}),
+		versions: newLoader(ctx, wait, maxBatch, func(ctx context.Context, keys []db.FlightInstanceKey) (map[db.FlightInstanceKey]graphQLVersions, error) {
+			versions, err := repo.FlightScheduleVersionsMany(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[db.FlightInstanceKey]graphQLVersions, len(keys))
+			for _, key := range keys {
+				result[key] = graphQLVersions{
+					versions: versions.Versions[key],
+					variants: versions.Variants,
+				}
+			}
+
+			return result, nil
+		}),
+		updates: newLoader(ctx, wait, maxBatch, func(ctx context.Context, keys []graphQLFlightNumberVersionKey) (map[graphQLFlightNumberVersionKey][]db.UpdateReportItem, error) {
+			fnsByVersion := make(map[time.Time][]db.FlightNumber)
+			for _, key := range keys {
+				fnsByVersion[key.version] = append(fnsByVersion[key.version], key.fn)
+			}
+
+			result := make(map[graphQLFlightNumberVersionKey][]db.UpdateReportItem, len(keys))
+			for version, fns := range fnsByVersion {
+				updates, err := repo.UpdatesReportMany(ctx, fns, version)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, fn := range fns {
+					result[graphQLFlightNumberVersionKey{fn, version}] = updates[fn]
+				}
+			}
+
+			return result, nil
+		}),
+		related: newLoader(ctx, wait, maxBatch, func(ctx context.Context, keys []graphQLFlightNumberVersionKey) (map[graphQLFlightNumberVersionKey]common.Set[db.FlightNumber], error) {
+			fnsByVersion := make(map[time.Time][]db.FlightNumber)
+			for _, key := range keys {
+				fnsByVersion[key.version] = append(fnsByVersion[key.version], key.fn)
+			}
+
+			result := make(map[graphQLFlightNumberVersionKey]common.Set[db.FlightNumber], len(keys))
+			for version, fns := range fnsByVersion {
+				related, err := repo.RelatedFlightNumbersMany(ctx, fns, version)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, fn := range fns {
+					result[graphQLFlightNumberVersionKey{fn, version}] = related[fn]
+				}
+			}
+
+			return result, nil
+		}),
+	}
+
+	return context.WithValue(ctx, graphQLLoadersContextKey{}, loaders)
+}
+
+func graphQLContextLoaders(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersContextKey{}).(*graphQLLoaders)
+}
+
+func graphQLVersion(v *graphql.Time) time.Time {
+	if v == nil {
+		return time.Date(2999, time.December, 31, 23, 59, 59, 0, time.UTC)
+	}
+
+	return v.Time.UTC()
+}
@@ -0,0 +1,103 @@
+package web
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type loaderResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys       []K
+	results    map[K]*loaderResult[V]
+	dispatched bool
+}
+
+// loader collects keys requested within a short window and resolves them with a single fetch.
+// A batch is dispatched once it reaches maxBatch keys, otherwise when the window ends.
+// Results are cached for the lifetime of the loader, which is a single request.
+type loader[K comparable, V any] struct {
+	ctx      context.Context
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	wait     time.Duration
+	maxBatch int
+	mtx      sync.Mutex
+	cache    map[K]*loaderResult[V]
+	batch    *loaderBatch[K, V]
+}
+
+func newLoader[K comparable, V any](ctx context.Context, wait time.Duration, maxBatch int, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:      ctx,
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*loaderResult[V]),
+	}
+}
+
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	r := l.enqueue(key)
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var v V
+		return v, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) enqueue(key K) *loaderResult[V] {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if r, ok := l.cache[key]; ok {
+		return r
+	}
+
+	r := &loaderResult[V]{done: make(chan struct{})}
+	l.cache[key] = r
+
+	if l.batch == nil {
+		b := &loaderBatch[K, V]{results: make(map[K]*loaderResult[V])}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results[key] = r
+
+	if len(l.batch.keys) >= l.maxBatch {
+		b := l.batch
+		l.batch = nil
+		go l.dispatch(b)
+	}
+
+	return r
+}
+
+func (l *loader[K, V]) dispatch(b *loaderBatch[K, V]) {
+	l.mtx.Lock()
+	if b.dispatched {
+		l.mtx.Unlock()
+		return
+	}
+
+	b.dispatched = true
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mtx.Unlock()
+
+	values, err := l.fetch(l.ctx, b.keys)
+	for key, r := range b.results {
+		r.value, r.err = values[key], err
+		close(r.done)
+	}
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type loaderTestFetcher struct {
+	mtx     sync.Mutex
+	batches [][]int
+}
+
+func (f *loaderTestFetcher) fetch(ctx context.Context, keys []int) (map[int]int, error) {
+	f.mtx.Lock()
+	f.batches = append(f.batches, keys)
+	f.mtx.Unlock()
+
+	result := make(map[int]int, len(keys))
+	for _, key := range keys {
+		result[key] = key * 2
+	}
+
+	return result, nil
+}
+
+// flush dispatches the pending batch without waiting for the window to end
+func (l *loader[K, V]) flush() {
+	l.mtx.Lock()
+	b := l.batch
+	l.mtx.Unlock()
+
+	if b != nil {
+		l.dispatch(b)
+	}
+}
+
+func TestLoaderFlush(t *testing.T) {
+	f := &loaderTestFetcher{}
+	l := newLoader(context.Background(), time.Hour, 100, f.fetch)
+
+	results := make([]*loaderResult[int], 0, 25)
+	for key := range 25 {
+		results = append(results, l.enqueue(key))
+	}
+
+	// already requested keys are served from the pending batch
+	assert.Same(t, results[3], l.enqueue(3))
+
+	l.flush()
+
+	require.Len(t, f.batches, 1)
+	assert.Len(t, f.batches[0], 25)
+
+	for key, r := range results {
+		<-r.done
+		assert.NoError(t, r.err)
+		assert.Equal(t, key*2, r.value)
+	}
+
+	v, err := l.Load(context.Background(), 24)
+	require.NoError(t, err)
+	assert.Equal(t, 48, v)
+	assert.Len(t, f.batches, 1)
+}
+
+func TestLoaderDispatchesFullBatches(t *testing.T) {
+	f := &loaderTestFetcher{}
+	l := newLoader(context.Background(), time.Hour, 10, f.fetch)
+
+	var wg sync.WaitGroup
+	for key := range 25 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.enqueue(key)
+		}()
+	}
+
+	wg.Wait()
+	l.flush()
+
+	for key := range 25 {
+		v, err := l.Load(context.Background(), key)
+		require.NoError(t, err)
+		assert.Equal(t, key*2, v)
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	sizes := make([]int, 0, len(f.batches))
+	for _, batch := range f.batches {
+		sizes = append(sizes, len(batch))
+	}
+
+	assert.ElementsMatch(t, []int{10, 10, 5}, sizes)
+}
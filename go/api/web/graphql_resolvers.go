@@ -0,0 +1,701 @@
+package web
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/data"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type graphQLQueryResolver struct {
+	repo graphQLHandlerRepo
+}
+
+func (r *graphQLQueryResolver) Airlines(ctx context.Context) ([]*graphQLAirlineResolver, error) {
+	airlines, err := r.repo.Airlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLAirlineResolver, 0, len(airlines))
+	for _, airlineIataCode := range slices.Sorted(maps.Keys(airlines)) {
+		result = append(result, &graphQLAirlineResolver{airlines[airlineIataCode]})
+	}
+
+	return result, nil
+}
+
+func (r *graphQLQueryResolver) Airline(ctx context.Context, args struct{ Id string }) (*graphQLAirlineResolver, error) {
+	airlines, err := r.repo.Airlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.ToUpper(args.Id)
+	if airline, ok := airlines[id]; ok {
+		return &graphQLAirlineResolver{airline}, nil
+	}
+
+	for _, airline := range airlines {
+		if airline.IcaoCode.Valid && airline.IcaoCode.String == id {
+			return &graphQLAirlineResolver{airline}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *graphQLQueryResolver) Airports(ctx context.Context) ([]*graphQLAirportResolver, error) {
+	airports, err := r.repo.Airports(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLAirportResolver, 0, len(airports))
+	for _, airportIataCode := range slices.Sorted(maps.Keys(airports)) {
+		result = append(result, &graphQLAirportResolver{airports[airportIataCode]})
+	}
+
+	return result, nil
+}
+
+func (r *graphQLQueryResolver) Airport(ctx context.Context, args struct{ Id string }) (*graphQLAirportResolver, error) {
+	airportIataCode, err := util{}.parseAirport(ctx, strings.ToUpper(args.Id), r.repo.Airports)
+	if err != nil {
+		return nil, nil
+	}
+
+	return graphQLAirport(ctx, r.repo, airportIataCode)
+}
+
+func (r *graphQLQueryResolver) Aircraft(ctx context.Context) ([]*graphQLAircraftResolver, error) {
+	aircraft, err := r.repo.Aircraft(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLAircraftResolver, 0, len(aircraft))
+	for _, aircraftIataCode := range slices.Sorted(maps.Keys(aircraft)) {
+		result = append(result, &graphQLAircraftResolver{r.repo, aircraft[aircraftIataCode]})
+	}
+
+	return result, nil
+}
+
+func (r *graphQLQueryResolver) FlightNumber(ctx context.Context, args struct{ Id string }) (*graphQLFlightNumberResolver, error) {
+	airlines, err := r.repo.Airlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := parseFlightNumber(airlines, strings.ToUpper(args.Id))
+	if err != nil {
+		return nil, nil
+	}
+
+	return &graphQLFlightNumberResolver{r.repo, fn}, nil
+}
+
+func (r *graphQLQueryResolver) FlightNumbers(ctx context.Context, args struct {
+	Query string
+	Limit int32
+}) ([]*graphQLFlightNumberResolver, error) {
+	fns, err := r.repo.FindFlightNumbers(ctx, strings.TrimSpace(args.Query), min(max(int(args.Limit), 1), 100))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLFlightNumberResolver, 0, len(fns))
+	for _, fn := range fns {
+		result = append(result, &graphQLFlightNumberResolver{r.repo, fn})
+	}
+
+	return result, nil
+}
+
+func (r *graphQLQueryResolver) Destinations(ctx context.Context, args struct {
+	DepartureAirportId string
+	AsOf               *graphql.Time
+}) ([]*graphQLAirportResolver, error) {
+	departureAirportIataCode, err := util{}.parseAirport(ctx, strings.ToUpper(args.DepartureAirportId), r.repo.Airports)
+	if err != nil {
+		return nil, fmt.Errorf("invalid departureAirportId %q: %w", args.DepartureAirportId, err)
+	}
+
+	var asOf *time.Time
+	if args.AsOf != nil {
+		t := args.AsOf.Time.UTC()
+		asOf = &t
+	}
+
+	destinationAirportIataCodes, err := r.repo.Destinations(ctx, departureAirportIataCode, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	airports, err := r.repo.Airports(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLAirportResolver, 0, len(destinationAirportIataCodes))
+	for _, destinationAirportIataCode := range destinationAirportIataCodes {
+		if airport, ok := airports[destinationAirportIataCode]; ok {
+			result = append(result, &graphQLAirportResolver{airport})
+		}
+	}
+
+	return result, nil
+}
+
+func (r *graphQLQueryResolver) Updates(ctx context.Context) ([]*graphQLUpdateReportItemResolver, error) {
+	reportItems, err := r.repo.GlobalUpdatesReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphQLUpdateReportItems(reportItems), nil
+}
+
+type graphQLAirlineResolver struct {
+	airline db.Airline
+}
+
+func (r *graphQLAirlineResolver) Id() string {
+	return r.airline.IataCode
+}
+
+func (r *graphQLAirlineResolver) IataCode() string {
+	return r.airline.IataCode
+}
+
+func (r *graphQLAirlineResolver) IcaoCode() *string {
+	return graphQLNullString(r.airline.IcaoCode.String)
+}
+
+func (r *graphQLAirlineResolver) Name() string {
+	return r.airline.Name
+}
+
+type graphQLGeoLocationResolver struct {
+	lng, lat float64
+}
+
+func (r *graphQLGeoLocationResolver) Lng() float64 {
+	return r.lng
+}
+
+func (r *graphQLGeoLocationResolver) Lat() float64 {
+	return r.lat
+}
+
+type graphQLAirportResolver struct {
+	airport db.Airport
+}
+
+func (r *graphQLAirportResolver) Id() string {
+	return r.airport.IataCode
+}
+
+func (r *graphQLAirportResolver) IataCode() string {
+	return r.airport.IataCode
+}
+
+func (r *graphQLAirportResolver) IcaoCode() *string {
+	return graphQLNullString(r.airport.IcaoCode.String)
+}
+
+func (r *graphQLAirportResolver) IataAreaCode() *string {
+	return graphQLNullString(r.airport.IataAreaCode.String)
+}
+
+func (r *graphQLAirportResolver) CountryCode() string {
+	return r.airport.CountryCode
+}
+
+func (r *graphQLAirportResolver) CityCode() string {
+	return r.airport.CityCode
+}
+
+func (r *graphQLAirportResolver) Type() string {
+	return r.airport.Type
+}
+
+func (r *graphQLAirportResolver) Location() *graphQLGeoLocationResolver {
+	return &graphQLGeoLocationResolver{r.airport.Lng, r.airport.Lat}
+}
+
+func (r *graphQLAirportResolver) Timezone() string {
+	return r.airport.Timezone
+}
+
+func (r *graphQLAirportResolver) Name() string {
+	return r.airport.Name
+}
+
+type graphQLAircraftResolver struct {
+	repo     graphQLHandlerRepo
+	aircraft db.Aircraft
+}
+
+func (r *graphQLAircraftResolver) Id() string {
+	return r.aircraft.IataCode
+}
+
+func (r *graphQLAircraftResolver) Type() string {
+	if r.aircraft.IsFamily {
+		return "family"
+	}
+
+	return "aircraft"
+}
+
+func (r *graphQLAircraftResolver) IataCode() string {
+	return r.aircraft.IataCode
+}
+
+func (r *graphQLAircraftResolver) IcaoCode() *string {
+	return graphQLNullString(r.aircraft.IcaoCode.String)
+}
+
+func (r *graphQLAircraftResolver) Name() string {
+	return r.aircraft.Name
+}
+
+func (r *graphQLAircraftResolver) Parent(ctx context.Context) (*graphQLAircraftResolver, error) {
+	if !r.aircraft.ParentIataCode.Valid {
+		return nil, nil
+	}
+
+	return graphQLAircraft(ctx, r.repo, r.aircraft.ParentIataCode.String)
+}
+
+func (r *graphQLAircraftResolver) Configurations(args struct{ AirlineId *string }) []*graphQLAircraftConfigurationResolver {
+	result := make([]*graphQLAircraftConfigurationResolver, 0)
+	for _, airlineIataCode := range slices.Sorted(maps.Keys(r.aircraft.Configurations)) {
+		if args.AirlineId != nil && !strings.EqualFold(*args.AirlineId, airlineIataCode) {
+			continue
+		}
+
+		for _, version := range r.aircraft.Configurations[airlineIataCode] {
+			result = append(result, &graphQLAircraftConfigurationResolver{
+				repo:             r.repo,
+				airlineIataCode:  airlineIataCode,
+				aircraftIataCode: r.aircraft.IataCode,
+				version:          version,
+			})
+		}
+	}
+
+	return result
+}
+
+type graphQLAircraftConfigurationResolver struct {
+	repo             graphQLHandlerRepo
+	airlineIataCode  string
+	aircraftIataCode string
+	version          string
+}
+
+func (r *graphQLAircraftConfigurationResolver) Airline(ctx context.Context) (*graphQLAirlineResolver, error) {
+	return graphQLAirline(ctx, r.repo, r.airlineIataCode)
+}
+
+func (r *graphQLAircraftConfigurationResolver) Version() string {
+	return r.version
+}
+
+func (r *graphQLAircraftConfigurationResolver) Name() *string {
+	if names, ok := data.AircraftConfigurationName(r.airlineIataCode, r.aircraftIataCode, r.version); ok {
+		return graphQLNullString(names.Name)
+	}
+
+	return nil
+}
+
+func (r *graphQLAircraftConfigurationResolver) ShortName() *string {
+	if names, ok := data.AircraftConfigurationName(r.airlineIataCode, r.aircraftIataCode, r.version); ok {
+		return graphQLNullString(names.ShortName)
+	}
+
+	return nil
+}
+
+type graphQLFlightNumberResolver struct {
+	repo graphQLHandlerRepo
+	fn   db.FlightNumber
+}
+
+func (r *graphQLFlightNumberResolver) Id() string {
+	return r.fn.String()
+}
+
+func (r *graphQLFlightNumberResolver) Airline(ctx context.Context) (*graphQLAirlineResolver, error) {
+	return graphQLAirline(ctx, r.repo, r.fn.AirlineIataCode)
+}
+
+func (r *graphQLFlightNumberResolver) Number() int32 {
+	return int32(r.fn.Number)
+}
+
+func (r *graphQLFlightNumberResolver) Suffix() string {
+	return r.fn.Suffix
+}
+
+func (r *graphQLFlightNumberResolver) Schedule(ctx context.Context, args struct {
+	Year    int32
+	Version *graphql.Time
+}) ([]*graphQLFlightScheduleItemResolver, error) {
+	if args.Year < 1 || args.Year > 9999 {
+		return nil, fmt.Errorf("invalid year %d", args.Year)
+	}
+
+	schedule, err := graphQLContextLoaders(ctx).schedules.Load(ctx, graphQLScheduleKey{
+		fn:      r.fn,
+		version: graphQLVersion(args.Version),
+		year:    int(args.Year),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLFlightScheduleItemResolver, 0, len(schedule.items))
+	for _, item := range schedule.items {
+		result = append(result, &graphQLFlightScheduleItemResolver{r.repo, item, schedule.variants})
+	}
+
+	return result, nil
+}
+
+func (r *graphQLFlightNumberResolver) Versions(ctx context.Context, args struct {
+	DepartureAirportId string
+	DepartureDateLocal string
+}) ([]*graphQLFlightScheduleVersionResolver, error) {
+	departureAirportIataCode, err := util{}.parseAirport(ctx, strings.ToUpper(args.DepartureAirportId), r.repo.Airports)
+	if err != nil {
+		return nil, fmt.Errorf("invalid departureAirportId %q: %w", args.DepartureAirportId, err)
+	}
+
+	departureDateLocal, err := xtime.ParseLocalDate(args.DepartureDateLocal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid departureDateLocal %q: %w", args.DepartureDateLocal, err)
+	}
+
+	versions, err := graphQLContextLoaders(ctx).versions.Load(ctx, db.FlightInstanceKey{
+		FlightNumber:             r.fn,
+		DepartureAirportIataCode: departureAirportIataCode,
+		DepartureDateLocal:       departureDateLocal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphQLFlightScheduleVersionResolver, 0, len(versions.versions))
+	for _, version := range versions.versions {
+		var variant *graphQLFlightVariantResolver
+		if version.FlightVariantId.Valid {
+			variant = graphQLFlightVariant(r.repo, versions.variants, version.FlightVariantId.V)
+		}
+
+		result = append(result, &graphQLFlightScheduleVersionResolver{
+			version: graphql.Time{Time: version.Version},
+			variant: variant,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *graphQLFlightNumberResolver) Updates(ctx context.Context, args struct{ Version *graphql.Time }) ([]*graphQLUpdateReportItemResolver, error) {
+	reportItems, err := graphQLContextLoaders(ctx).updates.Load(ctx, graphQLFlightNumberVersionKey{
+		fn:      r.fn,
+		version: graphQLVersion(args.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return graphQLUpdateReportItems(reportItems), nil
+}
+
+func (r *graphQLFlightNumberResolver) Related(ctx context.Context, args struct{ Version *graphql.Time }) ([]*graphQLFlightNumberResolver, error) {
+	related, err := graphQLContextLoaders(ctx).related.Load(ctx, graphQLFlightNumberVersionKey{
+		fn:      r.fn,
+		version: graphQLVersion(args.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fns := slices.SortedFunc(maps.Keys(related), func(a, b db.FlightNumber) int {
+		return cmp.Or(
+			cmp.Compare(a.AirlineIataCode, b.AirlineIataCode),
+			cmp.Compare(a.Number, b.Number),
+			cmp.Compare(a.Suffix, b.Suffix),
+		)
+	})
+
+	result := make([]*graphQLFlightNumberResolver, 0, len(fns))
+	for _, fn := range fns {
+		result = append(result, &graphQLFlightNumberResolver{r.repo, fn})
+	}
+
+	return result, nil
+}
+
+type graphQLFlightScheduleItemResolver struct {
+	repo     graphQLHandlerRepo
+	item     db.FlightScheduleItem
+	variants map[uuid.UUID]db.FlightScheduleVariant
+}
+
+func (r *graphQLFlightScheduleItemResolver) DepartureDateLocal() string {
+	return r.item.DepartureDateLocal.String()
+}
+
+func (r *graphQLFlightScheduleItemResolver) DepartureAirport(ctx context.Context) (*graphQLAirportResolver, error) {
+	return graphQLAirport(ctx, r.repo, r.item.DepartureAirportIataCode)
+}
+
+func (r *graphQLFlightScheduleItemResolver) Version() graphql.Time {
+	return graphql.Time{Time: r.item.Version}
+}
+
+func (r *graphQLFlightScheduleItemResolver) VersionCount() int32 {
+	return int32(r.item.VersionCount)
+}
+
+func (r *graphQLFlightScheduleItemResolver) Variant() *graphQLFlightVariantResolver {
+	if !r.item.FlightVariantId.Valid {
+		return nil
+	}
+
+	return graphQLFlightVariant(r.repo, r.variants, r.item.FlightVariantId.V)
+}
+
+func (r *graphQLFlightScheduleItemResolver) PreviousVariant() *graphQLFlightVariantResolver {
+	if !r.item.PreviousFlightVariantId.Valid {
+		return nil
+	}
+
+	return graphQLFlightVariant(r.repo, r.variants, r.item.PreviousFlightVariantId.V)
+}
+
+type graphQLFlightScheduleVersionResolver struct {
+	version graphql.Time
+	variant *graphQLFlightVariantResolver
+}
+
+func (r *graphQLFlightScheduleVersionResolver) Version() graphql.Time {
+	return r.version
+}
+
+func (r *graphQLFlightScheduleVersionResolver) Variant() *graphQLFlightVariantResolver {
+	return r.variant
+}
+
+type graphQLFlightVariantResolver struct {
+	repo    graphQLHandlerRepo
+	variant db.FlightScheduleVariant
+}
+
+func (r *graphQLFlightVariantResolver) Id() graphql.ID {
+	return graphql.ID(r.variant.Id.String())
+}
+
+func (r *graphQLFlightVariantResolver) OperatedAs() *graphQLFlightNumberResolver {
+	return &graphQLFlightNumberResolver{r.repo, r.variant.OperatedAs}
+}
+
+func (r *graphQLFlightVariantResolver) DepartureTimeLocal() string {
+	return r.variant.DepartureTimeLocal.String()
+}
+
+func (r *graphQLFlightVariantResolver) DepartureUtcOffsetSeconds() int32 {
+	return int32(r.variant.DepartureUtcOffsetSeconds)
+}
+
+func (r *graphQLFlightVariantResolver) DurationSeconds() int32 {
+	return int32(r.variant.DurationSeconds)
+}
+
+func (r *graphQLFlightVariantResolver) ArrivalAirport(ctx context.Context) (*graphQLAirportResolver, error) {
+	return graphQLAirport(ctx, r.repo, r.variant.ArrivalAirportIataCode)
+}
+
+func (r *graphQLFlightVariantResolver) ArrivalUtcOffsetSeconds() int32 {
+	return int32(r.variant.ArrivalUtcOffsetSeconds)
+}
+
+func (r *graphQLFlightVariantResolver) ServiceType() string {
+	return r.variant.ServiceType
+}
+
+func (r *graphQLFlightVariantResolver) AircraftOwner() string {
+	return r.variant.AircraftOwner
+}
+
+func (r *graphQLFlightVariantResolver) Aircraft(ctx context.Context) (*graphQLAircraftResolver, error) {
+	return graphQLAircraft(ctx, r.repo, r.variant.AircraftIataCode)
+}
+
+func (r *graphQLFlightVariantResolver) AircraftConfigurationVersion() string {
+	return r.variant.AircraftConfigurationVersion
+}
+
+func (r *graphQLFlightVariantResolver) AircraftConfigurationName() *string {
+	names, ok := data.AircraftConfigurationName(r.variant.AircraftOwner, r.variant.AircraftIataCode, r.variant.AircraftConfigurationVersion)
+	if !ok {
+		return nil
+	}
+
+	return graphQLNullString(names.Name)
+}
+
+func (r *graphQLFlightVariantResolver) SeatsFirst() int32 {
+	return int32(r.variant.SeatsFirst)
+}
+
+func (r *graphQLFlightVariantResolver) SeatsBusiness() int32 {
+	return int32(r.variant.SeatsBusiness)
+}
+
+func (r *graphQLFlightVariantResolver) SeatsPremium() int32 {
+	return int32(r.variant.SeatsPremium)
+}
+
+func (r *graphQLFlightVariantResolver) SeatsEconomy() int32 {
+	return int32(r.variant.SeatsEconomy)
+}
+
+func (r *graphQLFlightVariantResolver) CodeShares() []*graphQLFlightNumberResolver {
+	result := make([]*graphQLFlightNumberResolver, 0, len(r.variant.CodeShares))
+	for cs := range r.variant.CodeShares {
+		result = append(result, &graphQLFlightNumberResolver{r.repo, cs})
+	}
+
+	slices.SortFunc(result, func(a, b *graphQLFlightNumberResolver) int {
+		return cmp.Compare(a.fn.String(), b.fn.String())
+	})
+
+	return result
+}
+
+func (r *graphQLFlightVariantResolver) DataElements() []*graphQLDataElementResolver {
+	result := make([]*graphQLDataElementResolver, 0, len(r.variant.DataElements))
+	for _, id := range slices.Sorted(maps.Keys(r.variant.DataElements)) {
+		result = append(result, &graphQLDataElementResolver{id, r.variant.DataElements[id]})
+	}
+
+	return result
+}
+
+type graphQLDataElementResolver struct {
+	id    int64
+	value string
+}
+
+func (r *graphQLDataElementResolver) Id() int32 {
+	return int32(r.id)
+}
+
+func (r *graphQLDataElementResolver) Value() string {
+	return r.value
+}
+
+type graphQLUpdateReportItemResolver struct {
+	item db.UpdateReportItem
+}
+
+func (r *graphQLUpdateReportItemResolver) Version() graphql.Time {
+	return graphql.Time{Time: r.item.Version}
+}
+
+func (r *graphQLUpdateReportItemResolver) Added() int32 {
+	return int32(r.item.Added)
+}
+
+func (r *graphQLUpdateReportItemResolver) Updated() int32 {
+	return int32(r.item.Updated)
+}
+
+func (r *graphQLUpdateReportItemResolver) Removed() int32 {
+	return int32(r.item.Removed)
+}
+
+func graphQLUpdateReportItems(reportItems []db.UpdateReportItem) []*graphQLUpdateReportItemResolver {
+	result := make([]*graphQLUpdateReportItemResolver, 0, len(reportItems))
+	for _, item := range reportItems {
+		result = append(result, &graphQLUpdateReportItemResolver{item})
+	}
+
+	slices.SortFunc(result, func(a, b *graphQLUpdateReportItemResolver) int {
+		return a.item.Version.Compare(b.item.Version)
+	})
+
+	return result
+}
+
+func graphQLAirline(ctx context.Context, repo graphQLHandlerRepo, airlineIataCode string) (*graphQLAirlineResolver, error) {
+	airlines, err := repo.Airlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if airline, ok := airlines[airlineIataCode]; ok {
+		return &graphQLAirlineResolver{airline}, nil
+	}
+
+	return nil, nil
+}
+
+func graphQLAirport(ctx context.Context, repo graphQLHandlerRepo, airportIataCode string) (*graphQLAirportResolver, error) {
+	airports, err := repo.Airports(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if airport, ok := airports[airportIataCode]; ok {
+		return &graphQLAirportResolver{airport}, nil
+	}
+
+	return nil, nil
+}
+
+func graphQLAircraft(ctx context.Context, repo graphQLHandlerRepo, aircraftIataCode string) (*graphQLAircraftResolver, error) {
+	aircraft, err := repo.Aircraft(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ac, ok := aircraft[aircraftIataCode]; ok {
+		return &graphQLAircraftResolver{repo, ac}, nil
+	}
+
+	return nil, nil
+}
+
+func graphQLFlightVariant(repo graphQLHandlerRepo, variants map[uuid.UUID]db.FlightScheduleVariant, id uuid.UUID) *graphQLFlightVariantResolver {
+	if variant, ok := variants[id]; ok {
+		return &graphQLFlightVariantResolver{repo, variant}
+	}
+
+	return nil
+}
+
+func graphQLNullString(v string) *string {
+	if v == "" {
+		return nil
+	}
+
+	return &v
+}
@@ -0,0 +1,215 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLTestRepo struct {
+	flightNumbers int
+	mtx           sync.Mutex
+	schedulesMany [][]db.FlightNumber
+	updatesMany   [][]db.FlightNumber
+	relatedMany   [][]db.FlightNumber
+}
+
+func (r *graphQLTestRepo) Airlines(ctx context.Context) (map[string]db.Airline, error) {
+	return map[string]db.Airline{
+		"LH": {IataCode: "LH", IcaoCode: sql.NullString{String: "DLH", Valid: true}, Name: "Lufthansa"},
+	}, nil
+}
+
+func (r *graphQLTestRepo) Airports(ctx context.Context) (map[string]db.Airport, error) {
+	return map[string]db.Airport{
+		"FRA": {IataCode: "FRA", Name: "Frankfurt"},
+		"JFK": {IataCode: "JFK", Name: "New York JFK"},
+	}, nil
+}
+
+func (r *graphQLTestRepo) Aircraft(ctx context.Context) (map[string]db.Aircraft, error) {
+	return map[string]db.Aircraft{
+		"359": {IataCode: "359", Name: "Airbus A350-900"},
+	}, nil
+}
+
+func (r *graphQLTestRepo) FindFlightNumbers(ctx context.Context, query string, limit int) ([]db.FlightNumber, error) {
+	fns := make([]db.FlightNumber, 0, r.flightNumbers)
+	for i := range r.flightNumbers {
+		fns = append(fns, db.FlightNumber{AirlineIataCode: "LH", Number: 400 + i})
+	}
+
+	return fns, nil
+}
+
+func (r *graphQLTestRepo) RelatedFlightNumbersMany(ctx context.Context, fns []db.FlightNumber, version time.Time) (map[db.FlightNumber]common.Set[db.FlightNumber], error) {
+	r.mtx.Lock()
+	r.relatedMany = append(r.relatedMany, fns)
+	r.mtx.Unlock()
+
+	result := make(map[db.FlightNumber]common.Set[db.FlightNumber])
+	for _, fn := range fns {
+		result[fn] = common.Set[db.FlightNumber]{{AirlineIataCode: fn.AirlineIataCode, Number: fn.Number, Suffix: "A"}: {}}
+	}
+
+	return result, nil
+}
+
+func (r *graphQLTestRepo) FlightSchedulesMany(ctx context.Context, fns []db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedulesMany, error) {
+	r.mtx.Lock()
+	r.schedulesMany = append(r.schedulesMany, fns)
+	r.mtx.Unlock()
+
+	variantId := uuid.Must(uuid.NewV4())
+	result := db.FlightSchedulesMany{
+		Schedules: make(map[db.FlightNumber][]db.FlightScheduleItem),
+		Variants: map[uuid.UUID]db.FlightScheduleVariant{
+			variantId: {
+				Id:                     variantId,
+				ArrivalAirportIataCode: "JFK",
+				AircraftIataCode:       "359",
+			},
+		},
+	}
+
+	for _, fn := range fns {
+		result.Schedules[fn] = []db.FlightScheduleItem{{
+			DepartureDateLocal:       xtime.NewLocalDateFromParts(2026, time.January, 1),
+			DepartureAirportIataCode: "FRA",
+			FlightVariantId:          sql.Null[uuid.UUID]{V: variantId, Valid: true},
+			Version:                  version,
+			VersionCount:             1,
+		}}
+	}
+
+	return result, nil
+}
+
+func (r *graphQLTestRepo) FlightScheduleVersionsMany(ctx context.Context, keys []db.FlightInstanceKey) (db.FlightScheduleVersionsMany, error) {
+	return db.FlightScheduleVersionsMany{}, nil
+}
+
+func (r *graphQLTestRepo) GlobalUpdatesReport(ctx context.Context) ([]db.UpdateReportItem, error) {
+	return nil, nil
+}
+
+func (r *graphQLTestRepo) UpdatesReportMany(ctx context.Context, fns []db.FlightNumber, version time.Time) (map[db.FlightNumber][]db.UpdateReportItem, error) {
+	r.mtx.Lock()
+	r.updatesMany = append(r.updatesMany, fns)
+	r.mtx.Unlock()
+
+	result := make(map[db.FlightNumber][]db.UpdateReportItem)
+	for _, fn := range fns {
+		result[fn] = []db.UpdateReportItem{{Version: version, Added: fn.Number}}
+	}
+
+	return result, nil
+}
+
+func (r *graphQLTestRepo) Destinations(ctx context.Context, departureAirportIataCode string, asOf *time.Time) ([]string, error) {
+	return []string{"JFK"}, nil
+}
+
+// newGraphQLBatchTestHandler returns a handler whose loaders only dispatch full batches of the given size, so the batches do not depend on timing
+func newGraphQLBatchTestHandler(repo *graphQLTestRepo, batchSize int) *GraphQLHandler {
+	h := NewGraphQLHandler(repo)
+	h.loaderWait = time.Hour
+	h.loaderMaxBatch = batchSize
+
+	return h
+}
+
+func execGraphQLTestQuery(t *testing.T, h *GraphQLHandler, body string) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/api/graphql", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Query(echo.New().NewContext(req, rec)))
+
+	return rec
+}
+
+func TestGraphQLBatchesFlightNumberLoads(t *testing.T) {
+	repo := &graphQLTestRepo{flightNumbers: 3}
+	h := newGraphQLBatchTestHandler(repo, 3)
+
+	rec := execGraphQLTestQuery(t, h, `{"query":"{ flightNumbers(query: \"LH40\") { id airline { name } schedule(year: 2026) { departureAirport { id } variant { arrivalAirport { id } aircraft { name } } } updates { added } } }"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data struct {
+			FlightNumbers []struct {
+				Id      string
+				Airline struct{ Name string }
+				Updates []struct{ Added int }
+			}
+		}
+		Errors []any
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Empty(t, resp.Errors)
+	require.Len(t, resp.Data.FlightNumbers, 3)
+	assert.Equal(t, "LH400", resp.Data.FlightNumbers[0].Id)
+	assert.Equal(t, "Lufthansa", resp.Data.FlightNumbers[0].Airline.Name)
+	assert.Equal(t, 401, resp.Data.FlightNumbers[1].Updates[0].Added)
+
+	require.Len(t, repo.schedulesMany, 1)
+	assert.Len(t, repo.schedulesMany[0], 3)
+	require.Len(t, repo.updatesMany, 1)
+	assert.Len(t, repo.updatesMany[0], 3)
+	assert.Contains(t, rec.Body.String(), `"arrivalAirport":{"id":"JFK"}`)
+}
+
+func TestGraphQLBatchesLargeFanOut(t *testing.T) {
+	repo := &graphQLTestRepo{flightNumbers: 25}
+	h := newGraphQLBatchTestHandler(repo, 25)
+
+	rec := execGraphQLTestQuery(t, h, `{"query":"{ flightNumbers(query: \"LH4\") { id schedule(year: 2026) { departureDateLocal } updates { added } related { id } } }"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data struct {
+			FlightNumbers []struct {
+				Id      string
+				Related []struct{ Id string }
+			}
+		}
+		Errors []any
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Empty(t, resp.Errors)
+	require.Len(t, resp.Data.FlightNumbers, 25)
+	assert.Equal(t, "LH424", resp.Data.FlightNumbers[24].Id)
+	assert.Equal(t, []struct{ Id string }{{"LH424A"}}, resp.Data.FlightNumbers[24].Related)
+
+	for _, calls := range [][][]db.FlightNumber{repo.schedulesMany, repo.updatesMany, repo.relatedMany} {
+		require.Len(t, calls, 1)
+		assert.Len(t, calls[0], 25)
+	}
+}
+
+func TestGraphQLResolvesReferenceData(t *testing.T) {
+	h := NewGraphQLHandler(&graphQLTestRepo{flightNumbers: 3})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/graphql?query="+url.QueryEscape(`{airline(id:"DLH"){id}flightNumber(id:"XX1"){id}destinations(departureAirportId:"FRA"){name}}`), nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Query(echo.New().NewContext(req, rec)))
+
+	assert.JSONEq(t, `{"data":{"airline":{"id":"LH"},"flightNumber":null,"destinations":[{"name":"New York JFK"}]}}`, rec.Body.String())
+}
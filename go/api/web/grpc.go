@@ -0,0 +1,354 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/connections"
+	"github.com/explore-flights/monorepo/go/api/business/schedulesearch"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/api/pb"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServerRepo interface {
+	Airlines(ctx context.Context) (map[string]db.Airline, error)
+	Airports(ctx context.Context) (map[string]db.Airport, error)
+	FlightSchedules(ctx context.Context, fn db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedules, error)
+	FlightScheduleVersions(ctx context.Context, fn db.FlightNumber, departureAirportIataCode string, departureDate xtime.LocalDate) (db.FlightScheduleVersions, error)
+}
+
+type GrpcServer struct {
+	pb.UnimplementedExploreFlightsServer
+	repo           grpcServerRepo
+	connSearch     *connections.Search
+	scheduleSearch *schedulesearch.Search
+}
+
+func NewGrpcServer(repo grpcServerRepo, connSearch *connections.Search, scheduleSearch *schedulesearch.Search) *GrpcServer {
+	return &GrpcServer{
+		repo:           repo,
+		connSearch:     connSearch,
+		scheduleSearch: scheduleSearch,
+	}
+}
+
+func (s *GrpcServer) SearchConnections(pbReq *pb.ConnectionsSearchRequest, stream grpc.ServerStreamingServer[pb.Connection]) error {
+	ctx := stream.Context()
+	req := connectionsSearchRequestFromPb(pbReq)
+	if err := validateConnectionsSearchRequest(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	conns, err := s.connSearch.StreamConnections(
+		ctx,
+		req.Origins,
+		req.Destinations,
+		req.MinDeparture,
+		req.MaxDeparture,
+		req.MaxFlights,
+		time.Duration(req.MinLayoverMS)*time.Millisecond,
+		time.Duration(req.MaxLayoverMS)*time.Millisecond,
+		time.Duration(req.MaxDurationMS)*time.Millisecond,
+		connectionsSearchOptions(req)...,
+	)
+	if err != nil {
+		return err
+	}
+
+	for conn := range conns {
+		if err := stream.Send(connectionToPb(conn)); err != nil {
+			return err
+		}
+	}
+
+	// the channel is closed early if the context is done
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return nil
+}
+
+func (s *GrpcServer) SearchSchedules(ctx context.Context, req *pb.ScheduleSearchRequest) (*pb.FlightSchedulesResponse, error) {
+	conditions := make([]schedulesearch.Condition, 0)
+	appendAny := func(values []string, fn func(string) schedulesearch.Condition) {
+		if len(values) < 1 {
+			return
+		}
+
+		subConditions := make([]schedulesearch.Condition, 0, len(values))
+		for _, v := range values {
+			subConditions = append(subConditions, fn(v))
+		}
+
+		conditions = append(conditions, schedulesearch.WithAny(subConditions...))
+	}
+
+	if len(req.AirlineIds) > 0 {
+		conditions = append(conditions, schedulesearch.WithAirlines(req.AirlineIds...))
+	}
+
+	appendAny(req.AircraftIds, schedulesearch.WithAircraftIataCode)
+	appendAny(req.AircraftConfigurationVersions, schedulesearch.WithAircraftConfigurationVersion)
+	appendAny(req.DepartureAirportIds, schedulesearch.WithDepartureAirportIataCode)
+	appendAny(req.ArrivalAirportIds, schedulesearch.WithArrivalAirportIataCode)
+
+	if req.MinDepartureTime != nil {
+		conditions = append(conditions, schedulesearch.WithMinDepartureTime(req.MinDepartureTime.AsTime()))
+	}
+
+	if req.MaxDepartureTime != nil {
+		conditions = append(conditions, schedulesearch.WithMaxDepartureTime(req.MaxDepartureTime.AsTime()))
+	}
+
+	if len(conditions) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "too few filters")
+	}
+
+	var asOf *time.Time
+	if req.AsOf != nil {
+		t := req.AsOf.AsTime()
+		asOf = &t
+	}
+
+	result, err := s.scheduleSearch.QuerySchedules(ctx, schedulesearch.WithAll(
+		schedulesearch.WithAny(
+			schedulesearch.WithServiceType("J"),
+			schedulesearch.WithServiceType("U"),
+		),
+		schedulesearch.WithIgnoreCodeShares(),
+		schedulesearch.WithAll(conditions...),
+	), asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.FlightSchedulesResponse{
+		Schedules: make([]*pb.FlightSchedule, 0, len(result.Schedules)),
+		Variants:  flightScheduleVariantsToPb(result.Variants),
+	}
+
+	for fn, items := range result.Schedules {
+		resp.Schedules = append(resp.Schedules, flightScheduleToPb(fn, items))
+	}
+
+	return resp, nil
+}
+
+func (s *GrpcServer) GetFlightSchedule(ctx context.Context, req *pb.FlightScheduleRequest) (*pb.FlightSchedulesResponse, error) {
+	if req.Year < 1 || req.Year > 9999 {
+		return nil, status.Error(codes.InvalidArgument, "invalid year")
+	}
+
+	fn, err := s.parseFlightNumber(ctx, req.FlightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	version := time.Date(2999, time.December, 31, 23, 59, 59, 0, time.UTC)
+	if req.Version != nil {
+		version = req.Version.AsTime()
+	}
+
+	departureDateRangeLocal := xtime.LocalDateRange{
+		xtime.NewLocalDateFromParts(int(req.Year), time.January, 1),
+		xtime.NewLocalDateFromParts(int(req.Year)+1, time.January, 1),
+	}
+
+	fs, err := s.repo.FlightSchedules(ctx, fn, version, &departureDateRangeLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FlightSchedulesResponse{
+		Schedules: []*pb.FlightSchedule{flightScheduleToPb(fn, fs.Items)},
+		Variants:  flightScheduleVariantsToPb(fs.Variants),
+	}, nil
+}
+
+func (s *GrpcServer) GetFlightScheduleVersions(ctx context.Context, req *pb.FlightScheduleVersionsRequest) (*pb.FlightScheduleVersionsResponse, error) {
+	fn, err := s.parseFlightNumber(ctx, req.FlightNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	departureAirportIataCode, err := util{}.parseAirport(ctx, req.DepartureAirportId, s.repo.Airports)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid departure airport: %v", err)
+	}
+
+	departureDateLocal, err := xtime.ParseLocalDate(req.DepartureDateLocal)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid departure date: %v", err)
+	}
+
+	fsv, err := s.repo.FlightScheduleVersions(ctx, fn, departureAirportIataCode, departureDateLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.FlightScheduleVersionsResponse{
+		FlightNumber: flightNumberToPb(fn),
+		Versions:     make([]*pb.FlightScheduleVersion, 0, len(fsv.Versions)),
+		Variants:     flightScheduleVariantsToPb(fsv.Variants),
+	}
+
+	for _, v := range fsv.Versions {
+		resp.Versions = append(resp.Versions, &pb.FlightScheduleVersion{
+			Version:         timestamppb.New(v.Version),
+			FlightVariantId: nullUuidString(v.FlightVariantId.V, v.FlightVariantId.Valid),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *GrpcServer) parseFlightNumber(ctx context.Context, raw string) (db.FlightNumber, error) {
+	airlines, err := s.repo.Airlines(ctx)
+	if err != nil {
+		return db.FlightNumber{}, err
+	}
+
+	fn, err := parseFlightNumber(airlines, raw)
+	if err != nil {
+		return db.FlightNumber{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return fn, nil
+}
+
+func GrpcErrorLogAndMaskUnaryInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, logAndMaskGrpcError(logger, info.FullMethod, err)
+		}
+
+		return resp, nil
+	}
+}
+
+func GrpcErrorLogAndMaskStreamInterceptor(logger *log.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return logAndMaskGrpcError(logger, info.FullMethod, err)
+		}
+
+		return nil
+	}
+}
+
+func logAndMaskGrpcError(logger *log.Logger, method string, err error) error {
+	logger.Printf("Error handling grpc request %s: %v\n", method, err)
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+func connectionToPb(conn connections.Connection) *pb.Connection {
+	f := conn.Flight
+	r := &pb.Connection{
+		Flight: &pb.ConnectionFlight{
+			FlightNumber:                 flightNumberToPb(f.FlightNumber),
+			DepartureAirportId:           f.DepartureAirportIataCode,
+			DepartureTime:                timestamppb.New(f.DepartureTime),
+			ArrivalAirportId:             f.ArrivalAirportIataCode,
+			ArrivalTime:                  timestamppb.New(f.ArrivalTime),
+			ServiceType:                  f.ServiceType,
+			AircraftOwner:                f.AircraftOwner,
+			AircraftId:                   f.AircraftIataCode,
+			AircraftConfigurationVersion: f.AircraftConfigurationVersion,
+			CodeShares:                   flightNumbersToPb(f.CodeShares),
+		},
+		Outgoing: make([]*pb.Connection, 0, len(conn.Outgoing)),
+	}
+
+	for _, outgoing := range conn.Outgoing {
+		r.Outgoing = append(r.Outgoing, connectionToPb(outgoing))
+	}
+
+	return r
+}
+
+func flightScheduleToPb(fn db.FlightNumber, items []db.FlightScheduleItem) *pb.FlightSchedule {
+	r := &pb.FlightSchedule{
+		FlightNumber: flightNumberToPb(fn),
+		Items:        make([]*pb.FlightScheduleItem, 0, len(items)),
+	}
+
+	for _, item := range items {
+		r.Items = append(r.Items, &pb.FlightScheduleItem{
+			DepartureDateLocal: item.DepartureDateLocal.String(),
+			DepartureAirportId: item.DepartureAirportIataCode,
+			FlightVariantId:    nullUuidString(item.FlightVariantId.V, item.FlightVariantId.Valid),
+			Version:            timestamppb.New(item.Version),
+			VersionCount:       uint32(item.VersionCount),
+		})
+	}
+
+	return r
+}
+
+func flightScheduleVariantsToPb(variants map[uuid.UUID]db.FlightScheduleVariant) map[string]*pb.FlightScheduleVariant {
+	r := make(map[string]*pb.FlightScheduleVariant, len(variants))
+	for id, variant := range variants {
+		r[id.String()] = &pb.FlightScheduleVariant{
+			Id:                           variant.Id.String(),
+			OperatedAs:                   flightNumberToPb(variant.OperatedAs),
+			DepartureTimeLocal:           variant.DepartureTimeLocal.String(),
+			DepartureUtcOffsetSeconds:    variant.DepartureUtcOffsetSeconds,
+			DurationSeconds:              variant.DurationSeconds,
+			ArrivalAirportId:             variant.ArrivalAirportIataCode,
+			ArrivalUtcOffsetSeconds:      variant.ArrivalUtcOffsetSeconds,
+			ServiceType:                  variant.ServiceType,
+			AircraftOwner:                variant.AircraftOwner,
+			AircraftId:                   variant.AircraftIataCode,
+			AircraftConfigurationVersion: variant.AircraftConfigurationVersion,
+			CodeShares:                   flightNumbersToPb(variant.CodeShares),
+			DataElements:                 variant.DataElements,
+		}
+	}
+
+	return r
+}
+
+func flightNumbersToPb(fns common.Set[db.FlightNumber]) []*pb.FlightNumber {
+	r := make([]*pb.FlightNumber, 0, len(fns))
+	for fn := range fns {
+		r = append(r, flightNumberToPb(fn))
+	}
+
+	return r
+}
+
+func flightNumberToPb(fn db.FlightNumber) *pb.FlightNumber {
+	return &pb.FlightNumber{
+		AirlineId: fn.AirlineIataCode,
+		Number:    uint32(fn.Number),
+		Suffix:    fn.Suffix,
+	}
+}
+
+func nullUuidString(id uuid.UUID, valid bool) string {
+	if !valid {
+		return ""
+	}
+
+	return id.String()
+}
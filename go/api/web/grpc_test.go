@@ -0,0 +1,143 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/connections"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/api/pb"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcTestRepo struct {
+	graphQLTestRepo
+	flights map[xtime.LocalDate][]db.Flight
+}
+
+func (r *grpcTestRepo) Flights(ctx context.Context, start, end xtime.LocalDate, asOf *time.Time) (map[xtime.LocalDate][]db.Flight, error) {
+	return r.flights, nil
+}
+
+func (r *grpcTestRepo) Airports(ctx context.Context) (map[string]db.Airport, error) {
+	return map[string]db.Airport{
+		"FRA": {IataCode: "FRA"},
+		"MUC": {IataCode: "MUC"},
+		"JFK": {IataCode: "JFK"},
+	}, nil
+}
+
+func (r *grpcTestRepo) FlightSchedules(ctx context.Context, fn db.FlightNumber, version time.Time, departureDateRangeLocal *xtime.LocalDateRange) (db.FlightSchedules, error) {
+	fsm, err := r.FlightSchedulesMany(ctx, []db.FlightNumber{fn}, version, departureDateRangeLocal)
+	return db.FlightSchedules{Items: fsm.Schedules[fn], Variants: fsm.Variants}, err
+}
+
+func (r *grpcTestRepo) FlightScheduleVersions(ctx context.Context, fn db.FlightNumber, departureAirportIataCode string, departureDate xtime.LocalDate) (db.FlightScheduleVersions, error) {
+	return db.FlightScheduleVersions{}, nil
+}
+
+func newGrpcTestClient(t *testing.T, repo *grpcTestRepo) pb.ExploreFlightsClient {
+	l := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	pb.RegisterExploreFlightsServer(gs, NewGrpcServer(repo, connections.NewSearch(repo), nil))
+	go gs.Serve(l)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewExploreFlightsClient(conn)
+}
+
+func TestGrpcSearchConnectionsStreamsResults(t *testing.T) {
+	d := xtime.NewLocalDateFromParts(2026, time.January, 1)
+	flight := func(number int, departureAirport, arrivalAirport string, departureHour, durationHours int) db.Flight {
+		departure := time.Date(2026, time.January, 1, departureHour, 0, 0, 0, time.UTC)
+		return db.Flight{
+			FlightNumber:             db.FlightNumber{AirlineIataCode: "LH", Number: number},
+			DepartureTime:            departure,
+			DepartureAirportIataCode: departureAirport,
+			ArrivalTime:              departure.Add(time.Duration(durationHours) * time.Hour),
+			ArrivalAirportIataCode:   arrivalAirport,
+			ServiceType:              "J",
+			CodeShares:               make(common.Set[db.FlightNumber]),
+		}
+	}
+
+	repo := &grpcTestRepo{flights: map[xtime.LocalDate][]db.Flight{
+		d: {
+			flight(400, "FRA", "JFK", 10, 8),
+			flight(100, "FRA", "MUC", 8, 1),
+			flight(410, "MUC", "JFK", 11, 9),
+		},
+	}}
+
+	client := newGrpcTestClient(t, repo)
+	stream, err := client.SearchConnections(t.Context(), &pb.ConnectionsSearchRequest{
+		Origins:      []string{"FRA"},
+		Destinations: []string{"JFK"},
+		MinDeparture: timestamppb.New(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		MaxDeparture: timestamppb.New(time.Date(2026, time.January, 1, 23, 0, 0, 0, time.UTC)),
+		MaxFlights:   2,
+		MinLayover:   durationpb.New(time.Hour),
+		MaxLayover:   durationpb.New(6 * time.Hour),
+		MaxDuration:  durationpb.New(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	numbers := make(map[uint32][]uint32)
+	for {
+		conn, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		for _, outgoing := range conn.Outgoing {
+			numbers[conn.Flight.FlightNumber.Number] = append(numbers[conn.Flight.FlightNumber.Number], outgoing.Flight.FlightNumber.Number)
+		}
+
+		if len(conn.Outgoing) < 1 {
+			numbers[conn.Flight.FlightNumber.Number] = nil
+		}
+	}
+
+	assert.Equal(t, map[uint32][]uint32{400: nil, 100: {410}}, numbers)
+}
+
+func TestGrpcRejectsInvalidRequests(t *testing.T) {
+	client := newGrpcTestClient(t, &grpcTestRepo{})
+
+	stream, err := client.SearchConnections(t.Context(), &pb.ConnectionsSearchRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetFlightSchedule(t.Context(), &pb.FlightScheduleRequest{FlightNumber: "XX1", Year: 2026})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := client.GetFlightSchedule(t.Context(), &pb.FlightScheduleRequest{FlightNumber: "DLH400", Year: 2026})
+	require.NoError(t, err)
+	require.Len(t, resp.Schedules, 1)
+	assert.Equal(t, uint32(400), resp.Schedules[0].FlightNumber.Number)
+	assert.Len(t, resp.Schedules[0].Items, 1)
+	assert.Contains(t, resp.Variants, resp.Schedules[0].Items[0].FlightVariantId)
+}
@@ -7,7 +7,6 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -40,19 +39,43 @@ func requestContextYear(ctx context.Context) (int, bool) {
 	return year, ok
 }
 
-func VersionHeaderMiddleware(version string) echo.MiddlewareFunc {
-	readVersion := sync.OnceValues(func() (time.Time, error) {
-		t, err := time.Parse(time.RFC3339, version)
-		if err != nil {
-			return time.Time{}, err
+type asOfRequestContextKey struct{}
+
+// AsOfMiddleware makes the optional asOf query parameter available to point-in-time capable handlers
+func AsOfMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			asOfRaw := c.QueryParam("asOf")
+			if asOfRaw == "" || asOfRaw == "latest" {
+				return next(c)
+			}
+
+			asOf, err := time.Parse(time.RFC3339, asOfRaw)
+			if err != nil {
+				return NewHTTPError(http.StatusBadRequest, WithMessage("Invalid asOf format"), WithCause(err))
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(context.WithValue(req.Context(), asOfRequestContextKey{}, asOf.UTC())))
+			return next(c)
 		}
+	}
+}
 
-		return t, nil
-	})
+// requestContextAsOf returns nil if the latest version was requested
+func requestContextAsOf(ctx context.Context) *time.Time {
+	asOf, ok := ctx.Value(asOfRequestContextKey{}).(time.Time)
+	if !ok {
+		return nil
+	}
+
+	return &asOf
+}
 
+func VersionHeaderMiddleware(version func() string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			version, err := readVersion()
+			version, err := time.Parse(time.RFC3339, version())
 			if err == nil {
 				c.Response().Header().Add("Ef-Data-Version", version.Format(time.RFC3339))
 			}
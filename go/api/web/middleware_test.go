@@ -3,6 +3,7 @@ package web
 import (
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,44 @@ func TestYearMiddlewareRejectsInvalidYears(t *testing.T) {
 		})
 	}
 }
+
+func TestAsOfMiddleware(t *testing.T) {
+	asOf := time.Date(2025, time.February, 28, 23, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		query    string
+		expected *time.Time
+	}{
+		{"", nil},
+		{"?asOf=latest", nil},
+		{"?asOf=2025-03-01T00:00:00%2B01:00", &asOf},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest("GET", "/data/destinations/FRA"+tc.query, nil), nil)
+
+			called := false
+			err := AsOfMiddleware()(func(c echo.Context) error {
+				called = true
+				assert.Equal(t, tc.expected, requestContextAsOf(c.Request().Context()))
+				return nil
+			})(c)
+
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestAsOfMiddlewareRejectsInvalidVersions(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest("GET", "/data/destinations/FRA?asOf=2025-03-01", nil), nil)
+
+	err := AsOfMiddleware()(func(c echo.Context) error {
+		t.Fatal("handler must not be called")
+		return nil
+	})(c)
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, 400, httpErr.code)
+}
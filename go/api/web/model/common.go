@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"iter"
 
 	"github.com/explore-flights/monorepo/go/api/db"
@@ -115,6 +116,49 @@ func (ac Aircraft) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v)
 }
 
+func (ac *Aircraft) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Id             string              `json:"id"`
+		Type           string              `json:"type"`
+		IataCode       string              `json:"iataCode"`
+		IcaoCode       string              `json:"icaoCode"`
+		Name           string              `json:"name"`
+		ParentFamilyId *string             `json:"parentFamilyId"`
+		Configurations map[string][]string `json:"configurations"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*ac = Aircraft{
+		Id:             v.Id,
+		Configurations: v.Configurations,
+	}
+
+	switch v.Type {
+	case "aircraft":
+		ac.AircraftType = &AircraftType{
+			ParentFamilyId: v.ParentFamilyId,
+			IataCode:       v.IataCode,
+			IcaoCode:       v.IcaoCode,
+			Name:           v.Name,
+		}
+
+	case "family":
+		ac.AircraftFamily = &AircraftFamily{
+			ParentFamilyId: v.ParentFamilyId,
+			IataCode:       v.IataCode,
+			Name:           v.Name,
+		}
+
+	default:
+		return fmt.Errorf("unknown aircraft type %q", v.Type)
+	}
+
+	return nil
+}
+
 func (ac Aircraft) Name() string {
 	if ac.AircraftType != nil {
 		return ac.AircraftType.Name
@@ -10,25 +10,31 @@ import (
 )
 
 type ConnectionsSearchRequest struct {
-	Origins             []string  `json:"origins"`
-	Destinations        []string  `json:"destinations"`
-	MinDeparture        time.Time `json:"minDeparture"`
-	MaxDeparture        time.Time `json:"maxDeparture"`
-	MaxFlights          uint32    `json:"maxFlights"`
-	MinLayoverMS        uint64    `json:"minLayoverMS"`
-	MaxLayoverMS        uint64    `json:"maxLayoverMS"`
-	MaxDurationMS       uint64    `json:"maxDurationMS"`
-	CountMultiLeg       bool      `json:"countMultiLeg"`
-	IncludeAirport      []string  `json:"includeAirport,omitempty"`
-	ExcludeAirport      []string  `json:"excludeAirport,omitempty"`
-	IncludeFlightNumber []string  `json:"includeFlightNumber,omitempty"`
-	ExcludeFlightNumber []string  `json:"excludeFlightNumber,omitempty"`
-	IncludeAircraft     []string  `json:"includeAircraft,omitempty"`
-	ExcludeAircraft     []string  `json:"excludeAircraft,omitempty"`
+	Origins             []string   `json:"origins"`
+	Destinations        []string   `json:"destinations"`
+	MinDeparture        time.Time  `json:"minDeparture"`
+	MaxDeparture        time.Time  `json:"maxDeparture"`
+	MaxFlights          uint32     `json:"maxFlights"`
+	MinLayoverMS        uint64     `json:"minLayoverMS"`
+	MaxLayoverMS        uint64     `json:"maxLayoverMS"`
+	MaxDurationMS       uint64     `json:"maxDurationMS"`
+	CountMultiLeg       bool       `json:"countMultiLeg"`
+	IncludeAirport      []string   `json:"includeAirport,omitempty"`
+	ExcludeAirport      []string   `json:"excludeAirport,omitempty"`
+	IncludeFlightNumber []string   `json:"includeFlightNumber,omitempty"`
+	ExcludeFlightNumber []string   `json:"excludeFlightNumber,omitempty"`
+	IncludeAircraft     []string   `json:"includeAircraft,omitempty"`
+	ExcludeAircraft     []string   `json:"excludeAircraft,omitempty"`
+	AsOf                *time.Time `json:"asOf,omitempty"`
 }
 
 func (req ConnectionsSearchRequest) ToPb() proto.Message {
 	countMultiLeg := req.CountMultiLeg
+	var asOf *timestamppb.Timestamp
+	if req.AsOf != nil {
+		asOf = timestamppb.New(*req.AsOf)
+	}
+
 	return &pb.ConnectionsSearchRequest{
 		Origins:             req.Origins,
 		Destinations:        req.Destinations,
@@ -45,6 +51,7 @@ func (req ConnectionsSearchRequest) ToPb() proto.Message {
 		ExcludeFlightNumber: req.ExcludeFlightNumber,
 		IncludeAircraft:     req.IncludeAircraft,
 		ExcludeAircraft:     req.ExcludeAircraft,
+		AsOf:                asOf,
 	}
 }
 
@@ -77,3 +84,21 @@ type ConnectionsSearchResponse struct {
 	Data   ConnectionsResponse       `json:"data"`
 	Search *ConnectionsSearchRequest `json:"search,omitempty"`
 }
+
+type ConnectionsShareResponse struct {
+	HtmlUrl  string `json:"htmlUrl"`
+	ImageUrl string `json:"imageUrl"`
+}
+
+type ReachabilityResponse struct {
+	Origin    string             `json:"origin"`
+	Reachable []ReachableAirport `json:"reachable"`
+	Airports  map[string]Airport `json:"airports"`
+}
+
+type ReachableAirport struct {
+	AirportIataCode string                     `json:"airportId"`
+	ArrivalTime     time.Time                  `json:"arrivalTime"`
+	DurationMS      int64                      `json:"durationMS"`
+	Flights         []ConnectionFlightResponse `json:"flights"`
+}
@@ -0,0 +1,17 @@
+package model
+
+type NotificationType string
+
+const (
+	NotificationTypeSuccess    = NotificationType("success")
+	NotificationTypeInfo       = NotificationType("info")
+	NotificationTypeWarning    = NotificationType("warning")
+	NotificationTypeError      = NotificationType("error")
+	NotificationTypeInProgress = NotificationType("in-progress")
+)
+
+type Notification struct {
+	Type    NotificationType `json:"type"`
+	Header  string           `json:"header,omitempty"`
+	Content string           `json:"content,omitempty"`
+}
@@ -0,0 +1,147 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/analytics"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultNetworkDays is the range of a network export if no start and end are given
+const defaultNetworkDays = 7
+
+type networkHandlerRepo interface {
+	Airlines(ctx context.Context) (map[string]db.Airline, error)
+	Airports(ctx context.Context) (map[string]db.Airport, error)
+}
+
+type networkWriter func(w io.Writer, n analytics.Network) error
+
+type NetworkHandler struct {
+	repo   networkHandlerRepo
+	search *analytics.Search
+}
+
+func NewNetworkHandler(repo networkHandlerRepo, search *analytics.Search) *NetworkHandler {
+	return &NetworkHandler{
+		repo:   repo,
+		search: search,
+	}
+}
+
+func (h *NetworkHandler) AirlineGeoJSON(c echo.Context) error {
+	return h.airlineNetwork(c, "geojson", mimeGeoJSON, analytics.WriteGeoJSON)
+}
+
+func (h *NetworkHandler) AirlineGraphML(c echo.Context) error {
+	return h.airlineNetwork(c, "graphml", mimeGraphML, analytics.WriteGraphML)
+}
+
+func (h *NetworkHandler) AirlineCSV(c echo.Context) error {
+	return h.airlineNetwork(c, "csv", mimeCSV, analytics.WriteCSV)
+}
+
+func (h *NetworkHandler) AirportGeoJSON(c echo.Context) error {
+	return h.airportNetwork(c, "geojson", mimeGeoJSON, analytics.WriteGeoJSON)
+}
+
+func (h *NetworkHandler) AirportGraphML(c echo.Context) error {
+	return h.airportNetwork(c, "graphml", mimeGraphML, analytics.WriteGraphML)
+}
+
+func (h *NetworkHandler) AirportCSV(c echo.Context) error {
+	return h.airportNetwork(c, "csv", mimeCSV, analytics.WriteCSV)
+}
+
+func (h *NetworkHandler) airlineNetwork(c echo.Context, ext, contentType string, writer networkWriter) error {
+	airline, err := util{}.parseAirline(c.Request().Context(), c.Param("airlineId"), h.repo.Airlines)
+	if err != nil {
+		return NewHTTPError(http.StatusNotFound, WithCause(err))
+	}
+
+	q, err := h.parseRange(c)
+	if err != nil {
+		return err
+	}
+
+	q.AirlineIataCodes = []string{airline}
+	return h.network(c, q, airline, ext, contentType, writer)
+}
+
+// airportNetwork exports all routes from and to the airport; the airlineId query parameter restricts the operating airlines
+func (h *NetworkHandler) airportNetwork(c echo.Context, ext, contentType string, writer networkWriter) error {
+	ctx := c.Request().Context()
+	airport, err := util{}.parseAirport(ctx, c.Param("airportId"), h.repo.Airports)
+	if err != nil {
+		return NewHTTPError(http.StatusNotFound, WithCause(err))
+	}
+
+	q, err := h.parseRange(c)
+	if err != nil {
+		return err
+	}
+
+	q.AirportIataCode = airport
+	for _, raw := range c.QueryParams()["airlineId"] {
+		airline, err := util{}.parseAirline(ctx, raw, h.repo.Airlines)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err))
+		}
+
+		q.AirlineIataCodes = append(q.AirlineIataCodes, airline)
+	}
+
+	return h.network(c, q, airport, ext, contentType, writer)
+}
+
+func (h *NetworkHandler) parseRange(c echo.Context) (analytics.Query, error) {
+	var q analytics.Query
+	var err error
+
+	if raw := c.QueryParam("start"); raw != "" {
+		if q.Start, err = xtime.ParseLocalDate(raw); err != nil {
+			return q, NewHTTPError(http.StatusBadRequest, WithMessage("invalid start"), WithCause(err))
+		}
+	} else {
+		q.Start = xtime.NewLocalDate(time.Now().UTC())
+	}
+
+	if raw := c.QueryParam("end"); raw != "" {
+		if q.End, err = xtime.ParseLocalDate(raw); err != nil {
+			return q, NewHTTPError(http.StatusBadRequest, WithMessage("invalid end"), WithCause(err))
+		}
+	} else {
+		q.End = q.Start + defaultNetworkDays - 1
+	}
+
+	return q, nil
+}
+
+func (h *NetworkHandler) network(c echo.Context, q analytics.Query, name, ext, contentType string, writer networkWriter) error {
+	network, err := h.search.Network(c.Request().Context(), q)
+	if err != nil {
+		if errors.Is(err, analytics.ErrInvalidRange) {
+			return NewHTTPError(http.StatusBadRequest, WithCause(err), WithUnmaskedCause())
+		}
+
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = writer(&buf, network); err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-%s-%s.%s"`, name, q.Start.String(), q.End.String(), ext))
+	addExpirationHeaders(c, time.Now(), time.Hour)
+
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
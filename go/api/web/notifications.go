@@ -6,43 +6,28 @@ import (
 	"strings"
 	"time"
 
+	"github.com/explore-flights/monorepo/go/api/web/model"
 	"github.com/labstack/echo/v4"
 )
 
-type notificationType string
-
-const (
-	notificationTypeSuccess    = notificationType("success")
-	notificationTypeInfo       = notificationType("info")
-	notificationTypeWarning    = notificationType("warning")
-	notificationTypeError      = notificationType("error")
-	notificationTypeInProgress = notificationType("in-progress")
-)
-
-type notification struct {
-	Type    notificationType `json:"type"`
-	Header  string           `json:"header,omitempty"`
-	Content string           `json:"content,omitempty"`
-}
-
 type NotificationHandler struct {
-	version string
+	version func() string
 }
 
-func NewNotificationHandler(version string) *NotificationHandler {
+func NewNotificationHandler(version func() string) *NotificationHandler {
 	return &NotificationHandler{version: version}
 }
 
 func (nh *NotificationHandler) Notifications(c echo.Context) error {
-	t, err := time.Parse(time.RFC3339, nh.version)
+	t, err := time.Parse(time.RFC3339, nh.version())
 	if err != nil {
 		return err
 	}
 
-	notifications := make([]notification, 0)
+	notifications := make([]model.Notification, 0)
 	if timeSinceLastUpdate := time.Since(t); timeSinceLastUpdate >= time.Hour*36 {
-		notifications = append(notifications, notification{
-			Type:    notificationTypeInfo,
+		notifications = append(notifications, model.Notification{
+			Type:    model.NotificationTypeInfo,
 			Header:  "Information outdated",
 			Content: fmt.Sprintf("We are having issues updating the data shown on this website and are working on a fix. The schedules have last been updated at %s (%s ago).", t.Format(time.RFC3339), nh.humanReadableDuration(timeSinceLastUpdate)),
 		})
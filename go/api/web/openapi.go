@@ -0,0 +1,839 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/analytics"
+	"github.com/explore-flights/monorepo/go/api/business/seatmap"
+	"github.com/explore-flights/monorepo/go/api/business/updates"
+	"github.com/explore-flights/monorepo/go/api/web/model"
+	"github.com/explore-flights/monorepo/go/api/web/openapi"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	mimeRSS      = "application/rss+xml"
+	mimeAtom     = "application/atom+xml"
+	mimeJSONFeed = "application/feed+json"
+	mimeGeoJSON  = "application/geo+json"
+	mimeGraphML  = "application/graphml+xml"
+	mimeCSV      = "text/csv"
+	mimePNG      = "image/png"
+	mimeSVG      = "image/svg+xml"
+)
+
+type apiResponse struct {
+	contentType string
+	body        reflect.Type
+}
+
+type apiOperation struct {
+	id          string
+	summary     string
+	tags        []string
+	deprecated  bool
+	query       []openapi.Parameter
+	requestBody reflect.Type
+	response    apiResponse
+	// altResponses are further content types of the successful response, selected by a query parameter
+	altResponses []apiResponse
+}
+
+func jsonResponse[T any]() apiResponse {
+	return apiResponse{
+		contentType: echo.MIMEApplicationJSON,
+		body:        reflect.TypeFor[T](),
+	}
+}
+
+func rawResponse(contentType string) apiResponse {
+	return apiResponse{contentType: contentType}
+}
+
+func queryParam(name, description string, schema *openapi.Schema) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      schema,
+	}
+}
+
+var asOfQueryParam = queryParam("asOf", "RFC3339 version timestamp to answer the request as of; defaults to the latest version", openapi.String("date-time"))
+
+var analyticsQueryParams = []openapi.Parameter{
+	queryParam("start", "first local departure date of the range", openapi.String("date")),
+	queryParam("end", "last local departure date of the range; the range may span at most 366 days", openapi.String("date")),
+	queryParam("groupBy", "group by operating airline, departure airport, departure country or aircraft", &openapi.Schema{Type: "string", Enum: []any{"airline", "airport", "country", "aircraft"}}),
+	queryParam("airlineId", "IATA or ICAO codes of the operating airlines to include", openapi.Array(&openapi.Schema{Type: "string"})),
+	queryParam("aircraftId", "IATA codes of the aircraft types to include", openapi.Array(&openapi.Schema{Type: "string"})),
+	queryParam("departureAirportId", "IATA or ICAO code of the departure airport", &openapi.Schema{Type: "string"}),
+	queryParam("arrivalAirportId", "IATA or ICAO code of the arrival airport", &openapi.Schema{Type: "string"}),
+}
+
+var networkQueryParams = []openapi.Parameter{
+	queryParam("start", "first local departure date of the range, defaults to today", openapi.String("date")),
+	queryParam("end", "last local departure date of the range, defaults to 6 days after start; the range may span at most 366 days", openapi.String("date")),
+}
+
+var reachabilityQueryParams = []openapi.Parameter{
+	queryParam("minDeparture", "earliest departure from the origin", openapi.String("date-time")),
+	queryParam("maxDeparture", "latest departure from the origin, defaults to 24 hours after minDeparture", openapi.String("date-time")),
+	queryParam("maxFlights", "maximum number of flights, between 1 and 4, defaults to 2", openapi.Integer("int32")),
+	queryParam("minLayover", "minimum layover as a duration (e.g. 45m), defaults to 1h", &openapi.Schema{Type: "string"}),
+	queryParam("maxDuration", "maximum duration from the first departure to the arrival as a duration (e.g. 12h), defaults to 24h", &openapi.Schema{Type: "string"}),
+	queryParam("excludeAirport", "airports (or glob patterns) to exclude", openapi.Array(&openapi.Schema{Type: "string"})),
+	queryParam("excludeFlightNumber", "flight numbers (or glob patterns) to exclude", openapi.Array(&openapi.Schema{Type: "string"})),
+	queryParam("excludeAircraft", "aircraft (or glob patterns) to exclude", openapi.Array(&openapi.Schema{Type: "string"})),
+	asOfQueryParam,
+}
+
+var pathParameters = map[string]openapi.Parameter{
+	"fn": {
+		Description: "IATA or ICAO flight number, e.g. LH400 or DLH400",
+		Schema:      &openapi.Schema{Type: "string", Pattern: "^([0-9A-Z]{2,3})([0-9]{1,4})([A-Z]?)$"},
+	},
+	"version": {
+		Description: "RFC3339 version timestamp or 'latest'",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"departureAirport": {
+		Description: "IATA or ICAO code of the departure airport",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"arrivalAirport": {
+		Description: "IATA or ICAO code of the arrival airport",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"departureDateLocal": {
+		Description: "departure date in airport local time",
+		Schema:      openapi.String("date"),
+	},
+	"departureDate": {
+		Description: "departure date (UTC)",
+		Schema:      openapi.String("date"),
+	},
+	"year": {
+		Description: "year of the departure dates to include",
+		Schema:      openapi.Integer("int32"),
+	},
+	"airportId": {
+		Description: "IATA or ICAO code of the airport",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"payload": {
+		Description: "base64 (raw url encoding) encoded protobuf ConnectionsSearchRequest",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"airlineId": {
+		Description: "IATA code of the airline",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"profile": {
+		Description: "seat scoring profile",
+		Schema:      &openapi.Schema{Type: "string", Enum: []any{seatmap.ScoringProfileLegroom, seatmap.ScoringProfileQuietFront, seatmap.ScoringProfileWindowNoWing}},
+	},
+	"aircraftId": {
+		Description: "IATA code of the aircraft type",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"aircraftConfigurationVersion": {
+		Description: "aircraft configuration version as published by the airline",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"from": {
+		Description: "RFC3339 seat map version timestamp or 'latest'",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+	"to": {
+		Description: "RFC3339 seat map version timestamp or 'latest'",
+		Schema:      &openapi.Schema{Type: "string"},
+	},
+}
+
+var apiOperations = map[string]apiOperation{
+	// region /api
+	"GET /api/analytics/frequency": {
+		id:       "analyticsRouteFrequencies",
+		summary:  "Flights, weekly frequency and seats per route of operating flights within a date range",
+		tags:     []string{"analytics"},
+		query:    analyticsQueryParams,
+		response: jsonResponse[[]analytics.RouteFrequency](),
+	},
+	"GET /api/analytics/capacity": {
+		id:       "analyticsCapacity",
+		summary:  "Seat capacity per cabin, routes and destinations of operating flights within a date range",
+		tags:     []string{"analytics"},
+		query:    analyticsQueryParams,
+		response: jsonResponse[[]analytics.Capacity](),
+	},
+	"GET /api/analytics/busiest-routes": {
+		id:      "analyticsBusiestRoutes",
+		summary: "Busiest routes (per group) of operating flights within a date range",
+		tags:    []string{"analytics"},
+		query: append(
+			slices.Clone(analyticsQueryParams),
+			queryParam("limit", "number of routes per group, defaults to 20", openapi.Integer("int32")),
+			queryParam("sortBy", "rank routes by number of flights (default) or seats", &openapi.Schema{Type: "string", Enum: []any{"flights", "seats"}}),
+		),
+		response: jsonResponse[[]analytics.RouteFrequency](),
+	},
+	"GET /api/analytics/route-changes": {
+		id:       "analyticsRouteChanges",
+		summary:  "Routes added and dropped within a date range compared to the preceding range of the same length",
+		tags:     []string{"analytics"},
+		query:    analyticsQueryParams,
+		response: jsonResponse[analytics.RouteChanges](),
+	},
+	"POST /api/connections/json": {
+		id:          "searchConnections",
+		summary:     "Search connections",
+		tags:        []string{"connections"},
+		query:       []openapi.Parameter{queryParam("includeSearch", "include the search request in the response", &openapi.Schema{Type: "boolean"}), asOfQueryParam},
+		requestBody: reflect.TypeFor[model.ConnectionsSearchRequest](),
+		response:    jsonResponse[model.ConnectionsSearchResponse](),
+	},
+	"GET /api/connections/json/:payload": {
+		id:       "searchConnectionsByPayload",
+		summary:  "Search connections using a shared payload",
+		tags:     []string{"connections"},
+		query:    []openapi.Parameter{queryParam("includeSearch", "include the search request in the response", &openapi.Schema{Type: "boolean"}), asOfQueryParam},
+		response: jsonResponse[model.ConnectionsSearchResponse](),
+	},
+	"POST /api/connections/png": {
+		id:          "renderConnections",
+		summary:     "Render connections as a graph",
+		tags:        []string{"connections"},
+		query:       []openapi.Parameter{asOfQueryParam},
+		requestBody: reflect.TypeFor[model.ConnectionsSearchRequest](),
+		response:    rawResponse(mimePNG),
+	},
+	"GET /api/connections/png/:payload/c.png": {
+		id:       "renderConnectionsByPayload",
+		summary:  "Render connections of a shared payload as a graph",
+		tags:     []string{"connections"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: rawResponse(mimePNG),
+	},
+	"POST /api/connections/share": {
+		id:          "shareConnections",
+		summary:     "Create share links for a connection search",
+		tags:        []string{"connections"},
+		query:       []openapi.Parameter{asOfQueryParam},
+		requestBody: reflect.TypeFor[model.ConnectionsSearchRequest](),
+		response:    jsonResponse[model.ConnectionsShareResponse](),
+	},
+	"GET /api/connections/share/:payload": {
+		id:       "shareConnectionsHtml",
+		summary:  "HTML preview page of a shared connection search",
+		tags:     []string{"connections"},
+		response: rawResponse(echo.MIMETextHTMLCharsetUTF8),
+	},
+	"GET /api/connections/reachability/:airportId": {
+		id:       "reachability",
+		summary:  "Airports reachable from an airport with the earliest arrival and the path leading to it",
+		tags:     []string{"connections"},
+		query:    reachabilityQueryParams,
+		response: jsonResponse[model.ReachabilityResponse](),
+	},
+	"GET /api/connections/reachability/:airportId/reachability.geojson": {
+		id:       "reachabilityGeoJson",
+		summary:  "Airports reachable from an airport and the paths leading to them as GeoJSON",
+		tags:     []string{"connections"},
+		query:    reachabilityQueryParams,
+		response: rawResponse(mimeGeoJSON),
+	},
+	"GET /api/search": {
+		id:      "search",
+		summary: "Search flight numbers; responds with a redirect unless JSON is accepted",
+		tags:    []string{"search"},
+		query: []openapi.Parameter{
+			queryParam("q", "search query", &openapi.Schema{Type: "string"}),
+		},
+		response: jsonResponse[model.SearchResponse](),
+	},
+	"GET /api/schedule/search": {
+		id:      "scheduleSearch",
+		summary: "Search flight schedules; at least two filters are required",
+		tags:    []string{"schedules"},
+		query: []openapi.Parameter{
+			queryParam("airlineId", "IATA airline code", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("aircraftId", "IATA aircraft code", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("aircraftConfigurationVersion", "aircraft configuration version", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("aircraft", "<aircraftId>-<aircraftConfigurationVersion>", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("departureAirportId", "IATA departure airport code", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("arrivalAirportId", "IATA arrival airport code", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("route", "<departureAirportId>-<arrivalAirportId>", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("minDepartureTime", "minimum departure time", openapi.String("date-time")),
+			queryParam("maxDepartureTime", "maximum departure time", openapi.String("date-time")),
+			queryParam("format", "json (default) or ssim to export the schedules as IATA SSIM chapter 7 file", &openapi.Schema{Type: "string", Enum: []any{"json", "ssim"}}),
+			asOfQueryParam,
+		},
+		response:     jsonResponse[model.FlightSchedulesMany](),
+		altResponses: []apiResponse{rawResponse(echo.MIMETextPlain)},
+	},
+	"GET /api/game/connection": {
+		id:      "connectionGame",
+		summary: "Connection game challenge",
+		tags:    []string{"game"},
+		query: []openapi.Parameter{
+			queryParam("minFlights", "minimum number of flights", openapi.Integer("int32")),
+			queryParam("maxFlights", "maximum number of flights", openapi.Integer("int32")),
+			queryParam("seed", "seed of the challenge", &openapi.Schema{Type: "string"}),
+		},
+		response: jsonResponse[model.ConnectionGameChallenge](),
+	},
+	"POST /api/graphql": {
+		id:          "graphql",
+		summary:     "GraphQL query over airlines, airports, aircraft and flight schedules",
+		tags:        []string{"graphql"},
+		requestBody: reflect.TypeFor[model.GraphQLRequest](),
+		response:    rawResponse(echo.MIMEApplicationJSON),
+	},
+	"GET /api/graphql": {
+		id:      "graphqlGet",
+		summary: "GraphQL query over airlines, airports, aircraft and flight schedules",
+		tags:    []string{"graphql"},
+		query: []openapi.Parameter{
+			queryParam("query", "GraphQL query document", &openapi.Schema{Type: "string"}),
+			queryParam("operationName", "name of the operation to execute", &openapi.Schema{Type: "string"}),
+			queryParam("variables", "JSON encoded variables", &openapi.Schema{Type: "string"}),
+		},
+		response: rawResponse(echo.MIMEApplicationJSON),
+	},
+	"GET /api/network/airline/:airlineId/network.geojson": {
+		id:       "airlineNetworkGeoJson",
+		summary:  "GeoJSON export of the route network of an airline",
+		tags:     []string{"analytics"},
+		query:    networkQueryParams,
+		response: rawResponse(mimeGeoJSON),
+	},
+	"GET /api/network/airline/:airlineId/network.graphml": {
+		id:       "airlineNetworkGraphML",
+		summary:  "GraphML export of the route network of an airline",
+		tags:     []string{"analytics"},
+		query:    networkQueryParams,
+		response: rawResponse(mimeGraphML),
+	},
+	"GET /api/network/airline/:airlineId/network.csv": {
+		id:       "airlineNetworkCsv",
+		summary:  "CSV export of the route network of an airline",
+		tags:     []string{"analytics"},
+		query:    networkQueryParams,
+		response: rawResponse(mimeCSV),
+	},
+	"GET /api/network/airport/:airportId/network.geojson": {
+		id:      "airportNetworkGeoJson",
+		summary: "GeoJSON export of the route network from and to an airport",
+		tags:    []string{"analytics"},
+		query: append(
+			slices.Clone(networkQueryParams),
+			queryParam("airlineId", "IATA or ICAO codes of the operating airlines to include", openapi.Array(&openapi.Schema{Type: "string"})),
+		),
+		response: rawResponse(mimeGeoJSON),
+	},
+	"GET /api/network/airport/:airportId/network.graphml": {
+		id:      "airportNetworkGraphML",
+		summary: "GraphML export of the route network from and to an airport",
+		tags:    []string{"analytics"},
+		query: append(
+			slices.Clone(networkQueryParams),
+			queryParam("airlineId", "IATA or ICAO codes of the operating airlines to include", openapi.Array(&openapi.Schema{Type: "string"})),
+		),
+		response: rawResponse(mimeGraphML),
+	},
+	"GET /api/network/airport/:airportId/network.csv": {
+		id:      "airportNetworkCsv",
+		summary: "CSV export of the route network from and to an airport",
+		tags:    []string{"analytics"},
+		query: append(
+			slices.Clone(networkQueryParams),
+			queryParam("airlineId", "IATA or ICAO codes of the operating airlines to include", openapi.Array(&openapi.Schema{Type: "string"})),
+		),
+		response: rawResponse(mimeCSV),
+	},
+	"GET /api/notifications": {
+		id:       "notifications",
+		summary:  "Notifications to show to users",
+		tags:     []string{"meta"},
+		response: jsonResponse[[]model.Notification](),
+	},
+	"GET /api/openapi.json": {
+		id:       "openapi",
+		summary:  "This document",
+		tags:     []string{"meta"},
+		response: jsonResponse[openapi.Document](),
+	},
+	// endregion
+	// region /data
+	"GET /data/airlines.json": {
+		id:       "airlines",
+		summary:  "All airlines",
+		tags:     []string{"reference"},
+		response: jsonResponse[[]model.Airline](),
+	},
+	"GET /data/airports.json": {
+		id:       "airports",
+		summary:  "All airports",
+		tags:     []string{"reference"},
+		response: jsonResponse[[]model.Airport](),
+	},
+	"GET /data/aircraft.json": {
+		id:       "aircraft",
+		summary:  "All aircraft types and families",
+		tags:     []string{"reference"},
+		response: jsonResponse[[]model.Aircraft](),
+	},
+	"GET /data/flight/:fn/versions/:departureAirport/:departureDateLocal": {
+		id:       "flightScheduleVersions",
+		summary:  "All versions of a single flight",
+		tags:     []string{"flights"},
+		response: jsonResponse[model.FlightScheduleVersions](),
+	},
+	"GET /data/flight/:fn/versions/:departureAirport/:departureDateLocal/feed.rss": {
+		id:       "flightScheduleVersionsRss",
+		summary:  "RSS feed of the versions of a single flight",
+		tags:     []string{"flights", "feeds"},
+		response: rawResponse(mimeRSS),
+	},
+	"GET /data/flight/:fn/versions/:departureAirport/:departureDateLocal/feed.atom": {
+		id:       "flightScheduleVersionsAtom",
+		summary:  "Atom feed of the versions of a single flight",
+		tags:     []string{"flights", "feeds"},
+		response: rawResponse(mimeAtom),
+	},
+	"GET /data/flight/:fn/:version/:departureAirport/:departureDateLocal/raw.json": {
+		id:       "flightScheduleVersionRaw",
+		summary:  "Raw Lufthansa API schedules of a single flight version",
+		tags:     []string{"flights"},
+		response: apiResponse{contentType: echo.MIMEApplicationJSON},
+	},
+	"GET /data/flight/:fn/seatmap/:departureAirport/:departureDateLocal": {
+		id:       "seatMap",
+		summary:  "Normalized seat map of a single flight",
+		tags:     []string{"seatmap"},
+		response: jsonResponse[seatmap.SeatMap](),
+	},
+	"GET /data/flight/:fn/seatmap/:departureAirport/:departureDateLocal/seatmap.svg": {
+		id:       "seatMapSVG",
+		summary:  "Seat map of a single flight rendered as SVG",
+		tags:     []string{"seatmap"},
+		query:    []openapi.Parameter{queryParam("highlight", "seat characteristics to highlight, e.g. W or E", openapi.Array(&openapi.Schema{Type: "string"}))},
+		response: rawResponse(mimeSVG),
+	},
+	"GET /data/flight/:fn/seatmap/:departureAirport/:departureDateLocal/scores/:profile": {
+		id:       "seatMapScores",
+		summary:  "Seats of a single flight ranked per cabin for a scoring profile",
+		tags:     []string{"seatmap"},
+		response: jsonResponse[[]seatmap.ScoredCabin](),
+	},
+	"GET /data/seatmap/compare": {
+		id:      "seatMapCompare",
+		summary: "Compare the latest seat maps of two aircraft configurations",
+		tags:    []string{"seatmap"},
+		query: []openapi.Parameter{
+			queryParam("a", "first configuration as airline/aircraft/configuration, e.g. LH/359/C48E21M224", &openapi.Schema{Type: "string"}),
+			queryParam("b", "second configuration as airline/aircraft/configuration, e.g. LH/359/F4C38E24M201", &openapi.Schema{Type: "string"}),
+		},
+		response: jsonResponse[seatmap.Comparison](),
+	},
+	"GET /data/seatmap/:airlineId/:aircraftId/:aircraftConfigurationVersion/versions": {
+		id:       "seatMapVersions",
+		summary:  "Known seat map versions of an aircraft configuration",
+		tags:     []string{"seatmap"},
+		response: jsonResponse[[]seatmap.Version](),
+	},
+	"GET /data/seatmap/:airlineId/:aircraftId/:aircraftConfigurationVersion/:version": {
+		id:       "seatMapVersion",
+		summary:  "Normalized seat map of an aircraft configuration version",
+		tags:     []string{"seatmap"},
+		response: jsonResponse[seatmap.SeatMap](),
+	},
+	"GET /data/seatmap/:airlineId/:aircraftId/:aircraftConfigurationVersion/diff/:from/:to": {
+		id:       "seatMapDiff",
+		summary:  "Differences between two seat map versions of an aircraft configuration",
+		tags:     []string{"seatmap"},
+		response: jsonResponse[seatmap.Diff](),
+	},
+	"GET /data/destinations/:departureAirport": {
+		id:       "destinations",
+		summary:  "Non-stop destinations of an airport",
+		tags:     []string{"reference"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: jsonResponse[[]model.Airport](),
+	},
+	"GET /data/schedule/allegris/feed.rss": {
+		id:       "allegrisRss",
+		summary:  "RSS feed of Lufthansa Allegris flights",
+		tags:     []string{"schedules", "feeds"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: rawResponse(mimeRSS),
+	},
+	"GET /data/schedule/allegris/feed.atom": {
+		id:       "allegrisAtom",
+		summary:  "Atom feed of Lufthansa Allegris flights",
+		tags:     []string{"schedules", "feeds"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: rawResponse(mimeAtom),
+	},
+	"GET /data/schedule/swiss350/feed.rss": {
+		id:       "swissA350Rss",
+		summary:  "RSS feed of Swiss A350 flights",
+		tags:     []string{"schedules", "feeds"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: rawResponse(mimeRSS),
+	},
+	"GET /data/schedule/swiss350/feed.atom": {
+		id:       "swissA350Atom",
+		summary:  "Atom feed of Swiss A350 flights",
+		tags:     []string{"schedules", "feeds"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: rawResponse(mimeAtom),
+	},
+	"GET /data/updates": {
+		id:       "globalUpdates",
+		summary:  "Number of added, updated and removed flights per version",
+		tags:     []string{"updates"},
+		response: jsonResponse[[]model.UpdateReportItem](),
+	},
+	"GET /data/updates/:version/diff": {
+		id:      "updatesDiff",
+		summary: "Flights whose schedule changed between two versions, with classified changes",
+		tags:    []string{"updates"},
+		query: []openapi.Parameter{
+			queryParam("since", "RFC3339 version timestamp to compare against; defaults to the version preceding version", openapi.String("date-time")),
+			queryParam("airlineId", "IATA codes of the airlines to include", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("airportId", "IATA or ICAO code of an airport served by the flight before or after the change", &openapi.Schema{Type: "string"}),
+			queryParam("limit", "maximum number of flights, defaults to 1000 and must not exceed 10000", openapi.Integer("int32")),
+		},
+		response: jsonResponse[updates.Diff](),
+	},
+	"GET /data/alerts": {
+		id:      "alerts",
+		summary: "Aircraft swaps and cabin downgrades of operating flights introduced by the latest versions; airlineId or both departureAirportId and arrivalAirportId are required",
+		tags:    []string{"updates"},
+		query: []openapi.Parameter{
+			queryParam("versions", "number of latest versions to include, defaults to 7 and must not exceed 14", openapi.Integer("int32")),
+			queryParam("airlineId", "IATA or ICAO codes of the operating airlines to include", openapi.Array(&openapi.Schema{Type: "string"})),
+			queryParam("departureAirportId", "IATA or ICAO code of the departure airport", &openapi.Schema{Type: "string"}),
+			queryParam("arrivalAirportId", "IATA or ICAO code of the arrival airport", &openapi.Schema{Type: "string"}),
+			queryParam("downgradesOnly", "only include alerts with a removed cabin or fewer seats", &openapi.Schema{Type: "boolean"}),
+		},
+		response: jsonResponse[[]updates.Alert](),
+	},
+	"GET /data/alerts/airline/:airlineId/feed.rss": {
+		id:       "airlineAlertsRss",
+		summary:  "RSS feed of aircraft swaps and cabin downgrades of an airline",
+		tags:     []string{"updates", "feeds"},
+		response: rawResponse(mimeRSS),
+	},
+	"GET /data/alerts/airline/:airlineId/feed.atom": {
+		id:       "airlineAlertsAtom",
+		summary:  "Atom feed of aircraft swaps and cabin downgrades of an airline",
+		tags:     []string{"updates", "feeds"},
+		response: rawResponse(mimeAtom),
+	},
+	"GET /data/alerts/airline/:airlineId/feed.json": {
+		id:       "airlineAlertsJsonFeed",
+		summary:  "JSON feed of aircraft swaps and cabin downgrades of an airline",
+		tags:     []string{"updates", "feeds"},
+		response: rawResponse(mimeJSONFeed),
+	},
+	"GET /data/alerts/route/:departureAirport/:arrivalAirport/feed.rss": {
+		id:       "routeAlertsRss",
+		summary:  "RSS feed of aircraft swaps and cabin downgrades on a route",
+		tags:     []string{"updates", "feeds"},
+		response: rawResponse(mimeRSS),
+	},
+	"GET /data/alerts/route/:departureAirport/:arrivalAirport/feed.atom": {
+		id:       "routeAlertsAtom",
+		summary:  "Atom feed of aircraft swaps and cabin downgrades on a route",
+		tags:     []string{"updates", "feeds"},
+		response: rawResponse(mimeAtom),
+	},
+	"GET /data/alerts/route/:departureAirport/:arrivalAirport/feed.json": {
+		id:       "routeAlertsJsonFeed",
+		summary:  "JSON feed of aircraft swaps and cabin downgrades on a route",
+		tags:     []string{"updates", "feeds"},
+		response: rawResponse(mimeJSONFeed),
+	},
+	"GET /data/:year/flight/:fn": {
+		id:       "flightSchedule",
+		summary:  "Latest schedule of a flight number",
+		tags:     []string{"flights"},
+		response: jsonResponse[model.FlightSchedules](),
+	},
+	"GET /data/:year/flight/:fn/:version": {
+		id:       "flightScheduleVersion",
+		summary:  "Schedule of a flight number as of a version",
+		tags:     []string{"flights"},
+		response: jsonResponse[model.FlightSchedules](),
+	},
+	"GET /data/:year/schedule/allegris": {
+		id:       "allegris",
+		summary:  "Lufthansa Allegris flights",
+		tags:     []string{"schedules"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: jsonResponse[model.FlightSchedulesMany](),
+	},
+	"GET /data/:year/schedule/swiss350": {
+		id:       "swissA350",
+		summary:  "Swiss A350 flights",
+		tags:     []string{"schedules"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: jsonResponse[model.FlightSchedulesMany](),
+	},
+	"GET /data/:year/schedule/lh380": {
+		id:       "lufthansaA380",
+		summary:  "Lufthansa A380 flights",
+		tags:     []string{"schedules"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: jsonResponse[model.FlightSchedulesMany](),
+	},
+	"GET /data/:year/schedule/lh340": {
+		id:       "lufthansaA340",
+		summary:  "Lufthansa A340 flights",
+		tags:     []string{"schedules"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: jsonResponse[model.FlightSchedulesMany](),
+	},
+	"GET /data/:year/schedule/lh747": {
+		id:       "lufthansa747",
+		summary:  "Lufthansa 747 flights",
+		tags:     []string{"schedules"},
+		query:    []openapi.Parameter{asOfQueryParam},
+		response: jsonResponse[model.FlightSchedulesMany](),
+	},
+	"GET /data/:fn/:departureDate/:departureAirport/feed.rss": {
+		id:         "legacyFlightScheduleVersionsRss",
+		summary:    "Deprecated RSS feed of the versions of a single flight",
+		tags:       []string{"flights", "feeds"},
+		deprecated: true,
+		response:   rawResponse(mimeRSS),
+	},
+	"GET /data/:fn/:departureDate/:departureAirport/feed.atom": {
+		id:         "legacyFlightScheduleVersionsAtom",
+		summary:    "Deprecated Atom feed of the versions of a single flight",
+		tags:       []string{"flights", "feeds"},
+		deprecated: true,
+		response:   rawResponse(mimeAtom),
+	},
+	"GET /data/sitemap.xml": {
+		id:       "sitemapIndex",
+		summary:  "Sitemap index",
+		tags:     []string{"meta"},
+		response: rawResponse(echo.MIMEApplicationXMLCharsetUTF8),
+	},
+	"GET /data/sitemap/:airlineId/sitemap.xml": {
+		id:       "sitemapAirline",
+		summary:  "Sitemap of an airline",
+		tags:     []string{"meta"},
+		response: rawResponse(echo.MIMEApplicationXMLCharsetUTF8),
+	},
+	// endregion
+}
+
+type OpenAPIHandler struct {
+	version func() string
+	routes  func() []*echo.Route
+	mtx     sync.Mutex
+	// docVersion is the dataset version doc was built for
+	docVersion string
+	doc        []byte
+}
+
+func NewOpenAPIHandler(version func() string, routes func() []*echo.Route) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		version: version,
+		routes:  routes,
+	}
+}
+
+func (h *OpenAPIHandler) OpenAPI(c echo.Context) error {
+	doc, err := h.document(h.version())
+	if err != nil {
+		return err
+	}
+
+	addExpirationHeaders(c, time.Now(), time.Hour)
+	return c.JSONBlob(http.StatusOK, doc)
+}
+
+// document returns the cached document, rebuilt if the dataset has been reloaded since.
+// Routes are only complete once the server is running, so the document is built lazily.
+func (h *OpenAPIHandler) document(version string) ([]byte, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.doc == nil || h.docVersion != version {
+		doc, err := json.Marshal(BuildOpenAPIDocument(version, h.routes()))
+		if err != nil {
+			return nil, err
+		}
+
+		h.doc = doc
+		h.docVersion = version
+	}
+
+	return h.doc, nil
+}
+
+func BuildOpenAPIDocument(version string, routes []*echo.Route) openapi.Document {
+	g := newOpenAPIGenerator()
+	doc := openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       "explore.flights",
+			Description: "Public API of explore.flights",
+			Version:     version,
+		},
+		Paths: make(map[string]openapi.PathItem),
+	}
+
+	routes = slices.Clone(routes)
+	slices.SortFunc(routes, func(a, b *echo.Route) int {
+		return strings.Compare(a.Path+" "+a.Method, b.Path+" "+b.Method)
+	})
+
+	for _, route := range routes {
+		// groups with middleware register catch-all routes answering 404
+		if route.Method == echo.RouteNotFound || (!strings.HasPrefix(route.Path, "/api/") && !strings.HasPrefix(route.Path, "/data/")) {
+			continue
+		}
+
+		apiOp, ok := apiOperations[route.Method+" "+route.Path]
+		if !ok {
+			apiOp = apiOperation{
+				id:       strings.ToLower(route.Method) + strings.NewReplacer("/", "_", ":", "", ".", "_").Replace(route.Path),
+				response: apiResponse{contentType: "*/*"},
+			}
+		}
+
+		path, params := openAPIPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(openapi.PathItem)
+			doc.Paths[path] = item
+		}
+
+		item[strings.ToLower(route.Method)] = buildOpenAPIOperation(g, apiOp, params)
+	}
+
+	doc.Components = g.Components()
+	return doc
+}
+
+func buildOpenAPIOperation(g *openapi.Generator, apiOp apiOperation, pathParams []string) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationId: apiOp.id,
+		Summary:     apiOp.summary,
+		Tags:        apiOp.tags,
+		Deprecated:  apiOp.deprecated,
+		Parameters:  make([]openapi.Parameter, 0, len(pathParams)+len(apiOp.query)),
+		Responses: map[string]openapi.Response{
+			"400": {Description: "Bad Request"},
+			"404": {Description: "Not Found"},
+			"500": {Description: "Internal Server Error"},
+		},
+	}
+
+	for _, name := range pathParams {
+		param, ok := pathParameters[name]
+		if !ok {
+			param.Schema = &openapi.Schema{Type: "string"}
+		}
+
+		param.Name = name
+		param.In = "path"
+		param.Required = true
+
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	op.Parameters = append(op.Parameters, apiOp.query...)
+
+	if apiOp.requestBody != nil {
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				echo.MIMEApplicationJSON: {Schema: g.SchemaFor(apiOp.requestBody)},
+			},
+		}
+	}
+
+	content := make(map[string]openapi.MediaType, 1+len(apiOp.altResponses))
+	for _, r := range append([]apiResponse{apiOp.response}, apiOp.altResponses...) {
+		content[r.contentType] = openapi.MediaType{Schema: responseSchema(g, r)}
+	}
+
+	op.Responses["200"] = openapi.Response{
+		Description: "OK",
+		Content:     content,
+	}
+
+	return op
+}
+
+func responseSchema(g *openapi.Generator, r apiResponse) *openapi.Schema {
+	if r.body != nil {
+		return g.SchemaFor(r.body)
+	} else if r.contentType == echo.MIMEApplicationJSON {
+		return &openapi.Schema{}
+	}
+
+	return openapi.Binary()
+}
+
+func openAPIPath(echoPath string) (string, []string) {
+	parts := strings.Split(echoPath, "/")
+	params := make([]string, 0)
+
+	for i, part := range parts {
+		if name, ok := strings.CutPrefix(part, ":"); ok {
+			parts[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+
+	return strings.Join(parts, "/"), params
+}
+
+func newOpenAPIGenerator() *openapi.Generator {
+	g := openapi.NewGenerator()
+	g.Override(reflect.TypeFor[model.UUID](), func(g *openapi.Generator) *openapi.Schema {
+		return &openapi.Schema{Type: "string", Description: "base62 encoded UUID"}
+	})
+
+	g.Override(reflect.TypeFor[xtime.LocalDate](), func(g *openapi.Generator) *openapi.Schema {
+		return openapi.String("date")
+	})
+
+	g.Override(reflect.TypeFor[xtime.LocalTime](), func(g *openapi.Generator) *openapi.Schema {
+		return &openapi.Schema{Type: "string", Pattern: "^[0-9]{2}:[0-9]{2}:[0-9]{2}$"}
+	})
+
+	g.Override(reflect.TypeFor[model.Aircraft](), func(g *openapi.Generator) *openapi.Schema {
+		return &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"id":             {Type: "string"},
+				"type":           {Type: "string", Enum: []any{"aircraft", "family"}},
+				"iataCode":       {Type: "string"},
+				"icaoCode":       {Type: "string"},
+				"name":           {Type: "string"},
+				"parentFamilyId": {Type: "string"},
+				"configurations": {Type: "object", AdditionalProperties: openapi.Array(&openapi.Schema{Type: "string"})},
+			},
+			Required: []string{"id", "type", "iataCode", "name", "configurations"},
+		}
+	})
+
+	return g
+}
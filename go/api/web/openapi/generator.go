@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	emptyStructType   = reflect.TypeFor[struct{}]()
+)
+
+type Generator struct {
+	overrides map[reflect.Type]func(g *Generator) *Schema
+	names     map[reflect.Type]string
+	schemas   map[string]*Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		overrides: make(map[reflect.Type]func(g *Generator) *Schema),
+		names:     make(map[reflect.Type]string),
+		schemas:   make(map[string]*Schema),
+	}
+}
+
+// Override registers a fixed schema for types whose json representation can't be derived from their fields
+func (g *Generator) Override(t reflect.Type, fn func(g *Generator) *Schema) {
+	g.overrides[t] = fn
+}
+
+func (g *Generator) Components() Components {
+	return Components{Schemas: g.schemas}
+}
+
+func (g *Generator) SchemaFor(t reflect.Type) *Schema {
+	if fn, ok := g.overrides[t]; ok {
+		if t.Name() == "" {
+			return fn(g)
+		}
+
+		return g.named(t, fn)
+	}
+
+	if t.Kind() == reflect.Pointer {
+		return g.SchemaFor(t.Elem())
+	}
+
+	if t == timeType {
+		return String("date-time")
+	}
+
+	if !t.Implements(jsonMarshalerType) && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) && t.Kind() != reflect.Map {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return Integer("int32")
+
+	case reflect.Int64, reflect.Uint64:
+		return Integer("int64")
+
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+
+	case reflect.String:
+		return &Schema{Type: "string"}
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return String("byte")
+		}
+
+		return Array(g.SchemaFor(t.Elem()))
+
+	case reflect.Array:
+		s := Array(g.SchemaFor(t.Elem()))
+		size := t.Len()
+		s.MinItems = &size
+		s.MaxItems = &size
+		return s
+
+	case reflect.Map:
+		// sets (map[T]struct{}) are marshalled as json arrays
+		if t.Elem() == emptyStructType {
+			return Array(g.SchemaFor(t.Key()))
+		}
+
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: g.SchemaFor(t.Elem()),
+		}
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+
+		return g.named(t, func(g *Generator) *Schema {
+			return g.structSchema(t)
+		})
+	}
+
+	return &Schema{}
+}
+
+func (g *Generator) named(t reflect.Type, fn func(g *Generator) *Schema) *Schema {
+	if name, ok := g.names[t]; ok {
+		return Ref(name)
+	}
+
+	name := g.schemaName(t)
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *fn(g)
+
+	return Ref(name)
+}
+
+func (g *Generator) schemaName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i != -1 {
+		name = name[:i]
+	}
+
+	name = strings.ToUpper(name[:1]) + name[1:]
+	if _, ok := g.schemas[name]; !ok {
+		return name
+	}
+
+	pkg := t.PkgPath()
+	if i := strings.LastIndexByte(pkg, '/'); i != -1 {
+		pkg = pkg[i+1:]
+	}
+
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + name
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	g.addFields(s, t)
+	return s
+}
+
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = g.SchemaFor(f.Type)
+
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") && f.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/explore-flights/monorepo/go/api/web/openapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiOperationIdsAreUnique(t *testing.T) {
+	ids := make(map[string]string)
+	for route, op := range apiOperations {
+		other, ok := ids[op.id]
+		assert.False(t, ok, "operation id %q used by %q and %q", op.id, route, other)
+		ids[op.id] = route
+	}
+}
+
+func TestBuildOpenAPIDocument(t *testing.T) {
+	e := echo.New()
+	noop := func(c echo.Context) error { return nil }
+	e.GET("/data/:year/flight/:fn", noop)
+	e.GET("/data/unknown/:id", noop)
+	e.POST("/api/connections/json", noop)
+	e.GET("/api/schedule/search", noop)
+	e.GET("/internal", noop)
+
+	doc := BuildOpenAPIDocument("2026-01-01T00:00:00Z", e.Routes())
+	assert.Len(t, doc.Paths, 4)
+
+	op := doc.Paths["/data/{year}/flight/{fn}"]["get"]
+	require.NotNil(t, op)
+	assert.Equal(t, "flightSchedule", op.OperationId)
+	require.Len(t, op.Parameters, 2)
+	assert.Equal(t, "year", op.Parameters[0].Name)
+	assert.Equal(t, "path", op.Parameters[0].In)
+	assert.True(t, op.Parameters[0].Required)
+	assert.Equal(t, "#/components/schemas/FlightSchedules", op.Responses["200"].Content[echo.MIMEApplicationJSON].Schema.Ref)
+
+	op = doc.Paths["/data/unknown/{id}"]["get"]
+	require.NotNil(t, op)
+	assert.Equal(t, "get_data_unknown_id", op.OperationId)
+
+	op = doc.Paths["/api/connections/json"]["post"]
+	require.NotNil(t, op)
+	require.NotNil(t, op.RequestBody)
+	assert.Equal(t, "#/components/schemas/ConnectionsSearchRequest", op.RequestBody.Content[echo.MIMEApplicationJSON].Schema.Ref)
+
+	op = doc.Paths["/api/schedule/search"]["get"]
+	require.NotNil(t, op)
+	assert.Equal(t, "#/components/schemas/FlightSchedulesMany", op.Responses["200"].Content[echo.MIMEApplicationJSON].Schema.Ref)
+	assert.Equal(t, "binary", op.Responses["200"].Content[echo.MIMETextPlain].Schema.Format)
+	assert.True(t, slices.ContainsFunc(op.Parameters, func(p openapi.Parameter) bool { return p.Name == "format" }))
+
+	for _, name := range []string{"FlightSchedules", "FlightScheduleVariant", "Aircraft", "ConnectionsSearchRequest"} {
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+
+	variant := doc.Components.Schemas["FlightScheduleVariant"]
+	assert.Equal(t, "#/components/schemas/UUID", variant.Properties["id"].Ref)
+	assert.Equal(t, "string", doc.Components.Schemas["UUID"].Type)
+	assert.Equal(t, "array", variant.Properties["codeShares"].Type)
+	assert.Equal(t, "object", variant.Properties["dataElements"].Type)
+}
+
+func TestApiOperationsCoverRegisteredRoutes(t *testing.T) {
+	e := echo.New()
+	RegisterRoutes(e, struct{ Repo }{}, func() string { return "2026-01-01T00:00:00Z" }, nil, nil)
+
+	routes := e.Routes()
+	require.NotEmpty(t, routes)
+
+	missing := make([]string, 0)
+	for _, route := range routes {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+
+		if _, ok := apiOperations[route.Method+" "+route.Path]; !ok {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+
+	assert.Empty(t, missing, "routes without apiOperations entry")
+}
+
+func TestOpenAPIHandlerFollowsDatasetVersion(t *testing.T) {
+	e := echo.New()
+	version := "2026-01-01T00:00:00Z"
+	h := NewOpenAPIHandler(func() string { return version }, e.Routes)
+	e.GET("/api/openapi.json", h.OpenAPI)
+
+	infoVersion := func() string {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var doc openapi.Document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		return doc.Info.Version
+	}
+
+	assert.Equal(t, "2026-01-01T00:00:00Z", infoVersion())
+
+	version = "2026-01-02T00:00:00Z"
+	assert.Equal(t, "2026-01-02T00:00:00Z", infoVersion())
+}
@@ -0,0 +1,302 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/analytics"
+	"github.com/explore-flights/monorepo/go/api/business/connections"
+	"github.com/explore-flights/monorepo/go/api/web/model"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/labstack/echo/v4"
+)
+
+type reachabilityRequest struct {
+	origin              string
+	minDeparture        time.Time
+	maxDeparture        time.Time
+	maxFlights          uint32
+	minLayover          time.Duration
+	maxDuration         time.Duration
+	excludeAirport      []string
+	excludeFlightNumber []string
+	excludeAircraft     []string
+}
+
+func (ch *ConnectionsHandler) ReachabilityJSON(c echo.Context) error {
+	ctx := c.Request().Context()
+	req, reachable, err := ch.reachability(c)
+	if err != nil {
+		return err
+	}
+
+	airports, err := ch.repo.Airports(ctx)
+	if err != nil {
+		return err
+	}
+
+	referencedAirlines := make(common.Set[string])
+	referencedAirports := make(common.Set[string])
+	referencedAirports.Add(req.origin)
+
+	res := model.ReachabilityResponse{
+		Origin:    req.origin,
+		Reachable: make([]model.ReachableAirport, 0, len(reachable)),
+		Airports:  make(map[string]model.Airport),
+	}
+
+	for _, r := range reachable {
+		flights := make([]model.ConnectionFlightResponse, 0, len(r.Flights))
+		for _, f := range r.Flights {
+			referencedAirports.Add(f.DepartureAirportIataCode)
+			referencedAirports.Add(f.ArrivalAirportIataCode)
+			flights = append(flights, ch.convertFlight(f, referencedAirlines))
+		}
+
+		res.Reachable = append(res.Reachable, model.ReachableAirport{
+			AirportIataCode: r.AirportIataCode,
+			ArrivalTime:     r.ArrivalTime,
+			DurationMS:      r.ArrivalTime.Sub(r.Flights[0].DepartureTime).Milliseconds(),
+			Flights:         flights,
+		})
+	}
+
+	for iataCode := range referencedAirports {
+		if airport, ok := airports[iataCode]; ok {
+			res.Airports[iataCode] = model.AirportFromDb(airport)
+		}
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// ReachabilityGeoJSON returns a FeatureCollection with a Point for the origin and every reachable airport
+// and a LineString along the airports of the path to every reachable airport. Features of unknown airports have no geometry.
+func (ch *ConnectionsHandler) ReachabilityGeoJSON(c echo.Context) error {
+	ctx := c.Request().Context()
+	req, reachable, err := ch.reachability(c)
+	if err != nil {
+		return err
+	}
+
+	airports, err := ch.repo.Airports(ctx)
+	if err != nil {
+		return err
+	}
+
+	point := func(iataCode string) *analytics.GeoJSONGeometry {
+		airport, ok := airports[iataCode]
+		if !ok {
+			return nil
+		}
+
+		return &analytics.GeoJSONGeometry{
+			Type:        "Point",
+			Coordinates: [2]float64{airport.Lng, airport.Lat},
+		}
+	}
+
+	fc := analytics.GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]analytics.GeoJSONFeature, 0, len(reachable)*2+1),
+	}
+
+	fc.Features = append(fc.Features, analytics.GeoJSONFeature{
+		Type:     "Feature",
+		Geometry: point(req.origin),
+		Properties: map[string]any{
+			"type":      "origin",
+			"airportId": req.origin,
+			"name":      airports[req.origin].Name,
+		},
+	})
+
+	for _, r := range reachable {
+		flightNumbers := make([]string, 0, len(r.Flights))
+		path := make([][2]float64, 0, len(r.Flights)+1)
+		located := true
+
+		for i, f := range r.Flights {
+			flightNumbers = append(flightNumbers, f.FlightNumber.String())
+
+			iataCodes := []string{f.ArrivalAirportIataCode}
+			if i == 0 {
+				iataCodes = []string{f.DepartureAirportIataCode, f.ArrivalAirportIataCode}
+			}
+
+			for _, iataCode := range iataCodes {
+				if airport, ok := airports[iataCode]; ok {
+					path = append(path, [2]float64{airport.Lng, airport.Lat})
+				} else {
+					located = false
+				}
+			}
+		}
+
+		properties := map[string]any{
+			"airportId":       r.AirportIataCode,
+			"name":            airports[r.AirportIataCode].Name,
+			"departureTime":   r.Flights[0].DepartureTime,
+			"arrivalTime":     r.ArrivalTime,
+			"durationMinutes": int(r.ArrivalTime.Sub(r.Flights[0].DepartureTime).Minutes()),
+			"flights":         len(r.Flights),
+			"flightNumbers":   flightNumbers,
+		}
+
+		airportProperties := maps.Clone(properties)
+		airportProperties["type"] = "airport"
+
+		fc.Features = append(fc.Features, analytics.GeoJSONFeature{
+			Type:       "Feature",
+			Geometry:   point(r.AirportIataCode),
+			Properties: airportProperties,
+		})
+
+		properties["type"] = "path"
+
+		var geometry *analytics.GeoJSONGeometry
+		if located {
+			geometry = &analytics.GeoJSONGeometry{
+				Type:        "LineString",
+				Coordinates: path,
+			}
+		}
+
+		fc.Features = append(fc.Features, analytics.GeoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geometry,
+			Properties: properties,
+		})
+	}
+
+	b, err := json.Marshal(fc)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, mimeGeoJSON, b)
+}
+
+func (ch *ConnectionsHandler) reachability(c echo.Context) (reachabilityRequest, []connections.Reachable, error) {
+	ctx := c.Request().Context()
+	req, err := ch.parseReachabilityRequest(c)
+	if err != nil {
+		return req, nil, err
+	}
+
+	options := make([]connections.SearchOption, 0)
+	options = appendSliceOptions[connections.WithExcludeAirport, connections.WithExcludeAirportGlob](options, req.excludeAirport)
+	options = appendSliceOptions[connections.WithExcludeFlightNumber, connections.WithExcludeFlightNumberGlob](options, req.excludeFlightNumber)
+	options = appendSliceOptions[connections.WithExcludeAircraft, connections.WithExcludeAircraftGlob](options, req.excludeAircraft)
+
+	if asOf := requestContextAsOf(ctx); asOf != nil {
+		options = append(options, connections.WithAsOf(*asOf))
+	}
+
+	reachable, err := ch.search.Reachability(
+		ctx,
+		req.origin,
+		req.minDeparture,
+		req.maxDeparture,
+		req.maxFlights,
+		req.minLayover,
+		req.maxDuration,
+		options...,
+	)
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return req, nil, NewHTTPError(http.StatusRequestTimeout, WithCause(err))
+		}
+
+		return req, nil, err
+	}
+
+	return req, reachable, nil
+}
+
+func (ch *ConnectionsHandler) parseReachabilityRequest(c echo.Context) (reachabilityRequest, error) {
+	ctx := c.Request().Context()
+	req := reachabilityRequest{
+		maxFlights:  2,
+		minLayover:  time.Hour,
+		maxDuration: time.Hour * 24,
+	}
+
+	var err error
+	if req.origin, err = (util{}).parseAirport(ctx, c.Param("airportId"), ch.repo.Airports); err != nil {
+		return req, NewHTTPError(http.StatusNotFound, WithCause(err))
+	}
+
+	badRequest := func(err error) error {
+		return NewHTTPError(http.StatusBadRequest, WithCause(err), WithUnmaskedCause())
+	}
+
+	if req.minDeparture, err = time.Parse(time.RFC3339, c.QueryParam("minDeparture")); err != nil {
+		return req, badRequest(fmt.Errorf("invalid minDeparture: %w", err))
+	}
+
+	req.maxDeparture = req.minDeparture.Add(time.Hour * 24)
+	if raw := c.QueryParam("maxDeparture"); raw != "" {
+		if req.maxDeparture, err = time.Parse(time.RFC3339, raw); err != nil {
+			return req, badRequest(fmt.Errorf("invalid maxDeparture: %w", err))
+		}
+	}
+
+	if raw := c.QueryParam("maxFlights"); raw != "" {
+		v, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return req, badRequest(fmt.Errorf("invalid maxFlights: %w", err))
+		}
+
+		req.maxFlights = uint32(v)
+	}
+
+	if raw := c.QueryParam("minLayover"); raw != "" {
+		if req.minLayover, err = time.ParseDuration(raw); err != nil {
+			return req, badRequest(fmt.Errorf("invalid minLayover: %w", err))
+		}
+	}
+
+	if raw := c.QueryParam("maxDuration"); raw != "" {
+		if req.maxDuration, err = time.ParseDuration(raw); err != nil {
+			return req, badRequest(fmt.Errorf("invalid maxDuration: %w", err))
+		}
+	}
+
+	req.excludeAirport = c.QueryParams()["excludeAirport"]
+	req.excludeFlightNumber = c.QueryParams()["excludeFlightNumber"]
+	req.excludeAircraft = c.QueryParams()["excludeAircraft"]
+
+	if err = validateReachabilityRequest(req); err != nil {
+		return req, badRequest(err)
+	}
+
+	return req, nil
+}
+
+func validateReachabilityRequest(req reachabilityRequest) error {
+	if req.maxFlights < 1 || req.maxFlights > 4 {
+		return errors.New("maxFlights must be between 1 and 4")
+	} else if req.maxDeparture.Before(req.minDeparture) {
+		return errors.New("maxDeparture must not be before minDeparture")
+	} else if req.minLayover < 0 || req.maxDuration <= 0 {
+		return errors.New("minLayover must not be negative and maxDuration must be positive")
+	} else if req.maxDeparture.Add(req.maxDuration).Sub(req.minDeparture) > time.Hour*24*14 {
+		return errors.New("range must be <=14d")
+	} else if len(req.excludeAirport) > 100 {
+		return errors.New("len(excludeAirport) must be <= 100")
+	} else if len(req.excludeFlightNumber) > 100 {
+		return errors.New("len(excludeFlightNumber) must be <= 100")
+	} else if len(req.excludeAircraft) > 100 {
+		return errors.New("len(excludeAircraft) must be <= 100")
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/api/business/analytics"
+	"github.com/explore-flights/monorepo/go/api/business/connections"
+	"github.com/explore-flights/monorepo/go/api/business/raw"
+	"github.com/explore-flights/monorepo/go/api/business/schedulesearch"
+	"github.com/explore-flights/monorepo/go/api/business/seatmap"
+	"github.com/explore-flights/monorepo/go/api/business/updates"
+	"github.com/explore-flights/monorepo/go/api/db"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/labstack/echo/v4"
+)
+
+// Repo is the flight repository required by all handlers and searches registered by RegisterRoutes
+type Repo interface {
+	dataHandlerRepo
+	gameHandlerRepo
+	graphQLHandlerRepo
+	searchHandlerRepo
+	sitemapHandlerRepo
+	Flights(ctx context.Context, start, end xtime.LocalDate, asOf *time.Time) (map[xtime.LocalDate][]db.Flight, error)
+	FlightSchedulesLatestRaw(ctx context.Context, filter db.Condition, asOf *time.Time) (db.FlightSchedulesMany, error)
+	RouteStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.RouteStatistic, error)
+	GroupStatistics(ctx context.Context, start, end xtime.LocalDate, grouping db.AnalyticsGrouping, filter db.Condition) ([]db.GroupStatistic, error)
+	UpdatesDiff(ctx context.Context, since, version time.Time, filter db.Condition, limit int) (db.FlightScheduleDiff, error)
+	EquipmentChanges(ctx context.Context, since time.Time, filter db.Condition) (db.EquipmentChanges, error)
+}
+
+// RegisterRoutes registers every route of the HTTP API. The OpenAPI document is built from the routes registered here.
+func RegisterRoutes(e *echo.Echo, repo Repo, version func() string, smSearch *seatmap.Search, rawSearch *raw.Search) {
+	connSearch := connections.NewSearch(repo)
+	sshHandler := NewScheduleSearchHandler(repo, schedulesearch.NewSearch(repo))
+
+	// only mounted on routes answering point-in-time requests, all others would silently ignore asOf
+	asOf := AsOfMiddleware()
+
+	{
+		group := e.Group("/api")
+
+		connWebHandler := NewConnectionsHandler(repo, connSearch)
+		group.POST("/connections/json", connWebHandler.ConnectionsJSON, asOf)
+		group.GET("/connections/json/:payload", connWebHandler.ConnectionsJSON, asOf)
+		group.POST("/connections/png", connWebHandler.ConnectionsPNG, asOf)
+		group.GET("/connections/png/:payload/c.png", connWebHandler.ConnectionsPNG, asOf)
+		group.POST("/connections/share", connWebHandler.ConnectionsShareCreate, asOf)
+		group.GET("/connections/share/:payload", connWebHandler.ConnectionsShareHTML)
+		group.GET("/connections/reachability/:airportId", connWebHandler.ReachabilityJSON, asOf)
+		group.GET("/connections/reachability/:airportId/reachability.geojson", connWebHandler.ReachabilityGeoJSON, asOf)
+
+		searchHandler := NewSearchHandler(repo)
+		group.GET("/search", searchHandler.Search)
+
+		group.GET("/schedule/search", sshHandler.Query, asOf)
+
+		analyticsSearch := analytics.NewSearch(repo)
+		analyticsHandler := NewAnalyticsHandler(repo, analyticsSearch)
+		group.GET("/analytics/frequency", analyticsHandler.RouteFrequencies)
+		group.GET("/analytics/capacity", analyticsHandler.Capacity)
+		group.GET("/analytics/busiest-routes", analyticsHandler.BusiestRoutes)
+		group.GET("/analytics/route-changes", analyticsHandler.RouteChanges)
+
+		networkHandler := NewNetworkHandler(repo, analyticsSearch)
+		group.GET("/network/airline/:airlineId/network.geojson", networkHandler.AirlineGeoJSON)
+		group.GET("/network/airline/:airlineId/network.graphml", networkHandler.AirlineGraphML)
+		group.GET("/network/airline/:airlineId/network.csv", networkHandler.AirlineCSV)
+		group.GET("/network/airport/:airportId/network.geojson", networkHandler.AirportGeoJSON)
+		group.GET("/network/airport/:airportId/network.graphml", networkHandler.AirportGraphML)
+		group.GET("/network/airport/:airportId/network.csv", networkHandler.AirportCSV)
+
+		gameHandler := NewGameHandler(repo)
+		group.GET("/game/connection", gameHandler.ConnectionGame)
+
+		notificationHandler := NewNotificationHandler(version)
+		group.GET("/notifications", notificationHandler.Notifications)
+
+		graphQLHandler := NewGraphQLHandler(repo)
+		group.GET("/graphql", graphQLHandler.Query)
+		group.POST("/graphql", graphQLHandler.Query)
+
+		openApiHandler := NewOpenAPIHandler(version, e.Routes)
+		group.GET("/openapi.json", openApiHandler.OpenAPI)
+	}
+
+	{
+		group := e.Group("/data")
+
+		updSearch := updates.NewSearch(repo)
+		dh := NewDataHandler(repo, smSearch, rawSearch, updSearch)
+		group.GET("/airlines.json", dh.Airlines)
+		group.GET("/airports.json", dh.Airports)
+		group.GET("/aircraft.json", dh.Aircraft)
+		group.GET("/flight/:fn/versions/:departureAirport/:departureDateLocal", dh.FlightScheduleVersions)
+		group.GET("/flight/:fn/versions/:departureAirport/:departureDateLocal/feed.rss", dh.FlightScheduleVersionsRSSFeed)
+		group.GET("/flight/:fn/versions/:departureAirport/:departureDateLocal/feed.atom", dh.FlightScheduleVersionsAtomFeed)
+		group.GET("/flight/:fn/:version/:departureAirport/:departureDateLocal/raw.json", dh.FlightScheduleVersionRaw)
+		group.GET("/flight/:fn/seatmap/:departureAirport/:departureDateLocal", dh.SeatMap)
+		group.GET("/flight/:fn/seatmap/:departureAirport/:departureDateLocal/seatmap.svg", dh.SeatMapSVG)
+		group.GET("/flight/:fn/seatmap/:departureAirport/:departureDateLocal/scores/:profile", dh.SeatMapScores)
+		group.GET("/seatmap/compare", dh.SeatMapCompare)
+		group.GET("/seatmap/:airlineId/:aircraftId/:aircraftConfigurationVersion/versions", dh.SeatMapVersions)
+		group.GET("/seatmap/:airlineId/:aircraftId/:aircraftConfigurationVersion/diff/:from/:to", dh.SeatMapDiff)
+		group.GET("/seatmap/:airlineId/:aircraftId/:aircraftConfigurationVersion/:version", dh.SeatMapVersion)
+		group.GET("/destinations/:departureAirport", dh.Destinations, asOf)
+		group.GET("/schedule/allegris/feed.rss", sshHandler.AllegrisRSSFeed, asOf)
+		group.GET("/schedule/allegris/feed.atom", sshHandler.AllegrisAtomFeed, asOf)
+		group.GET("/schedule/swiss350/feed.rss", sshHandler.SwissA350RSSFeed, asOf)
+		group.GET("/schedule/swiss350/feed.atom", sshHandler.SwissA350AtomFeed, asOf)
+		group.GET("/updates", dh.GlobalUpdates)
+		group.GET("/updates/:version/diff", dh.UpdatesDiff)
+
+		alertsHandler := NewAlertsHandler(repo, updSearch)
+		group.GET("/alerts", alertsHandler.Alerts)
+		group.GET("/alerts/airline/:airlineId/feed.rss", alertsHandler.AirlineRSSFeed)
+		group.GET("/alerts/airline/:airlineId/feed.atom", alertsHandler.AirlineAtomFeed)
+		group.GET("/alerts/airline/:airlineId/feed.json", alertsHandler.AirlineJSONFeed)
+		group.GET("/alerts/route/:departureAirport/:arrivalAirport/feed.rss", alertsHandler.RouteRSSFeed)
+		group.GET("/alerts/route/:departureAirport/:arrivalAirport/feed.atom", alertsHandler.RouteAtomFeed)
+		group.GET("/alerts/route/:departureAirport/:arrivalAirport/feed.json", alertsHandler.RouteJSONFeed)
+
+		{
+			group := group.Group("/:year", YearMiddleware())
+			group.GET("/flight/:fn", dh.FlightSchedule)
+			group.GET("/flight/:fn/:version", dh.FlightSchedule)
+			group.GET("/schedule/allegris", sshHandler.Allegris, asOf)
+			group.GET("/schedule/swiss350", sshHandler.SwissA350, asOf)
+			group.GET("/schedule/lh380", sshHandler.LHA380, asOf)
+			group.GET("/schedule/lh340", sshHandler.LHA340, asOf)
+			group.GET("/schedule/lh747", sshHandler.LH747, asOf)
+		}
+
+		// region deprecated feed endpoints
+		group.GET("/:fn/:departureDate/:departureAirport/feed.rss", dh.LegacyFlightScheduleVersionsRSSFeed)
+		group.GET("/:fn/:departureDate/:departureAirport/feed.atom", dh.LegacyFlightScheduleVersionsAtomFeed)
+		// endregion
+
+		sitemapHandler := NewSitemapHandler(repo)
+		group.GET("/sitemap.xml", sitemapHandler.SitemapIndex)
+		group.GET("/sitemap/:airlineId/sitemap.xml", sitemapHandler.SitemapAirline)
+	}
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/explore-flights/monorepo/go/api/db"
 	"github.com/explore-flights/monorepo/go/api/web/model"
 	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/ssim"
 	"github.com/explore-flights/monorepo/go/common/xtime"
 	"github.com/gorilla/feeds"
 	"github.com/labstack/echo/v4"
@@ -119,6 +121,17 @@ func (h *ScheduleSearchHandler) Query(c echo.Context) error {
 		return NewHTTPError(http.StatusBadRequest, WithMessage("too few filters"))
 	}
 
+	switch c.QueryParam("format") {
+	case "", "json":
+		break
+
+	case "ssim":
+		return h.querySSIM(c, schedulesearch.WithAll(conditions...))
+
+	default:
+		return NewHTTPError(http.StatusBadRequest, WithMessage("unsupported format"))
+	}
+
 	result, err := h.queryInternal(ctx, schedulesearch.WithAll(conditions...))
 	if err != nil {
 		return err
@@ -127,6 +140,22 @@ func (h *ScheduleSearchHandler) Query(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+func (h *ScheduleSearchHandler) querySSIM(c echo.Context, condition schedulesearch.Condition) error {
+	result, err := h.queryRaw(c.Request().Context(), condition)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = ssim.Write(&buf, schedulesearch.ToSSIM(result, xtime.NewLocalDate(time.Now().UTC()))); err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="schedules.ssim"`)
+
+	return c.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, buf.Bytes())
+}
+
 func (h *ScheduleSearchHandler) Allegris(c echo.Context) error {
 	ctx := c.Request().Context()
 	result, err := h.queryAllegris(ctx)
@@ -491,6 +520,28 @@ func (h *ScheduleSearchHandler) querySpecialAircraft(ctx context.Context, airlin
 	)
 }
 
+func (h *ScheduleSearchHandler) queryRaw(ctx context.Context, condition schedulesearch.Condition) (db.FlightSchedulesMany, error) {
+	conditions := []schedulesearch.Condition{
+		schedulesearch.WithAny(
+			schedulesearch.WithServiceType("J"),
+			schedulesearch.WithServiceType("U"),
+		),
+		schedulesearch.WithIgnoreCodeShares(),
+		condition,
+	}
+	if year, ok := requestContextYear(ctx); ok {
+		minDepartureDate := xtime.NewLocalDateFromParts(year, time.January, 1)
+		maxDepartureDate := xtime.NewLocalDateFromParts(year+1, time.January, 1)
+		conditions = append(conditions, schedulesearch.WithDepartureDateRangeLocal(minDepartureDate, maxDepartureDate))
+	}
+
+	return h.search.QuerySchedules(
+		ctx,
+		schedulesearch.WithAll(conditions...),
+		requestContextAsOf(ctx),
+	)
+}
+
 func (h *ScheduleSearchHandler) queryInternal(ctx context.Context, condition schedulesearch.Condition) (model.FlightSchedulesMany, error) {
 	var dbResult db.FlightSchedulesMany
 	var airlines map[string]db.Airline
@@ -502,24 +553,7 @@ func (h *ScheduleSearchHandler) queryInternal(ctx context.Context, condition sch
 
 		g.Go(func() error {
 			var err error
-			conditions := []schedulesearch.Condition{
-				schedulesearch.WithAny(
-					schedulesearch.WithServiceType("J"),
-					schedulesearch.WithServiceType("U"),
-				),
-				schedulesearch.WithIgnoreCodeShares(),
-				condition,
-			}
-			if year, ok := requestContextYear(ctx); ok {
-				minDepartureDate := xtime.NewLocalDateFromParts(year, time.January, 1)
-				maxDepartureDate := xtime.NewLocalDateFromParts(year+1, time.January, 1)
-				conditions = append(conditions, schedulesearch.WithDepartureDateRangeLocal(minDepartureDate, maxDepartureDate))
-			}
-
-			dbResult, err = h.search.QuerySchedules(
-				ctx,
-				schedulesearch.WithAll(conditions...),
-			)
+			dbResult, err = h.queryRaw(ctx, condition)
 			return err
 		})
 
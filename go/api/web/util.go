@@ -82,6 +82,26 @@ func (util) parseAirport(ctx context.Context, raw string, airportsFn func(contex
 	return "", errors.New("not found")
 }
 
+func (util) parseAirline(ctx context.Context, raw string, airlinesFn func(context.Context) (map[string]db.Airline, error)) (string, error) {
+	airlines, err := airlinesFn(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	raw = strings.ToUpper(raw)
+	if _, ok := airlines[raw]; ok {
+		return raw, nil
+	}
+
+	for _, airline := range airlines {
+		if airline.IcaoCode.Valid && airline.IcaoCode.String == raw {
+			return airline.IataCode, nil
+		}
+	}
+
+	return "", errors.New("airline not found")
+}
+
 type HTTPErrorOption func(e *HTTPError)
 
 type HTTPError struct {
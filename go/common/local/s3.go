@@ -3,67 +3,113 @@
 package local
 
 import (
+	"cmp"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/explore-flights/monorepo/go/common/adapt"
 	"io"
 	"io/fs"
-	"iter"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
 
-type dirWalker struct {
-	err error
+const (
+	// metadataDir contains the metadata sidecars of all buckets, mirroring the layout of the objects
+	metadataDir    = ".s3metadata"
+	tmpPrefix      = ".s3tmp-"
+	defaultMaxKeys = 1000
+)
+
+// StatusError mimics the errors returned by S3. Errors for missing objects wrap fs.ErrNotExist.
+type StatusError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	err        error
 }
 
-func (d *dirWalker) Files(dir string) iter.Seq2[string, time.Time] {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		d.err = err
-		return func(yield func(string, time.Time) bool) {}
-	}
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("local s3: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
 
-	return func(yield func(string, time.Time) bool) {
-		for _, entry := range entries {
-			if entry.Name() == ".DS_Store" {
-				continue
-			}
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
 
-			fpath := filepath.Join(dir, entry.Name())
-
-			if entry.IsDir() {
-				for f, mtime := range d.Files(fpath) {
-					if !yield(f, mtime) {
-						return
-					}
-				}
-			} else {
-				var finfo fs.FileInfo
-				finfo, err = entry.Info()
-				if err != nil {
-					d.err = err
-					return
-				}
-
-				if !yield(fpath, finfo.ModTime()) {
-					return
-				}
-			}
-		}
+func (e *StatusError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+func (e *StatusError) ErrorCode() string {
+	return e.Code
+}
+
+func (e *StatusError) ErrorMessage() string {
+	return e.Message
+}
+
+func (e *StatusError) ErrorFault() smithy.ErrorFault {
+	return smithy.FaultClient
+}
+
+func errNoSuchKey(key string) error {
+	return &StatusError{http.StatusNotFound, "NoSuchKey", fmt.Sprintf("the specified key does not exist: %q", key), fs.ErrNotExist}
+}
+
+var (
+	errNotModified        = &StatusError{StatusCode: http.StatusNotModified, Code: "NotModified", Message: "not modified"}
+	errPreconditionFailed = &StatusError{StatusCode: http.StatusPreconditionFailed, Code: "PreconditionFailed", Message: "at least one of the preconditions did not hold"}
+)
+
+type objectMetadata struct {
+	ContentType        string            `json:"contentType,omitempty"`
+	ContentEncoding    string            `json:"contentEncoding,omitempty"`
+	ContentDisposition string            `json:"contentDisposition,omitempty"`
+	ContentLanguage    string            `json:"contentLanguage,omitempty"`
+	CacheControl       string            `json:"cacheControl,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	ETag               string            `json:"etag,omitempty"`
+	// Size and ModTime identify the file the ETag was computed for
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+type object struct {
+	path     string
+	size     int64
+	modTime  time.Time
+	metadata objectMetadata
+}
+
+func (o object) etag() string {
+	if o.metadata.ETag != "" && o.metadata.Size == o.size && o.metadata.ModTime.Equal(o.modTime) {
+		return o.metadata.ETag
 	}
+
+	// the file was written without this client; its modification time serves as version
+	return fmt.Sprintf(`"%x-%x"`, o.modTime.UnixNano(), o.size)
 }
 
-func (d *dirWalker) Err() error {
-	return d.err
+func (o object) contentType() string {
+	return cmp.Or(o.metadata.ContentType, "binary/octet-stream")
 }
 
+// S3Client emulates a S3 bucket per directory below basePath. Object metadata (content headers, user metadata and ETags)
+// is stored in sidecar files below basePath/.s3metadata.
 type S3Client struct {
 	basePath string
 }
@@ -72,136 +118,596 @@ func NewS3Client(basePath string) *S3Client {
 	return &S3Client{basePath}
 }
 
-func (s3c *S3Client) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
-	fpath := filepath.Join(s3c.basePath, *params.Bucket, filepath.FromSlash(*params.Key))
+func (s3c *S3Client) objectPath(bucket, key string) (string, error) {
+	if bucket == "" || key == "" || strings.HasSuffix(key, "/") || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", &StatusError{StatusCode: http.StatusBadRequest, Code: "InvalidArgument", Message: fmt.Sprintf("unsupported key %q", key)}
+	}
+
+	return filepath.Join(s3c.basePath, bucket, filepath.FromSlash(key)), nil
+}
+
+func (s3c *S3Client) metadataPath(bucket, key string) string {
+	return filepath.Join(s3c.basePath, metadataDir, bucket, filepath.FromSlash(key)) + ".json"
+}
+
+func (s3c *S3Client) stat(bucket, key string) (object, error) {
+	fpath, err := s3c.objectPath(bucket, key)
+	if err != nil {
+		return object{}, err
+	}
+
 	finfo, err := os.Stat(fpath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return object{}, errNoSuchKey(key)
+		}
+
+		return object{}, err
+	} else if finfo.IsDir() {
+		return object{}, errNoSuchKey(key)
+	}
+
+	obj := object{
+		path:    fpath,
+		size:    finfo.Size(),
+		modTime: finfo.ModTime(),
+	}
+
+	b, err := os.ReadFile(s3c.metadataPath(bucket, key))
+	if err == nil {
+		err = json.Unmarshal(b, &obj.metadata)
+	}
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return object{}, err
+	}
+
+	return obj, nil
+}
+
+func (s3c *S3Client) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	obj, err := s3c.stat(aws.ToString(params.Bucket), aws.ToString(params.Key))
 	if err != nil {
 		return nil, err
 	}
 
+	if err = checkConditions(obj, params.IfMatch, params.IfNoneMatch, params.IfModifiedSince, params.IfUnmodifiedSince); err != nil {
+		return nil, err
+	}
+
 	return &s3.HeadObjectOutput{
-		LastModified:  aws.Time(finfo.ModTime()),
-		ContentLength: aws.Int64(finfo.Size()),
+		AcceptRanges:       aws.String("bytes"),
+		CacheControl:       nilIfEmpty(obj.metadata.CacheControl),
+		ContentDisposition: nilIfEmpty(obj.metadata.ContentDisposition),
+		ContentEncoding:    nilIfEmpty(obj.metadata.ContentEncoding),
+		ContentLanguage:    nilIfEmpty(obj.metadata.ContentLanguage),
+		ContentLength:      aws.Int64(obj.size),
+		ContentType:        aws.String(obj.contentType()),
+		ETag:               aws.String(obj.etag()),
+		LastModified:       aws.Time(obj.modTime),
+		Metadata:           obj.metadata.Metadata,
 	}, nil
 }
 
 func (s3c *S3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
-	fpath := filepath.Join(s3c.basePath, *params.Bucket, filepath.FromSlash(*params.Key))
-	finfo, err := os.Stat(fpath)
+	obj, err := s3c.stat(aws.ToString(params.Bucket), aws.ToString(params.Key))
 	if err != nil {
 		return nil, err
 	}
 
-	f, err := os.Open(fpath)
+	if err = checkConditions(obj, params.IfMatch, params.IfNoneMatch, params.IfModifiedSince, params.IfUnmodifiedSince); err != nil {
+		return nil, err
+	}
+
+	start, end := int64(0), obj.size-1
+	if params.Range != nil {
+		start, end, err = parseRange(*params.Range, obj.size)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := os.Open(obj.path)
 	if err != nil {
 		return nil, err
 	}
 
-	return &s3.GetObjectOutput{
-		Body:          f,
-		LastModified:  aws.Time(finfo.ModTime()),
-		ContentLength: aws.Int64(finfo.Size()),
-	}, nil
+	output := &s3.GetObjectOutput{
+		AcceptRanges:       aws.String("bytes"),
+		Body:               f,
+		CacheControl:       nilIfEmpty(obj.metadata.CacheControl),
+		ContentDisposition: nilIfEmpty(obj.metadata.ContentDisposition),
+		ContentEncoding:    nilIfEmpty(obj.metadata.ContentEncoding),
+		ContentLanguage:    nilIfEmpty(obj.metadata.ContentLanguage),
+		ContentLength:      aws.Int64(obj.size),
+		ContentType:        aws.String(obj.contentType()),
+		ETag:               aws.String(obj.etag()),
+		LastModified:       aws.Time(obj.modTime),
+		Metadata:           obj.metadata.Metadata,
+	}
+
+	if params.Range != nil {
+		if _, err = f.Seek(start, io.SeekStart); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+
+		output.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(f, end-start+1), f}
+		output.ContentLength = aws.Int64(end - start + 1)
+		output.ContentRange = aws.String(fmt.Sprintf("bytes %d-%d/%d", start, end, obj.size))
+	}
+
+	return output, nil
 }
 
 func (s3c *S3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
-	fpath := filepath.Join(s3c.basePath, *params.Bucket, filepath.FromSlash(*params.Key))
+	bucket, key := aws.ToString(params.Bucket), aws.ToString(params.Key)
+	if err := s3c.checkPutConditions(bucket, key, params.IfMatch, params.IfNoneMatch); err != nil {
+		return nil, err
+	}
+
+	md := objectMetadata{
+		ContentType:        aws.ToString(params.ContentType),
+		ContentEncoding:    aws.ToString(params.ContentEncoding),
+		ContentDisposition: aws.ToString(params.ContentDisposition),
+		ContentLanguage:    aws.ToString(params.ContentLanguage),
+		CacheControl:       aws.ToString(params.CacheControl),
+		Metadata:           params.Metadata,
+	}
+
+	body := params.Body
+	if body == nil {
+		body = strings.NewReader("")
+	}
+
+	etag, err := s3c.write(bucket, key, body, md)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.PutObjectOutput{ETag: aws.String(etag)}, nil
+}
+
+func (s3c *S3Client) CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error) {
+	source, err := url.PathUnescape(strings.TrimPrefix(aws.ToString(params.CopySource), "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	// versioned sources (bucket/key?versionId=...) are not supported
+	srcBucket, srcKey, ok := strings.Cut(source, "/")
+	if !ok {
+		return nil, &StatusError{StatusCode: http.StatusBadRequest, Code: "InvalidArgument", Message: fmt.Sprintf("invalid copy source %q", source)}
+	}
+
+	obj, err := s3c.stat(srcBucket, srcKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkConditions(obj, params.CopySourceIfMatch, params.CopySourceIfNoneMatch, params.CopySourceIfModifiedSince, params.CopySourceIfUnmodifiedSince); err != nil {
+		// copy conditions always fail with precondition failed
+		return nil, errPreconditionFailed
+	}
 
-	if err := os.MkdirAll(filepath.Dir(fpath), 0750); err != nil {
+	bucket, key := aws.ToString(params.Bucket), aws.ToString(params.Key)
+	if err = s3c.checkPutConditions(bucket, key, params.IfMatch, params.IfNoneMatch); err != nil {
 		return nil, err
 	}
 
-	f, err := os.Create(fpath)
+	md := obj.metadata
+	if params.MetadataDirective == types.MetadataDirectiveReplace {
+		md = objectMetadata{
+			ContentType:        aws.ToString(params.ContentType),
+			ContentEncoding:    aws.ToString(params.ContentEncoding),
+			ContentDisposition: aws.ToString(params.ContentDisposition),
+			ContentLanguage:    aws.ToString(params.ContentLanguage),
+			CacheControl:       aws.ToString(params.CacheControl),
+			Metadata:           params.Metadata,
+		}
+	}
+
+	f, err := os.Open(obj.path)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
-	_, err = io.Copy(f, params.Body)
-	return nil, err
+	etag, err := s3c.write(bucket, key, f, md)
+	if err != nil {
+		return nil, err
+	}
+
+	copied, err := s3c.stat(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.CopyObjectOutput{
+		CopyObjectResult: &types.CopyObjectResult{
+			ETag:         aws.String(etag),
+			LastModified: aws.Time(copied.modTime),
+		},
+	}, nil
 }
 
-func (s3c *S3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
-	if params.Delimiter != nil {
-		return nil, errors.New("delimiter not yet supported")
+func (s3c *S3Client) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	if err := s3c.delete(aws.ToString(params.Bucket), aws.ToString(params.Key), params.IfMatch); err != nil {
+		return nil, err
+	}
+
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (s3c *S3Client) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	if params.Delete == nil {
+		return nil, &StatusError{StatusCode: http.StatusBadRequest, Code: "MalformedXML", Message: "missing delete"}
+	}
+
+	output := &s3.DeleteObjectsOutput{}
+	for _, obj := range params.Delete.Objects {
+		if err := s3c.delete(aws.ToString(params.Bucket), aws.ToString(obj.Key), obj.ETag); err != nil {
+			code := "InternalError"
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) {
+				code = statusErr.Code
+			}
+
+			output.Errors = append(output.Errors, types.Error{
+				Key:     obj.Key,
+				Code:    aws.String(code),
+				Message: aws.String(err.Error()),
+			})
+		} else if !aws.ToBool(params.Delete.Quiet) {
+			output.Deleted = append(output.Deleted, types.DeletedObject{Key: obj.Key})
+		}
 	}
 
-	maxKeys := 1000
+	return output, nil
+}
+
+func (s3c *S3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	bucket := aws.ToString(params.Bucket)
+	prefix := aws.ToString(params.Prefix)
+	delimiter := aws.ToString(params.Delimiter)
+
+	maxKeys := defaultMaxKeys
 	if params.MaxKeys != nil {
-		maxKeys = int(min(*params.MaxKeys, 1000))
+		maxKeys = int(min(max(*params.MaxKeys, 0), defaultMaxKeys))
+	}
+
+	// continuation tokens are the last key or common prefix of the previous page
+	startAfter := aws.ToString(params.StartAfter)
+	if params.ContinuationToken != nil {
+		startAfter = *params.ContinuationToken
 	}
 
-	s3Prefix := ""
-	if params.Prefix != nil {
-		s3Prefix = *params.Prefix
+	objects, err := s3c.list(bucket, prefix)
+	if err != nil {
+		return nil, err
 	}
 
-	dir, prefix := filepath.Split(filepath.Join(s3c.basePath, *params.Bucket, filepath.FromSlash(s3Prefix)))
+	output := &s3.ListObjectsV2Output{
+		Name:              params.Bucket,
+		Prefix:            params.Prefix,
+		Delimiter:         params.Delimiter,
+		StartAfter:        params.StartAfter,
+		ContinuationToken: params.ContinuationToken,
+		MaxKeys:           aws.Int32(int32(maxKeys)),
+		IsTruncated:       aws.Bool(false),
+	}
 
-	var w dirWalker
-	contents := make([]types.Object, 0)
-	for fpath, mtime := range w.Files(dir) {
-		key := filepath.ToSlash(strings.TrimPrefix(fpath, dir))
-		if suffix, ok := strings.CutPrefix(key, prefix); ok {
-			obj := types.Object{
-				LastModified: aws.Time(mtime),
+	count := 0
+	last := ""
+	for _, obj := range objects {
+		entry := *obj.Key
+		isCommonPrefix := false
+		if delimiter != "" {
+			if idx := strings.Index(entry[len(prefix):], delimiter); idx >= 0 {
+				entry = entry[:len(prefix)+idx+len(delimiter)]
+				isCommonPrefix = true
 			}
+		}
 
-			if strings.HasSuffix(s3Prefix, "/") {
-				obj.Key = aws.String(filepath.ToSlash(filepath.Join(s3Prefix, suffix)))
-			} else {
-				obj.Key = aws.String(s3Prefix + suffix)
-			}
+		if entry <= startAfter || entry == last {
+			continue
+		}
+
+		if count >= maxKeys {
+			output.IsTruncated = aws.Bool(true)
+			output.NextContinuationToken = aws.String(last)
+			break
+		}
 
-			contents = append(contents, obj)
+		if isCommonPrefix {
+			output.CommonPrefixes = append(output.CommonPrefixes, types.CommonPrefix{Prefix: aws.String(entry)})
+		} else {
+			output.Contents = append(output.Contents, obj)
 		}
+
+		count++
+		last = entry
 	}
 
-	if err := w.Err(); err != nil {
+	output.KeyCount = aws.Int32(int32(count))
+
+	return output, nil
+}
+
+// list returns all objects of the bucket starting with prefix, sorted by key
+func (s3c *S3Client) list(bucket, prefix string) ([]types.Object, error) {
+	bucketPath := filepath.Join(s3c.basePath, bucket)
+	root := filepath.Join(bucketPath, filepath.FromSlash(prefix[:strings.LastIndex(prefix, "/")+1]))
+
+	objects := make([]types.Object, 0)
+	err := filepath.WalkDir(root, func(fpath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// like s3, listing a prefix without any objects is not an error
+			if fpath == root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() || d.Name() == ".DS_Store" || strings.HasPrefix(d.Name(), tmpPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketPath, fpath)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		obj, err := s3c.stat(bucket, key)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, types.Object{
+			Key:          aws.String(key),
+			ETag:         aws.String(obj.etag()),
+			LastModified: aws.Time(obj.modTime),
+			Size:         aws.Int64(obj.size),
+			StorageClass: types.ObjectStorageClassStandard,
+		})
+
+		return nil
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
-	slices.SortFunc(contents, func(a, b types.Object) int {
+	slices.SortFunc(objects, func(a, b types.Object) int {
 		return strings.Compare(*a.Key, *b.Key)
 	})
 
-	startAfter := ""
-	if params.ContinuationToken != nil {
-		startAfter = *params.ContinuationToken
-	} else if params.StartAfter != nil {
-		startAfter = *params.StartAfter
+	return objects, nil
+}
+
+// write atomically replaces the object and its metadata, returning the new ETag
+func (s3c *S3Client) write(bucket, key string, r io.Reader, md objectMetadata) (string, error) {
+	fpath, err := s3c.objectPath(bucket, key)
+	if err != nil {
+		return "", err
 	}
 
-	result := make([]types.Object, 0, len(contents))
-	for _, obj := range contents {
-		if len(result) >= maxKeys {
-			break
+	if err = os.MkdirAll(filepath.Dir(fpath), 0750); err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(fpath), tmpPrefix+"*")
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(f.Name())
+
+	h := md5.New()
+	if _, err = io.Copy(io.MultiWriter(f, h), r); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+
+	if err = os.Rename(f.Name(), fpath); err != nil {
+		return "", err
+	}
+
+	finfo, err := os.Stat(fpath)
+	if err != nil {
+		return "", err
+	}
+
+	md.ETag = `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+	md.Size = finfo.Size()
+	md.ModTime = finfo.ModTime()
+
+	b, err := json.Marshal(md)
+	if err != nil {
+		return "", err
+	}
+
+	mdPath := s3c.metadataPath(bucket, key)
+	if err = os.MkdirAll(filepath.Dir(mdPath), 0750); err != nil {
+		return "", err
+	}
+
+	return md.ETag, os.WriteFile(mdPath, b, 0640)
+}
+
+func (s3c *S3Client) delete(bucket, key string, ifMatch *string) error {
+	obj, err := s3c.stat(bucket, key)
+	if err != nil {
+		// deleting a missing object succeeds, unless it was expected to exist
+		if errors.Is(err, fs.ErrNotExist) && ifMatch == nil {
+			return nil
 		}
 
-		if strings.Compare(*obj.Key, startAfter) > 0 {
-			result = append(result, obj)
+		return err
+	}
+
+	if ifMatch != nil && !etagMatches(*ifMatch, obj.etag()) {
+		return errPreconditionFailed
+	}
+
+	if err = os.Remove(obj.path); err != nil {
+		return err
+	}
+
+	if err = os.Remove(s3c.metadataPath(bucket, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// s3 has no directories: remove the ones left empty
+	removeEmptyParents(filepath.Dir(obj.path), filepath.Join(s3c.basePath, bucket))
+	removeEmptyParents(filepath.Dir(s3c.metadataPath(bucket, key)), filepath.Join(s3c.basePath, metadataDir, bucket))
+
+	return nil
+}
+
+func (s3c *S3Client) checkPutConditions(bucket, key string, ifMatch, ifNoneMatch *string) error {
+	if ifMatch == nil && ifNoneMatch == nil {
+		return nil
+	}
+
+	obj, err := s3c.stat(bucket, key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			if ifMatch != nil {
+				return errNoSuchKey(key)
+			}
+
+			return nil
 		}
+
+		return err
 	}
 
-	var nextContinuationToken *string
-	if len(result) >= maxKeys {
-		nextContinuationToken = result[len(result)-1].Key
+	if ifMatch != nil && !etagMatches(*ifMatch, obj.etag()) {
+		return errPreconditionFailed
 	}
 
-	return &s3.ListObjectsV2Output{
-		Contents:              result,
-		ContinuationToken:     params.ContinuationToken,
-		Delimiter:             params.Delimiter,
-		IsTruncated:           aws.Bool(nextContinuationToken != nil),
-		KeyCount:              aws.Int32(int32(len(result))),
-		MaxKeys:               aws.Int32(int32(maxKeys)),
-		NextContinuationToken: nextContinuationToken,
-		Prefix:                params.Prefix,
-	}, nil
+	if ifNoneMatch != nil && etagMatches(*ifNoneMatch, obj.etag()) {
+		return errPreconditionFailed
+	}
+
+	return nil
+}
+
+// checkConditions evaluates conditional requests like s3 does: If-Match takes precedence over If-Unmodified-Since
+// and If-None-Match takes precedence over If-Modified-Since
+func checkConditions(obj object, ifMatch, ifNoneMatch *string, ifModifiedSince, ifUnmodifiedSince *time.Time) error {
+	// http dates have second precision
+	modTime := obj.modTime.Truncate(time.Second)
+
+	if ifMatch != nil {
+		if !etagMatches(*ifMatch, obj.etag()) {
+			return errPreconditionFailed
+		}
+	} else if ifUnmodifiedSince != nil && modTime.After(*ifUnmodifiedSince) {
+		return errPreconditionFailed
+	}
+
+	if ifNoneMatch != nil {
+		if etagMatches(*ifNoneMatch, obj.etag()) {
+			return errNotModified
+		}
+	} else if ifModifiedSince != nil && !modTime.After(*ifModifiedSince) {
+		return errNotModified
+	}
+
+	return nil
+}
+
+func etagMatches(condition, etag string) bool {
+	for _, v := range strings.Split(condition, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || strings.Trim(v, `"`) == strings.Trim(etag, `"`) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRange parses a single http byte range, returning the inclusive start and end offsets
+func parseRange(v string, size int64) (int64, int64, error) {
+	errInvalidRange := &StatusError{StatusCode: http.StatusRequestedRangeNotSatisfiable, Code: "InvalidRange", Message: fmt.Sprintf("the requested range %q is not satisfiable", v)}
+
+	spec, ok := strings.CutPrefix(v, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, errInvalidRange
+	}
+
+	startRaw, endRaw, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, errInvalidRange
+	}
+
+	if startRaw == "" {
+		// suffix range: the last n bytes
+		n, err := strconv.ParseInt(endRaw, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return 0, 0, errInvalidRange
+		}
+
+		return max(size-n, 0), size - 1, nil
+	}
+
+	start, err := strconv.ParseInt(startRaw, 10, 64)
+	if err != nil || start >= size {
+		return 0, 0, errInvalidRange
+	}
+
+	end := size - 1
+	if endRaw != "" {
+		end, err = strconv.ParseInt(endRaw, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, errInvalidRange
+		}
+
+		end = min(end, size-1)
+	}
+
+	return start, end, nil
+}
+
+func removeEmptyParents(dir, root string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
+
+func nilIfEmpty(v string) *string {
+	if v == "" {
+		return nil
+	}
+
+	return &v
 }
 
 var _ interface {
+	adapt.S3Header
 	adapt.S3Getter
 	adapt.S3Putter
+	adapt.S3Copier
 	adapt.S3Lister
+	adapt.S3Deleter
 } = (*S3Client)(nil)
@@ -0,0 +1,228 @@
+//go:build !lambda
+
+package local
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func putString(t *testing.T, s3c *S3Client, key, v string) string {
+	resp, err := s3c.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String(key),
+		Body:   strings.NewReader(v),
+	})
+
+	require.NoError(t, err)
+	return *resp.ETag
+}
+
+func statusCode(err error) int {
+	var apiErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode()
+	}
+
+	return 0
+}
+
+func TestS3Client_PutGetMetadata(t *testing.T) {
+	s3c := NewS3Client(t.TempDir())
+	ctx := context.Background()
+
+	_, err := s3c.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String("bucket"),
+		Key:          aws.String("a/b.json"),
+		Body:         strings.NewReader(`{"a":1}`),
+		ContentType:  aws.String("application/json"),
+		CacheControl: aws.String("no-cache"),
+		Metadata:     map[string]string{"version": "1"},
+	})
+	require.NoError(t, err)
+
+	resp, err := s3c.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("a/b.json")})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"a":1}`, string(b))
+	assert.Equal(t, "application/json", aws.ToString(resp.ContentType))
+	assert.Equal(t, "no-cache", aws.ToString(resp.CacheControl))
+	assert.Equal(t, map[string]string{"version": "1"}, resp.Metadata)
+	assert.Equal(t, `"bb6cb5c68df4652941caf652a366f2d8"`, aws.ToString(resp.ETag))
+
+	_, err = s3c.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("a/missing.json")})
+	assert.True(t, adapt.IsS3NotFound(err))
+	assert.Equal(t, http.StatusNotFound, statusCode(err))
+
+	var apiErr smithy.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "NoSuchKey", apiErr.ErrorCode())
+	}
+
+	_, err = s3c.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("../escape")})
+	assert.Equal(t, http.StatusBadRequest, statusCode(err))
+}
+
+func TestS3Client_Conditionals(t *testing.T) {
+	s3c := NewS3Client(t.TempDir())
+	ctx := context.Background()
+	etag := putString(t, s3c, "key", "value")
+
+	_, err := s3c.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), IfNoneMatch: aws.String(etag)})
+	assert.Equal(t, http.StatusNotModified, statusCode(err))
+
+	_, err = s3c.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), IfMatch: aws.String(`"other"`)})
+	assert.Equal(t, http.StatusPreconditionFailed, statusCode(err))
+
+	_, err = s3c.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), IfModifiedSince: aws.Time(time.Now().Add(time.Hour))})
+	assert.Equal(t, http.StatusNotModified, statusCode(err))
+
+	resp, err := s3c.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), IfModifiedSince: aws.Time(time.Now().Add(-time.Hour))})
+	if assert.NoError(t, err) {
+		_ = resp.Body.Close()
+	}
+
+	_, err = s3c.PutObject(ctx, &s3.PutObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), Body: strings.NewReader("other"), IfNoneMatch: aws.String("*")})
+	assert.Equal(t, http.StatusPreconditionFailed, statusCode(err))
+
+	_, err = s3c.PutObject(ctx, &s3.PutObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), Body: strings.NewReader("other"), IfMatch: aws.String(etag)})
+	assert.NoError(t, err)
+}
+
+func TestS3Client_Range(t *testing.T) {
+	s3c := NewS3Client(t.TempDir())
+	putString(t, s3c, "key", "0123456789")
+
+	for rng, expected := range map[string]string{
+		"bytes=2-4":  "234",
+		"bytes=7-":   "789",
+		"bytes=-3":   "789",
+		"bytes=8-20": "89",
+	} {
+		resp, err := s3c.GetObject(context.Background(), &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), Range: aws.String(rng)})
+		require.NoError(t, err, rng)
+
+		b, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		assert.NoError(t, err, rng)
+		assert.Equal(t, expected, string(b), rng)
+		assert.Equal(t, int64(len(expected)), aws.ToInt64(resp.ContentLength), rng)
+	}
+
+	_, err := s3c.GetObject(context.Background(), &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String("key"), Range: aws.String("bytes=10-")})
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, statusCode(err))
+}
+
+func TestS3Client_ListObjectsV2(t *testing.T) {
+	s3c := NewS3Client(t.TempDir())
+	for _, key := range []string{"a/1", "a/2", "a/b/1", "a/c/1", "a/c/2", "b/1"} {
+		putString(t, s3c, key, key)
+	}
+
+	resp, err := s3c.ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{
+		Bucket:    aws.String("bucket"),
+		Prefix:    aws.String("a/"),
+		Delimiter: aws.String("/"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a/1", "a/2"}, keys(resp.Contents))
+	assert.Equal(t, []types.CommonPrefix{{Prefix: aws.String("a/b/")}, {Prefix: aws.String("a/c/")}}, resp.CommonPrefixes)
+	assert.Equal(t, int32(4), aws.ToInt32(resp.KeyCount))
+
+	result := make([]string, 0)
+	paginator := s3.NewListObjectsV2Paginator(s3c, &s3.ListObjectsV2Input{
+		Bucket:  aws.String("bucket"),
+		Prefix:  aws.String("a"),
+		MaxKeys: aws.Int32(2),
+	})
+
+	pages := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		require.NoError(t, err)
+
+		result = append(result, keys(page.Contents)...)
+		pages++
+	}
+
+	assert.Equal(t, []string{"a/1", "a/2", "a/b/1", "a/c/1", "a/c/2"}, result)
+	assert.Equal(t, 3, pages)
+
+	resp, err = s3c.ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{Bucket: aws.String("bucket"), Prefix: aws.String("missing/")})
+	if assert.NoError(t, err) {
+		assert.Empty(t, resp.Contents)
+	}
+}
+
+func TestS3Client_CopyDelete(t *testing.T) {
+	basePath := t.TempDir()
+	s3c := NewS3Client(basePath)
+	ctx := context.Background()
+	etag := putString(t, s3c, "src/key", "value")
+
+	_, err := s3c.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String("bucket"),
+		Key:        aws.String("dst/key"),
+		CopySource: aws.String("bucket/src/key"),
+	})
+	require.NoError(t, err)
+
+	head, err := s3c.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("bucket"), Key: aws.String("dst/key")})
+	require.NoError(t, err)
+	assert.Equal(t, etag, aws.ToString(head.ETag))
+
+	resp, err := s3c.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String("bucket"),
+		Delete: &types.Delete{
+			Objects: []types.ObjectIdentifier{
+				{Key: aws.String("src/key"), ETag: aws.String(`"other"`)},
+				{Key: aws.String("dst/key"), ETag: aws.String(etag)},
+				{Key: aws.String("missing")},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.DeletedObject{{Key: aws.String("dst/key")}, {Key: aws.String("missing")}}, resp.Deleted)
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, "PreconditionFailed", aws.ToString(resp.Errors[0].Code))
+	}
+
+	_, err = os.Stat(filepath.Join(basePath, "bucket", "dst"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = s3c.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String("bucket"), Key: aws.String("src/key")})
+	assert.NoError(t, err)
+
+	_, err = s3c.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("bucket"), Key: aws.String("src/key")})
+	assert.True(t, adapt.IsS3NotFound(err))
+}
+
+func keys(objects []types.Object) []string {
+	result := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		result = append(result, *obj.Key)
+	}
+
+	return result
+}
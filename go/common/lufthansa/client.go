@@ -49,6 +49,9 @@ type Client struct {
 	cred         *atomic.Pointer[credentials]
 	baseUrl      string
 	leeway       time.Duration
+	retryPolicy  RetryPolicy
+	breaker      *circuitBreaker
+	metrics      RetryMetrics
 }
 
 type ClientOption func(c *Client)
@@ -79,14 +82,23 @@ func WithLeeway(leeway time.Duration) ClientOption {
 
 func NewClient(clientId, clientSecret string, opts ...ClientOption) *Client {
 	c := &Client{
-		mtx:  new(sync.Mutex),
-		cred: new(atomic.Pointer[credentials]),
+		mtx:         new(sync.Mutex),
+		cred:        new(atomic.Pointer[credentials]),
+		retryPolicy: DefaultRetryPolicy,
+		breaker:     &circuitBreaker{policy: DefaultCircuitBreakerPolicy},
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.metrics == nil {
+		c.metrics = noopRetryMetrics{}
+	}
+
+	c.breaker.metrics = c.metrics
+	c.retryPolicy.MaxAttempts = max(c.retryPolicy.MaxAttempts, 1)
+
 	if c.limiter == nil {
 		c.limiter = rate.NewLimiter(rate.Inf, math.MaxInt)
 	}
@@ -137,15 +149,100 @@ func (c *Client) token(ctx context.Context) (string, error) {
 	return cred.token, nil
 }
 
-func (c *Client) doRequest(ctx context.Context, method, surl string, q url.Values, body io.Reader) (*http.Response, error) {
+func (c *Client) invalidateToken(token string) {
+	cred := c.cred.Load()
+	if cred != nil && cred.token == token {
+		c.cred.CompareAndSwap(cred, nil)
+	}
+}
+
+// doRequest sends the request, retrying attempts failing without response or with a status accepted by retryable.
+// The response of the last attempt is returned regardless of its status.
+func (c *Client) doRequest(ctx context.Context, method, surl string, q url.Values, body io.Reader, retryable func(status int) bool) (*http.Response, error) {
+	maxAttempts := c.retryPolicy.MaxAttempts
+	if body != nil {
+		// the body can only be read once
+		maxAttempts = 1
+	}
+
+	path := strings.TrimPrefix(surl, c.baseUrl)
+
+	for attempt := 1; ; attempt++ {
+		if !c.breaker.allow() {
+			c.metrics.CircuitRejected(method, path)
+			return nil, ErrCircuitOpen
+		}
+
+		resp, token, err := c.doRequestAttempt(ctx, method, surl, q, body)
+		if err != nil && (ctx.Err() != nil || errors.Is(err, ErrRateLimit)) {
+			// not a failure of the api
+			c.breaker.abort()
+			return nil, err
+		}
+
+		event := RetryEvent{
+			Method:  method,
+			Path:    path,
+			Attempt: attempt,
+			Err:     err,
+		}
+
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+		}
+
+		if err == nil && !isOutageStatus(resp.StatusCode) {
+			c.breaker.success()
+		} else {
+			c.breaker.failure()
+		}
+
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			c.invalidateToken(token)
+		}
+
+		if err == nil && (resp.StatusCode == http.StatusOK || !retryable(resp.StatusCode)) {
+			return resp, nil
+		} else if token == "" {
+			// failed to retrieve a token
+			return nil, err
+		}
+
+		if attempt >= maxAttempts {
+			c.metrics.GiveUp(event)
+			return resp, err
+		}
+
+		event.Delay = c.retryPolicy.backoff(attempt)
+		if resp != nil {
+			event.Delay = max(event.Delay, retryAfter(resp))
+			_ = resp.Body.Close()
+		}
+
+		c.metrics.Retry(event)
+
+		if sleepErr := sleep(ctx, event.Delay); sleepErr != nil {
+			if err == nil {
+				err = ResponseStatusErr{
+					StatusCode: resp.StatusCode,
+					Status:     resp.Status,
+				}
+			}
+
+			return nil, errors.Join(err, sleepErr)
+		}
+	}
+}
+
+func (c *Client) doRequestAttempt(ctx context.Context, method, surl string, q url.Values, body io.Reader) (*http.Response, string, error) {
 	token, err := c.token(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, surl, body)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if q != nil {
@@ -159,11 +256,12 @@ func (c *Client) doRequest(ctx context.Context, method, surl string, q url.Value
 
 	if c.limiter != nil {
 		if err = c.limiter.Wait(ctx); err != nil {
-			return nil, decorateLimiterErr(err)
+			return nil, "", decorateLimiterErr(err)
 		}
 	}
 
-	return c.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
+	return resp, token, err
 }
 
 func (c *Client) Countries(ctx context.Context) ([]Country, error) {
@@ -239,6 +337,100 @@ func (c *Client) SeatMapRaw(ctx context.Context, fn, departureAirport, arrivalAi
 	return doRequest[json.RawMessage](ctx, c, http.MethodGet, path, nil, nil, readJsonFunc[json.RawMessage]())
 }
 
+func (c *Client) FlightStatus(ctx context.Context, fn string, departureDate xtime.LocalDate) ([]FlightStatus, error) {
+	return doRequestAllPages[flightStatusResource[FlightStatus]](ctx, c, c.buildFlightStatusPath(fn, departureDate), nil, 100)
+}
+
+func (c *Client) FlightStatusRaw(ctx context.Context, fn string, departureDate xtime.LocalDate) ([]json.RawMessage, error) {
+	return doRequestAllPages[flightStatusResource[json.RawMessage]](ctx, c, c.buildFlightStatusPath(fn, departureDate), nil, 100)
+}
+
+// FlightStatusByRoute returns the status of all flights between two airports, departing after the given local time at the origin
+func (c *Client) FlightStatusByRoute(ctx context.Context, origin, destination string, date xtime.LocalDate, from xtime.LocalTime) ([]FlightStatus, error) {
+	return doRequestAllPages[flightStatusResource[FlightStatus]](ctx, c, c.buildFlightStatusByRoutePath(origin, destination, date, from), nil, 100)
+}
+
+func (c *Client) FlightStatusByRouteRaw(ctx context.Context, origin, destination string, date xtime.LocalDate, from xtime.LocalTime) ([]json.RawMessage, error) {
+	return doRequestAllPages[flightStatusResource[json.RawMessage]](ctx, c, c.buildFlightStatusByRoutePath(origin, destination, date, from), nil, 100)
+}
+
+// FlightStatusArrivals returns the status of flights arriving at the airport within a few hours of the given local time
+func (c *Client) FlightStatusArrivals(ctx context.Context, airport string, date xtime.LocalDate, from xtime.LocalTime) ([]FlightStatus, error) {
+	return doRequestAllPages[flightStatusResource[FlightStatus]](ctx, c, c.buildFlightStatusAtAirportPath("arrivals", airport, date, from), nil, 100)
+}
+
+func (c *Client) FlightStatusArrivalsRaw(ctx context.Context, airport string, date xtime.LocalDate, from xtime.LocalTime) ([]json.RawMessage, error) {
+	return doRequestAllPages[flightStatusResource[json.RawMessage]](ctx, c, c.buildFlightStatusAtAirportPath("arrivals", airport, date, from), nil, 100)
+}
+
+// FlightStatusDepartures returns the status of flights departing at the airport within a few hours of the given local time
+func (c *Client) FlightStatusDepartures(ctx context.Context, airport string, date xtime.LocalDate, from xtime.LocalTime) ([]FlightStatus, error) {
+	return doRequestAllPages[flightStatusResource[FlightStatus]](ctx, c, c.buildFlightStatusAtAirportPath("departures", airport, date, from), nil, 100)
+}
+
+func (c *Client) FlightStatusDeparturesRaw(ctx context.Context, airport string, date xtime.LocalDate, from xtime.LocalTime) ([]json.RawMessage, error) {
+	return doRequestAllPages[flightStatusResource[json.RawMessage]](ctx, c, c.buildFlightStatusAtAirportPath("departures", airport, date, from), nil, 100)
+}
+
+func (c *Client) OperationsSchedules(ctx context.Context, origin, destination string, date xtime.LocalDate, directFlights bool) ([]OperationsSchedule, error) {
+	path, q := c.buildOperationsSchedulesPath(origin, destination, date, directFlights)
+	return doRequestAllPages[scheduleResource[OperationsSchedule]](ctx, c, path, q, 100)
+}
+
+func (c *Client) OperationsSchedulesRaw(ctx context.Context, origin, destination string, date xtime.LocalDate, directFlights bool) ([]json.RawMessage, error) {
+	path, q := c.buildOperationsSchedulesPath(origin, destination, date, directFlights)
+	return doRequestAllPages[scheduleResource[json.RawMessage]](ctx, c, path, q, 100)
+}
+
+// Lounges returns the lounges at a location, which is either an airport or a city code
+func (c *Client) Lounges(ctx context.Context, location string) ([]Lounge, error) {
+	return doRequestAllPages[loungeResource[Lounge]](ctx, c, "/v1/offers/lounges/"+url.PathEscape(location), nil, 100)
+}
+
+func (c *Client) LoungesRaw(ctx context.Context, location string) ([]json.RawMessage, error) {
+	return doRequestAllPages[loungeResource[json.RawMessage]](ctx, c, "/v1/offers/lounges/"+url.PathEscape(location), nil, 100)
+}
+
+func (c *Client) buildFlightStatusPath(fn string, departureDate xtime.LocalDate) string {
+	return fmt.Sprintf(
+		"/v1/operations/flightstatus/%s/%s",
+		url.PathEscape(fn),
+		url.PathEscape(departureDate.String()),
+	)
+}
+
+func (c *Client) buildFlightStatusByRoutePath(origin, destination string, date xtime.LocalDate, from xtime.LocalTime) string {
+	return fmt.Sprintf(
+		"/v1/operations/flightstatus/route/%s/%s/%s",
+		url.PathEscape(origin),
+		url.PathEscape(destination),
+		url.PathEscape(formatLocalDateTime(date, from)),
+	)
+}
+
+func (c *Client) buildFlightStatusAtAirportPath(direction, airport string, date xtime.LocalDate, from xtime.LocalTime) string {
+	return fmt.Sprintf(
+		"/v1/operations/flightstatus/%s/%s/%s",
+		direction,
+		url.PathEscape(airport),
+		url.PathEscape(formatLocalDateTime(date, from)),
+	)
+}
+
+func (c *Client) buildOperationsSchedulesPath(origin, destination string, date xtime.LocalDate, directFlights bool) (string, url.Values) {
+	path := fmt.Sprintf(
+		"/v1/operations/schedules/%s/%s/%s",
+		url.PathEscape(origin),
+		url.PathEscape(destination),
+		url.PathEscape(date.String()),
+	)
+
+	q := make(url.Values)
+	q.Set("directFlights", strconv.FormatBool(directFlights))
+
+	return path, q
+}
+
 func (c *Client) buildSeatMapPath(fn, departureAirport, arrivalAirport string, departureDate xtime.LocalDate, cabinClass RequestCabinClass) string {
 	return fmt.Sprintf(
 		"/v1/offers/seatmaps/%s/%s/%s/%s/%s",
@@ -263,14 +455,15 @@ func doRequestFlightSchedules[T any](ctx context.Context, c *Client, airlines []
 
 	q.Set("timeMode", "UTC")
 
-	const maxRetries = 10
-	errs := make([]error, 0, maxRetries)
+	// outages are retried with backoff by doRequest, bad element responses are retried immediately
+	const maxBadElementAttempts = 10
+	errs := make([]error, 0, maxBadElementAttempts)
 
-	for len(errs) < maxRetries {
+	for len(errs) < maxBadElementAttempts {
 		r, err := doRequest[T](ctx, c, http.MethodGet, "/v1/flight-schedules/flightschedules/passenger", q, nil, f)
 		if err != nil {
 			var statusErr ResponseStatusErr
-			if errors.As(err, &statusErr) && (isBadElementStatus(statusErr.StatusCode) || isRetryableStatus(statusErr.StatusCode)) {
+			if errors.As(err, &statusErr) && isBadElementStatus(statusErr.StatusCode) {
 				errs = append(errs, err)
 				continue
 			}
@@ -287,7 +480,7 @@ func doRequestFlightSchedules[T any](ctx context.Context, c *Client, airlines []
 }
 
 func doRequest[T any](ctx context.Context, c *Client, method, path string, q url.Values, body io.Reader, f func(r io.Reader) (T, error)) (T, error) {
-	resp, err := c.doRequest(ctx, method, c.baseUrl+path, q, body)
+	resp, err := c.doRequest(ctx, method, c.baseUrl+path, q, body, isRetryableStatus)
 	if err != nil {
 		var def T
 		return def, err
@@ -332,79 +525,103 @@ func doRequestPage[T pagedResource[D], D any](ctx context.Context, c *Client, me
 	q.Set("limit", strconv.Itoa(pageSize))
 	q.Set("offset", strconv.Itoa(offset))
 
-	const maxRetries = 10
-	errs := make([]error, 0, maxRetries)
-
-	for {
-		resp, err := c.doRequest(ctx, method, surl, q, nil)
-		if err != nil {
-			return results, 0, false, err
-		}
-
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			_ = resp.Body.Close()
+	resp, err := c.doRequest(ctx, method, surl, q, nil, isRetryableStatus)
+	if err != nil {
+		return results, 0, false, err
+	}
 
-			if pageSize > 1 && isBadElementStatus(resp.StatusCode) {
-				var statusErr ResponseStatusErr
+	defer resp.Body.Close()
 
-				subPageSizeLeft := pageSize / 2
-				subPageSizeRight := pageSize - subPageSizeLeft
-				offsetLeft := offset
-				offsetRight := offset + subPageSizeLeft
+	if resp.StatusCode != http.StatusOK {
+		if pageSize > 1 && isBadElementStatus(resp.StatusCode) {
+			var statusErr ResponseStatusErr
 
-				for _, page := range [2][2]int{{subPageSizeLeft, offsetLeft}, {subPageSizeRight, offsetRight}} {
-					results, _, _, err = doRequestPage[T, D](ctx, c, method, surl, q, page[0], page[1], results)
-					if err != nil {
-						if errors.As(err, &statusErr) && isBadElementStatus(statusErr.StatusCode) {
-							return results, 0, false, nil
-						}
+			subPageSizeLeft := pageSize / 2
+			subPageSizeRight := pageSize - subPageSizeLeft
+			offsetLeft := offset
+			offsetRight := offset + subPageSizeLeft
 
-						return results, 0, false, err
+			for _, page := range [2][2]int{{subPageSizeLeft, offsetLeft}, {subPageSizeRight, offsetRight}} {
+				results, _, _, err = doRequestPage[T, D](ctx, c, method, surl, q, page[0], page[1], results)
+				if err != nil {
+					if errors.As(err, &statusErr) && isBadElementStatus(statusErr.StatusCode) {
+						return results, 0, false, nil
 					}
-				}
-
-				nextPageOffset := offset + pageSize
-				hasNextPage := true
 
-				if results != nil {
-					hasNextPage = nextPageOffset < cap(results)
+					return results, 0, false, err
 				}
-
-				return results, nextPageOffset, hasNextPage, nil
 			}
 
-			errs = append(errs, ResponseStatusErr{
-				StatusCode: resp.StatusCode,
-				Status:     resp.Status,
-			})
+			nextPageOffset := offset + pageSize
+			hasNextPage := true
 
-			if isRetryableStatus(resp.StatusCode) && len(errs) < maxRetries {
-				continue
-			} else {
-				return results, 0, false, errors.Join(errs...)
+			if results != nil {
+				hasNextPage = nextPageOffset < cap(results)
 			}
-		}
 
-		var r T
-		if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
-			return results, 0, false, fmt.Errorf("failed to parse response: %w", err)
+			return results, nextPageOffset, hasNextPage, nil
 		}
 
-		if results == nil {
-			results = make([]D, 0, r.Meta().TotalCount)
+		return results, 0, false, ResponseStatusErr{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
 		}
+	}
+
+	var r T
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return results, 0, false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if results == nil {
+		results = make([]D, 0, r.Meta().TotalCount)
+	}
+
+	for _, v := range r.Data() {
+		results = append(results, v)
+	}
+
+	nextPageOffset := offset + pageSize
+	return results, nextPageOffset, nextPageOffset < r.Meta().TotalCount, nil
+}
+
+// doRequestAllPages requests all pages of a resource. Unlike doRequestPaged, a 404 is not treated as bad element but as the end of the results.
+func doRequestAllPages[T pagedResource[D], D any](ctx context.Context, c *Client, path string, q url.Values, pageSize int) ([]D, error) {
+	if q == nil {
+		q = make(url.Values)
+	} else {
+		q = maps.Clone(q)
+	}
+
+	results := make([]D, 0)
+	for offset := 0; ; offset += pageSize {
+		q.Set("limit", strconv.Itoa(pageSize))
+		q.Set("offset", strconv.Itoa(offset))
+
+		r, err := doRequest[T](ctx, c, http.MethodGet, path, q, nil, readJsonFunc[T]())
+		if err != nil {
+			var statusErr ResponseStatusErr
+			if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+				return results, nil
+			}
 
-		for _, v := range r.Data() {
-			results = append(results, v)
+			return nil, err
 		}
 
-		nextPageOffset := offset + pageSize
-		return results, nextPageOffset, nextPageOffset < r.Meta().TotalCount, nil
+		data := r.Data()
+		results = append(results, data...)
+
+		// some resources report no total count, those are paged until a page comes back short
+		if totalCount := r.Meta().TotalCount; len(data) < pageSize || (totalCount > 0 && len(results) >= totalCount) {
+			return results, nil
+		}
 	}
 }
 
+func formatLocalDateTime(d xtime.LocalDate, t xtime.LocalTime) string {
+	return t.Time(d, nil).Format("2006-01-02T15:04")
+}
+
 func readJsonFunc[T any]() func(r io.Reader) (T, error) {
 	return func(r io.Reader) (T, error) {
 		var res T
@@ -423,7 +640,12 @@ func isBadElementStatus(status int) bool {
 }
 
 func isRetryableStatus(status int) bool {
-	return status == http.StatusGatewayTimeout || status == http.StatusBadGateway || status == http.StatusForbidden || status == http.StatusUnauthorized
+	return isOutageStatus(status) || status == http.StatusForbidden || status == http.StatusUnauthorized
+}
+
+// isOutageStatus reports statuses counted as failures by the circuit breaker
+func isOutageStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
 }
 
 func decorateLimiterErr(err error) error {
@@ -0,0 +1,58 @@
+package lufthansa_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestPagingWithoutTotalCount(t *testing.T) {
+	const total = 150
+	var offsets []int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, offset)
+
+		page := make([]json.RawMessage, 0, limit)
+		for i := offset; i < min(offset+limit, total); i++ {
+			page = append(page, json.RawMessage(`{"Name":"lounge `+strconv.Itoa(i)+`"}`))
+		}
+
+		// the Meta of this resource omits the TotalCount
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"LoungeResource": map[string]any{
+				"Lounges": map[string]any{"Lounge": page},
+				"Meta":    map[string]any{"@Version": "1.0.0"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := lufthansa.NewClient("id", "secret", lufthansa.WithBaseUrl(srv.URL))
+
+	lounges, err := c.LoungesRaw(context.Background(), "FRA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lounges) != total {
+		t.Fatalf("expected %d lounges, got %d", total, len(lounges))
+	}
+
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != 100 {
+		t.Fatalf("unexpected offsets requested: %v", offsets)
+	}
+}
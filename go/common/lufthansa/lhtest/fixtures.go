@@ -0,0 +1,123 @@
+package lhtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// SeatMapKey identifies a recorded seat map response. An empty DepartureDate matches every departure date.
+type SeatMapKey struct {
+	FlightNumber     string
+	DepartureAirport string
+	ArrivalAirport   string
+	DepartureDate    string
+	CabinClass       lufthansa.RequestCabinClass
+}
+
+type Fixtures struct {
+	Countries       []json.RawMessage
+	Cities          []json.RawMessage
+	Airports        []json.RawMessage
+	Airlines        []json.RawMessage
+	Aircraft        []json.RawMessage
+	FlightSchedules []json.RawMessage
+	// FlightStatus holds single flights as returned by the flight status endpoints
+	FlightStatus []json.RawMessage
+	// OperationsSchedules holds single schedules as returned by the operations schedules endpoint
+	OperationsSchedules []json.RawMessage
+	Lounges             []json.RawMessage
+	// SeatMaps holds the complete response bodies of the seat map endpoint
+	SeatMaps map[SeatMapKey]json.RawMessage
+}
+
+// LoadFixtures reads fixtures from the following (optional) files:
+//
+//	countries.json, cities.json, airports.json, airlines.json, aircraft.json: json arrays of reference data entries
+//	flightschedules.json: json array of flight schedules
+//	flightstatus.json, operationsschedules.json, lounges.json: json arrays of flights, schedules and lounges
+//	seatmaps/{FN}_{DEP}_{ARR}[_{DATE}]_{CABIN}.json: seat map response bodies
+func LoadFixtures(fsys fs.FS) (Fixtures, error) {
+	f := Fixtures{
+		SeatMaps: make(map[SeatMapKey]json.RawMessage),
+	}
+
+	for name, ptr := range map[string]*[]json.RawMessage{
+		"countries.json":           &f.Countries,
+		"cities.json":              &f.Cities,
+		"airports.json":            &f.Airports,
+		"airlines.json":            &f.Airlines,
+		"aircraft.json":            &f.Aircraft,
+		"flightschedules.json":     &f.FlightSchedules,
+		"flightstatus.json":        &f.FlightStatus,
+		"operationsschedules.json": &f.OperationsSchedules,
+		"lounges.json":             &f.Lounges,
+	} {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return Fixtures{}, err
+		}
+
+		if err = json.Unmarshal(b, ptr); err != nil {
+			return Fixtures{}, fmt.Errorf("failed to parse %q: %w", name, err)
+		}
+	}
+
+	entries, err := fs.ReadDir(fsys, "seatmaps")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Fixtures{}, err
+	}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		key, err := parseSeatMapKey(name)
+		if err != nil {
+			return Fixtures{}, err
+		}
+
+		b, err := fs.ReadFile(fsys, path.Join("seatmaps", entry.Name()))
+		if err != nil {
+			return Fixtures{}, err
+		}
+
+		f.SeatMaps[key] = b
+	}
+
+	return f, nil
+}
+
+func parseSeatMapKey(name string) (SeatMapKey, error) {
+	parts := strings.Split(name, "_")
+	switch len(parts) {
+	case 4:
+		return SeatMapKey{
+			FlightNumber:     parts[0],
+			DepartureAirport: parts[1],
+			ArrivalAirport:   parts[2],
+			CabinClass:       lufthansa.RequestCabinClass(parts[3]),
+		}, nil
+
+	case 5:
+		return SeatMapKey{
+			FlightNumber:     parts[0],
+			DepartureAirport: parts[1],
+			ArrivalAirport:   parts[2],
+			DepartureDate:    parts[3],
+			CabinClass:       lufthansa.RequestCabinClass(parts[4]),
+		}, nil
+	}
+
+	return SeatMapKey{}, fmt.Errorf("invalid seat map fixture name: %q", name)
+}
@@ -0,0 +1,445 @@
+// Package lhtest provides an in-process fake of the Lufthansa Open API serving recorded fixtures.
+package lhtest
+
+import (
+	"encoding/json"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ClientId     = "lhtest-client-id"
+	ClientSecret = "lhtest-client-secret"
+	accessToken  = "lhtest-access-token"
+	// the flight status arrivals and departures endpoints return flights scheduled within this window
+	flightStatusWindow = time.Hour * 4
+)
+
+type Server struct {
+	*httptest.Server
+	fixtures Fixtures
+
+	mtx      sync.Mutex
+	requests []string
+	failures []int
+}
+
+func NewServer(fixtures Fixtures) *Server {
+	s := &Server{fixtures: fixtures}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/oauth/token", s.token)
+	mux.Handle("GET /v1/mds-references/countries", s.authorized(s.references("CountryResource", "Countries", "Country", func() []json.RawMessage { return s.fixtures.Countries })))
+	mux.Handle("GET /v1/mds-references/cities", s.authorized(s.references("CityResource", "Cities", "City", func() []json.RawMessage { return s.fixtures.Cities })))
+	mux.Handle("GET /v1/mds-references/airports", s.authorized(s.references("AirportResource", "Airports", "Airport", func() []json.RawMessage { return s.fixtures.Airports })))
+	mux.Handle("GET /v1/mds-references/airports/{code}", s.authorized(http.HandlerFunc(s.airport)))
+	mux.Handle("GET /v1/mds-references/airlines", s.authorized(s.references("AirlineResource", "Airlines", "Airline", func() []json.RawMessage { return s.fixtures.Airlines })))
+	mux.Handle("GET /v1/mds-references/aircraft", s.authorized(s.references("AircraftResource", "AircraftSummaries", "AircraftSummary", func() []json.RawMessage { return s.fixtures.Aircraft })))
+	mux.Handle("GET /v1/flight-schedules/flightschedules/passenger", s.authorized(http.HandlerFunc(s.flightSchedules)))
+	mux.Handle("GET /v1/offers/seatmaps/{fn}/{dep}/{arr}/{date}/{cabin}", s.authorized(http.HandlerFunc(s.seatMap)))
+	mux.Handle("GET /v1/operations/flightstatus/{fn}/{date}", s.authorized(http.HandlerFunc(s.flightStatus)))
+	mux.Handle("GET /v1/operations/flightstatus/route/{origin}/{destination}/{from}", s.authorized(http.HandlerFunc(s.flightStatusByRoute)))
+	mux.Handle("GET /v1/operations/flightstatus/arrivals/{airport}/{from}", s.authorized(s.flightStatusAtAirport(func(fs lufthansa.FlightStatus) lufthansa.FlightStatusEndpoint { return fs.Arrival })))
+	mux.Handle("GET /v1/operations/flightstatus/departures/{airport}/{from}", s.authorized(s.flightStatusAtAirport(func(fs lufthansa.FlightStatus) lufthansa.FlightStatusEndpoint { return fs.Departure })))
+	mux.Handle("GET /v1/operations/schedules/{origin}/{destination}/{date}", s.authorized(http.HandlerFunc(s.operationsSchedules)))
+	mux.Handle("GET /v1/offers/lounges/{location}", s.authorized(http.HandlerFunc(s.lounges)))
+
+	s.Server = httptest.NewServer(s.record(mux))
+	return s
+}
+
+// Client returns a client using this server which does not wait for any rate limit and retries with minimal backoff
+func (s *Server) Client(opts ...lufthansa.ClientOption) *lufthansa.Client {
+	opts = append(
+		[]lufthansa.ClientOption{
+			lufthansa.WithBaseUrl(s.URL),
+			lufthansa.WithHttpClient(s.Server.Client()),
+			lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond * 10,
+				Multiplier:     2,
+			}),
+		},
+		opts...,
+	)
+	return lufthansa.NewClient(ClientId, ClientSecret, opts...)
+}
+
+// Requests returns all requests received so far as "METHOD /path?query"
+func (s *Server) Requests() []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// FailNext lets the next n authorized requests fail with the given status
+func (s *Server) FailNext(n, status int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for range n {
+		s.failures = append(s.failures, status)
+	}
+}
+
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mtx.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+		s.mtx.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		s.mtx.Lock()
+		var status int
+		if len(s.failures) > 0 {
+			status = s.failures[0]
+			s.failures = s.failures[1:]
+		}
+		s.mtx.Unlock()
+
+		if status != 0 {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_id") != ClientId || r.PostForm.Get("client_secret") != ClientSecret {
+		http.Error(w, "invalid client", http.StatusUnauthorized)
+		return
+	}
+
+	writeJson(w, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "bearer",
+		"expires_in":   int((time.Hour * 36).Seconds()),
+	})
+}
+
+func (s *Server) references(resourceName, listName, itemName string, items func() []json.RawMessage) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		all := items()
+		page, ok := paginate(w, r, all, false)
+		if !ok {
+			return
+		}
+
+		writeJson(w, map[string]any{
+			resourceName: map[string]any{
+				listName: map[string]any{
+					itemName: page,
+				},
+				"Meta": pagedMeta(r, len(all)),
+			},
+		})
+	})
+}
+
+func (s *Server) airport(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	for _, raw := range s.fixtures.Airports {
+		var airport lufthansa.Airport
+		if err := json.Unmarshal(raw, &airport); err == nil && airport.Code == code {
+			writeJson(w, raw)
+			return
+		}
+	}
+
+	http.Error(w, "not found", http.StatusNotFound)
+}
+
+func (s *Server) flightSchedules(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	startDate, err := time.Parse("02Jan06", q.Get("startDate"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	endDate, err := time.Parse("02Jan06", q.Get("endDate"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	airlines := q["airlines"]
+	flightNumberRanges := q["flightNumberRanges"]
+
+	result := make([]json.RawMessage, 0)
+	for _, raw := range s.fixtures.FlightSchedules {
+		var fs lufthansa.FlightSchedule
+		if err := json.Unmarshal(raw, &fs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if len(airlines) > 0 && !slices.Contains(airlines, fs.Airline) {
+			continue
+		}
+
+		if len(flightNumberRanges) > 0 && !slices.ContainsFunc(flightNumberRanges, func(v string) bool { return inFlightNumberRange(v, fs.FlightNumber) }) {
+			continue
+		}
+
+		if time.Time(fs.PeriodOfOperationUTC.EndDate).Before(startDate) || time.Time(fs.PeriodOfOperationUTC.StartDate).After(endDate) {
+			continue
+		}
+
+		result = append(result, raw)
+	}
+
+	writeJson(w, result)
+}
+
+func (s *Server) seatMap(w http.ResponseWriter, r *http.Request) {
+	key := SeatMapKey{
+		FlightNumber:     r.PathValue("fn"),
+		DepartureAirport: r.PathValue("dep"),
+		ArrivalAirport:   r.PathValue("arr"),
+		DepartureDate:    r.PathValue("date"),
+		CabinClass:       lufthansa.RequestCabinClass(r.PathValue("cabin")),
+	}
+
+	raw, ok := s.fixtures.SeatMaps[key]
+	if !ok {
+		key.DepartureDate = ""
+		raw, ok = s.fixtures.SeatMaps[key]
+	}
+
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	writeJson(w, raw)
+}
+
+func (s *Server) flightStatus(w http.ResponseWriter, r *http.Request) {
+	fn := r.PathValue("fn")
+	date := r.PathValue("date")
+
+	s.writeFlightStatus(w, r, func(fs lufthansa.FlightStatus) bool {
+		matchesFn := func(c lufthansa.Carrier) bool {
+			return c.AirlineId+string(c.FlightNumber) == fn
+		}
+
+		return (matchesFn(fs.MarketingCarrier) || matchesFn(fs.OperatingCarrier)) && strings.HasPrefix(fs.Departure.ScheduledTimeLocal.DateTime, date)
+	})
+}
+
+func (s *Server) flightStatusByRoute(w http.ResponseWriter, r *http.Request) {
+	origin := r.PathValue("origin")
+	destination := r.PathValue("destination")
+	from := r.PathValue("from")
+	date, _, _ := strings.Cut(from, "T")
+
+	s.writeFlightStatus(w, r, func(fs lufthansa.FlightStatus) bool {
+		scheduled := fs.Departure.ScheduledTimeLocal.DateTime
+		return fs.Departure.AirportCode == origin && fs.Arrival.AirportCode == destination && scheduled >= from && strings.HasPrefix(scheduled, date)
+	})
+}
+
+func (s *Server) flightStatusAtAirport(endpoint func(fs lufthansa.FlightStatus) lufthansa.FlightStatusEndpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		airport := r.PathValue("airport")
+		from, err := time.Parse("2006-01-02T15:04", r.PathValue("from"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.writeFlightStatus(w, r, func(fs lufthansa.FlightStatus) bool {
+			ep := endpoint(fs)
+			scheduled, err := ep.ScheduledTimeLocal.Time(time.UTC)
+			return err == nil && ep.AirportCode == airport && !scheduled.Before(from) && scheduled.Before(from.Add(flightStatusWindow))
+		})
+	})
+}
+
+func (s *Server) writeFlightStatus(w http.ResponseWriter, r *http.Request, match func(fs lufthansa.FlightStatus) bool) {
+	all, ok := filterFixtures(w, s.fixtures.FlightStatus, match)
+	if !ok {
+		return
+	}
+
+	page, ok := paginate(w, r, all, true)
+	if !ok {
+		return
+	}
+
+	writeJson(w, map[string]any{
+		"FlightStatusResource": map[string]any{
+			"Flights": map[string]any{
+				"Flight": page,
+			},
+			"Meta": pagedMeta(r, len(all)),
+		},
+	})
+}
+
+func (s *Server) operationsSchedules(w http.ResponseWriter, r *http.Request) {
+	origin := r.PathValue("origin")
+	destination := r.PathValue("destination")
+	date := r.PathValue("date")
+	directFlights := r.URL.Query().Get("directFlights") == "true"
+
+	all, ok := filterFixtures(w, s.fixtures.OperationsSchedules, func(schedule lufthansa.OperationsSchedule) bool {
+		if len(schedule.Flights) < 1 || (directFlights && len(schedule.Flights) > 1) {
+			return false
+		}
+
+		first := schedule.Flights[0]
+		last := schedule.Flights[len(schedule.Flights)-1]
+
+		return first.Departure.AirportCode == origin && last.Arrival.AirportCode == destination && first.Details.DatePeriod.Effective <= date && first.Details.DatePeriod.Expiration >= date
+	})
+	if !ok {
+		return
+	}
+
+	page, ok := paginate(w, r, all, true)
+	if !ok {
+		return
+	}
+
+	writeJson(w, map[string]any{
+		"ScheduleResource": map[string]any{
+			"Schedule": page,
+			"Meta":     pagedMeta(r, len(all)),
+		},
+	})
+}
+
+func (s *Server) lounges(w http.ResponseWriter, r *http.Request) {
+	location := r.PathValue("location")
+	all, ok := filterFixtures(w, s.fixtures.Lounges, func(lounge lufthansa.Lounge) bool {
+		return lounge.AirportCode == location || lounge.CityCode == location
+	})
+	if !ok {
+		return
+	}
+
+	page, ok := paginate(w, r, all, true)
+	if !ok {
+		return
+	}
+
+	writeJson(w, map[string]any{
+		"LoungeResource": map[string]any{
+			"Lounges": map[string]any{
+				"Lounge": page,
+			},
+			"Meta": pagedMeta(r, len(all)),
+		},
+	})
+}
+
+func filterFixtures[T any](w http.ResponseWriter, fixtures []json.RawMessage, match func(v T) bool) ([]json.RawMessage, bool) {
+	result := make([]json.RawMessage, 0)
+	for _, raw := range fixtures {
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil, false
+		}
+
+		if match(v) {
+			result = append(result, raw)
+		}
+	}
+
+	return result, true
+}
+
+// paginate writes an error and returns false if the requested page is out of range. The operations api responds with 404 if there are no results at all.
+func paginate(w http.ResponseWriter, r *http.Request, all []json.RawMessage, emptyNotFound bool) ([]json.RawMessage, bool) {
+	limit, offset, err := pagination(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	if (offset > 0 || emptyNotFound) && offset >= len(all) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return all[offset:min(offset+limit, len(all))], true
+}
+
+func pagedMeta(r *http.Request, totalCount int) map[string]any {
+	return map[string]any{
+		"@Version":   "1.0.0",
+		"Link":       []any{map[string]string{"@Href": r.URL.String(), "@Rel": "self"}},
+		"TotalCount": totalCount,
+	}
+}
+
+func pagination(r *http.Request) (int, int, error) {
+	limit, offset := 100, 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if v := r.URL.Query().Get("offset"); v != "" {
+		var err error
+		if offset, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return limit, offset, nil
+}
+
+func inFlightNumberRange(v string, number int) bool {
+	startRaw, endRaw, isRange := strings.Cut(v, "-")
+	start, err := strconv.Atoi(startRaw)
+	if err != nil {
+		return false
+	}
+
+	if !isRange {
+		return start == number
+	}
+
+	end, err := strconv.Atoi(endRaw)
+	if err != nil {
+		return false
+	}
+
+	return number >= start && number <= end
+}
+
+func writeJson(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
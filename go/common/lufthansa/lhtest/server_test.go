@@ -0,0 +1,250 @@
+package lhtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *Server {
+	fixtures, err := LoadFixtures(os.DirFS("testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewServer(fixtures)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestServerReferencesPaged(t *testing.T) {
+	fixtures := Fixtures{}
+	for i := range 250 {
+		fixtures.Airports = append(fixtures.Airports, json.RawMessage(fmt.Sprintf(`{"AirportCode":"A%02d"}`, i)))
+	}
+
+	s := NewServer(fixtures)
+	defer s.Close()
+
+	airports, err := s.Client().Airports(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(airports) != 250 || airports[0].Code != "A00" || airports[249].Code != "A249" {
+		t.Fatalf("unexpected airports: %d", len(airports))
+	}
+
+	airport, err := s.Client().Airport(context.Background(), "A42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if airport.Code != "A42" {
+		t.Fatalf("unexpected airport: %q", airport.Code)
+	}
+}
+
+func TestServerAirlines(t *testing.T) {
+	s := newTestServer(t)
+
+	airlines, err := s.Client().Airlines(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(airlines) != 2 || airlines[0].AirlineId != "LH" || airlines[1].AirlineIdICAO != "SWR" {
+		t.Fatalf("unexpected airlines: %+v", airlines)
+	}
+}
+
+func TestServerFlightSchedules(t *testing.T) {
+	s := newTestServer(t)
+
+	fss, err := s.Client().FlightSchedules(
+		context.Background(),
+		[]common.AirlineIdentifier{"LH"},
+		xtime.NewLocalDateFromParts(2026, time.May, 1),
+		xtime.NewLocalDateFromParts(2026, time.May, 31),
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fss) != 1 || fss[0].FlightNumber != 400 || fss[0].Legs[0].AircraftType != "359" {
+		t.Fatalf("unexpected flight schedules: %+v", fss)
+	}
+
+	fss, err = s.Client().FlightSchedules(
+		context.Background(),
+		[]common.AirlineIdentifier{"LH", "LX"},
+		xtime.NewLocalDateFromParts(2026, time.May, 1),
+		xtime.NewLocalDateFromParts(2026, time.June, 30),
+		nil,
+		lufthansa.WithFlightNumber(1000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fss) != 2 {
+		t.Fatalf("expected 2 flight schedules, got %d", len(fss))
+	}
+}
+
+func TestServerSeatMap(t *testing.T) {
+	s := newTestServer(t)
+	c := s.Client()
+
+	sm, err := c.SeatMap(context.Background(), "LH400", "FRA", "JFK", xtime.NewLocalDateFromParts(2026, time.May, 1), lufthansa.RequestCabinClassBusiness)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sm.SeatDisplay) != 1 || len(sm.SeatDetails) != 4 || !sm.SeatDetails[0].AisleAccess() {
+		t.Fatalf("unexpected seat map: %+v", sm)
+	}
+
+	_, err = c.SeatMap(context.Background(), "LH400", "FRA", "JFK", xtime.NewLocalDateFromParts(2026, time.May, 2), lufthansa.RequestCabinClassBusiness)
+	var statusErr lufthansa.ResponseStatusErr
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestServerFailNext(t *testing.T) {
+	s := newTestServer(t)
+	s.FailNext(1, http.StatusServiceUnavailable)
+
+	c := s.Client(lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{MaxAttempts: 1}))
+	_, err := c.Airlines(context.Background())
+	var statusErr lufthansa.ResponseStatusErr
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected service unavailable, got %v", err)
+	}
+
+	if _, err = c.Airlines(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(s.Requests()); n != 3 {
+		t.Fatalf("expected 3 requests (token + 2), got %d", n)
+	}
+}
+
+func TestServerFlightStatus(t *testing.T) {
+	s := newTestServer(t)
+	c := s.Client()
+	date := xtime.NewLocalDateFromParts(2026, time.May, 1)
+
+	fss, err := c.FlightStatus(context.Background(), "LH400", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fss) != 1 || fss[0].Equipment.AircraftCode != "359" || fss[0].Departure.Terminal.Name != "1" || fss[0].FlightStatus.Code != lufthansa.FlightStatusCodeDeparted {
+		t.Fatalf("unexpected flight status: %+v", fss)
+	}
+
+	departure, err := fss[0].Departure.ActualTimeUTC.Time(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !departure.Equal(time.Date(2026, time.May, 1, 11, 52, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected departure time: %v", departure)
+	}
+
+	fss, err = c.FlightStatusByRoute(context.Background(), "FRA", "MUC", date, xtime.MustParseLocalTime("06:00:00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fss) != 1 || fss[0].Equipment.AircraftCode != "32N" {
+		t.Fatalf("unexpected flight status by route: %+v", fss)
+	}
+
+	fss, err = c.FlightStatusDepartures(context.Background(), "FRA", date, xtime.MustParseLocalTime("12:00:00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fss) != 1 || fss[0].MarketingCarrier.FlightNumber != "400" {
+		t.Fatalf("unexpected departures: %+v", fss)
+	}
+
+	// no flights is not an error
+	fss, err = c.FlightStatusArrivals(context.Background(), "MUC", date, xtime.MustParseLocalTime("12:00:00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fss) != 0 {
+		t.Fatalf("expected no arrivals, got %d", len(fss))
+	}
+
+	raw, err := c.FlightStatusRaw(context.Background(), "LH100", date)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(raw) != 1 {
+		t.Fatalf("expected 1 raw flight status, got %d", len(raw))
+	}
+}
+
+func TestServerOperationsSchedules(t *testing.T) {
+	s := newTestServer(t)
+	c := s.Client()
+	date := xtime.NewLocalDateFromParts(2026, time.May, 1)
+
+	schedules, err := c.OperationsSchedules(context.Background(), "FRA", "JFK", date, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(schedules) != 2 || len(schedules[1].Flights) != 2 || schedules[1].Flights[1].MarketingCarrier.FlightNumber != "410" {
+		t.Fatalf("unexpected schedules: %+v", schedules)
+	}
+
+	schedules, err = c.OperationsSchedules(context.Background(), "FRA", "JFK", date, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(schedules) != 1 || schedules[0].TotalJourney.Duration != "PT8H40M" || schedules[0].Flights[0].Details.DaysOfOperation != "1234567" {
+		t.Fatalf("unexpected direct schedules: %+v", schedules)
+	}
+}
+
+func TestServerLounges(t *testing.T) {
+	s := newTestServer(t)
+
+	lounges, err := s.Client().Lounges(context.Background(), "FRA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lounges) != 1 || len(lounges[0].Names.Name) != 2 || !lounges[0].Features.ShowerFacilities || lounges[0].Locations.Location[0].Name != "Terminal 1, Concourse Z, Level 3" {
+		t.Fatalf("unexpected lounges: %+v", lounges)
+	}
+
+	raw, err := s.Client().LoungesRaw(context.Background(), "HAM")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(raw) != 0 {
+		t.Fatalf("expected no lounges, got %d", len(raw))
+	}
+}
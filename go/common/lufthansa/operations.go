@@ -0,0 +1,135 @@
+package lufthansa
+
+import (
+	"strings"
+	"time"
+)
+
+type FlightStatusCode string
+
+const (
+	FlightStatusCodeCancelled = FlightStatusCode("CD")
+	FlightStatusCodeDeparted  = FlightStatusCode("DP")
+	FlightStatusCodeLanded    = FlightStatusCode("LD")
+	FlightStatusCodeRerouted  = FlightStatusCode("RT")
+	FlightStatusCodeNoStatus  = FlightStatusCode("NA")
+)
+
+type TimeStatusCode string
+
+const (
+	TimeStatusCodeEarly           = TimeStatusCode("FE")
+	TimeStatusCodeNextInformation = TimeStatusCode("NI")
+	TimeStatusCodeOnTime          = TimeStatusCode("OT")
+	TimeStatusCodeDelayed         = TimeStatusCode("DL")
+	TimeStatusCodeNoStatus        = TimeStatusCode("NO")
+)
+
+type DateTime struct {
+	// DateTime is formatted as 2006-01-02T15:04 for local times and as 2006-01-02T15:04Z for UTC times
+	DateTime string `json:"DateTime"`
+}
+
+// Time parses the DateTime in the given location (UTC if nil), which is ignored for UTC times
+func (dt DateTime) Time(loc *time.Location) (time.Time, error) {
+	if strings.HasSuffix(dt.DateTime, "Z") {
+		return time.Parse("2006-01-02T15:04Z", dt.DateTime)
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return time.ParseInLocation("2006-01-02T15:04", dt.DateTime, loc)
+}
+
+type StatusDefinition[T ~string] struct {
+	Code       T      `json:"Code"`
+	Definition string `json:"Definition"`
+}
+
+type Terminal struct {
+	Name JsonNumOrStr `json:"Name"`
+	Gate string       `json:"Gate,omitempty"`
+}
+
+type Carrier struct {
+	AirlineId    string       `json:"AirlineID"`
+	FlightNumber JsonNumOrStr `json:"FlightNumber,omitempty"`
+}
+
+type Equipment struct {
+	AircraftCode         JsonNumOrStr `json:"AircraftCode"`
+	AircraftRegistration string       `json:"AircraftRegistration,omitempty"`
+}
+
+type FlightStatusEndpoint struct {
+	AirportCode        string                           `json:"AirportCode"`
+	ScheduledTimeLocal DateTime                         `json:"ScheduledTimeLocal"`
+	ScheduledTimeUTC   DateTime                         `json:"ScheduledTimeUTC"`
+	EstimatedTimeLocal *DateTime                        `json:"EstimatedTimeLocal,omitempty"`
+	EstimatedTimeUTC   *DateTime                        `json:"EstimatedTimeUTC,omitempty"`
+	ActualTimeLocal    *DateTime                        `json:"ActualTimeLocal,omitempty"`
+	ActualTimeUTC      *DateTime                        `json:"ActualTimeUTC,omitempty"`
+	TimeStatus         StatusDefinition[TimeStatusCode] `json:"TimeStatus"`
+	Terminal           *Terminal                        `json:"Terminal,omitempty"`
+}
+
+type FlightStatus struct {
+	Departure        FlightStatusEndpoint               `json:"Departure"`
+	Arrival          FlightStatusEndpoint               `json:"Arrival"`
+	MarketingCarrier Carrier                            `json:"MarketingCarrier"`
+	OperatingCarrier Carrier                            `json:"OperatingCarrier"`
+	Equipment        Equipment                          `json:"Equipment"`
+	FlightStatus     StatusDefinition[FlightStatusCode] `json:"FlightStatus"`
+	ServiceType      string                             `json:"ServiceType"`
+}
+
+type OperationsScheduleEndpoint struct {
+	AirportCode        string    `json:"AirportCode"`
+	ScheduledTimeLocal DateTime  `json:"ScheduledTimeLocal"`
+	Terminal           *Terminal `json:"Terminal,omitempty"`
+}
+
+type OperationsScheduleFlight struct {
+	Departure        OperationsScheduleEndpoint `json:"Departure"`
+	Arrival          OperationsScheduleEndpoint `json:"Arrival"`
+	MarketingCarrier Carrier                    `json:"MarketingCarrier"`
+	OperatingCarrier Carrier                    `json:"OperatingCarrier"`
+	Equipment        Equipment                  `json:"Equipment"`
+	Details          struct {
+		Stops struct {
+			StopQuantity int `json:"StopQuantity"`
+		} `json:"Stops"`
+		DaysOfOperation JsonNumOrStr `json:"DaysOfOperation"`
+		DatePeriod      struct {
+			Effective  string `json:"Effective"`
+			Expiration string `json:"Expiration"`
+		} `json:"DatePeriod"`
+	} `json:"Details"`
+}
+
+// OperationsSchedule is a journey between two airports, consisting of one or more flights
+type OperationsSchedule struct {
+	TotalJourney struct {
+		// Duration is an ISO 8601 duration, e.g. PT8H50M
+		Duration string `json:"Duration"`
+	} `json:"TotalJourney"`
+	Flights Array[OperationsScheduleFlight] `json:"Flight"`
+}
+
+type Lounge struct {
+	Names       Names  `json:"Names"`
+	AirportCode string `json:"AirportCode"`
+	CityCode    string `json:"CityCode"`
+	Locations   struct {
+		Location Array[Name] `json:"Location"`
+	} `json:"Locations"`
+	OpeningHours struct {
+		OpeningHours Array[Name] `json:"OpeningHours"`
+	} `json:"OpeningHours"`
+	Features struct {
+		ShowerFacilities bool `json:"ShowerFacilities"`
+		RelaxingRooms    bool `json:"RelaxingRooms"`
+	} `json:"Features"`
+}
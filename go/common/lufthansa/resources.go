@@ -111,3 +111,52 @@ type seatAvailabilityResource struct {
 		Meta resourceMeta `json:"Meta"`
 	} `json:"SeatAvailabilityResource"`
 }
+
+type flightStatusResource[D any] struct {
+	Inner struct {
+		Flights struct {
+			Flight Array[D] `json:"Flight"`
+		} `json:"Flights"`
+		Meta pagedResourceMeta `json:"Meta"`
+	} `json:"FlightStatusResource"`
+}
+
+func (r flightStatusResource[D]) Data() []D {
+	return r.Inner.Flights.Flight
+}
+
+func (r flightStatusResource[D]) Meta() pagedResourceMeta {
+	return r.Inner.Meta
+}
+
+type loungeResource[D any] struct {
+	Inner struct {
+		Lounges struct {
+			Lounge Array[D] `json:"Lounge"`
+		} `json:"Lounges"`
+		Meta pagedResourceMeta `json:"Meta"`
+	} `json:"LoungeResource"`
+}
+
+func (r loungeResource[D]) Data() []D {
+	return r.Inner.Lounges.Lounge
+}
+
+func (r loungeResource[D]) Meta() pagedResourceMeta {
+	return r.Inner.Meta
+}
+
+type scheduleResource[D any] struct {
+	Inner struct {
+		Schedule Array[D]          `json:"Schedule"`
+		Meta     pagedResourceMeta `json:"Meta"`
+	} `json:"ScheduleResource"`
+}
+
+func (r scheduleResource[D]) Data() []D {
+	return r.Inner.Schedule
+}
+
+func (r scheduleResource[D]) Meta() pagedResourceMeta {
+	return r.Inner.Meta
+}
@@ -0,0 +1,229 @@
+package lufthansa
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrCircuitOpen              = errors.New("circuit breaker open")
+	ErrRetryWouldExceedDeadline = errors.New("retry wait would exceed deadline")
+	DefaultRetryPolicy          = RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Millisecond * 500, MaxBackoff: time.Second * 30, Multiplier: 2, Jitter: 0.5}
+	DefaultCircuitBreakerPolicy = CircuitBreakerPolicy{FailureThreshold: 20, Cooldown: time.Minute}
+)
+
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the first one
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction of the backoff which is randomized, between 0 and 1
+	Jitter float64
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(max(p.Multiplier, 1), float64(attempt-1))
+	d = min(d, float64(p.MaxBackoff))
+	d -= d * min(max(p.Jitter, 0), 1) * rand.Float64()
+
+	return time.Duration(d)
+}
+
+// CircuitBreakerPolicy configures the circuit breaker of a client: after FailureThreshold consecutive failed attempts, all requests fail
+// with ErrCircuitOpen until Cooldown has passed. A FailureThreshold <= 0 disables the circuit breaker.
+type CircuitBreakerPolicy struct {
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+type CircuitState string
+
+const (
+	CircuitClosed   = CircuitState("closed")
+	CircuitOpen     = CircuitState("open")
+	CircuitHalfOpen = CircuitState("halfOpen")
+)
+
+type RetryEvent struct {
+	Method string
+	Path   string
+	// Attempt is the attempt which failed, starting at 1
+	Attempt int
+	// StatusCode is 0 if the attempt failed without a response
+	StatusCode int
+	Err        error
+	// Delay is the time waited before the next attempt
+	Delay time.Duration
+}
+
+type RetryMetrics interface {
+	// Retry is called before waiting for the next attempt of a request
+	Retry(e RetryEvent)
+	// GiveUp is called when a request failed after its last attempt
+	GiveUp(e RetryEvent)
+	CircuitStateChanged(from, to CircuitState)
+	// CircuitRejected is called for every request failing fast with ErrCircuitOpen
+	CircuitRejected(method, path string)
+}
+
+type noopRetryMetrics struct{}
+
+func (noopRetryMetrics) Retry(RetryEvent)                          {}
+func (noopRetryMetrics) GiveUp(RetryEvent)                         {}
+func (noopRetryMetrics) CircuitStateChanged(from, to CircuitState) {}
+func (noopRetryMetrics) CircuitRejected(method, path string)       {}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+func WithCircuitBreaker(policy CircuitBreakerPolicy) ClientOption {
+	return func(c *Client) {
+		c.breaker.policy = policy
+	}
+}
+
+func WithRetryMetrics(metrics RetryMetrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
+type circuitBreaker struct {
+	policy   CircuitBreakerPolicy
+	metrics  RetryMetrics
+	mtx      sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (cb *circuitBreaker) allow() bool {
+	if cb.policy.FailureThreshold <= 0 {
+		return true
+	}
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if time.Since(cb.openedAt) < cb.policy.Cooldown {
+			return false
+		}
+
+		cb.transition(CircuitHalfOpen)
+		cb.probing = true
+		return true
+
+	case CircuitHalfOpen:
+		// only a single request probes whether the api recovered
+		if cb.probing {
+			return false
+		}
+
+		cb.probing = true
+		return true
+	}
+
+	return true
+}
+
+func (cb *circuitBreaker) success() {
+	if cb.policy.FailureThreshold <= 0 {
+		return
+	}
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	cb.failures = 0
+	cb.probing = false
+	cb.transition(CircuitClosed)
+}
+
+func (cb *circuitBreaker) failure() {
+	if cb.policy.FailureThreshold <= 0 {
+		return
+	}
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	cb.failures++
+	cb.probing = false
+
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.policy.FailureThreshold {
+		cb.openedAt = time.Now()
+		cb.transition(CircuitOpen)
+	}
+}
+
+// abort releases the probe of a half-open circuit if the request ended without a response from the api
+func (cb *circuitBreaker) abort() {
+	if cb.policy.FailureThreshold <= 0 {
+		return
+	}
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	cb.probing = false
+}
+
+func (cb *circuitBreaker) transition(to CircuitState) {
+	from := cb.state
+	if from == "" {
+		from = CircuitClosed
+	}
+
+	cb.state = to
+	if from != to {
+		cb.metrics.CircuitStateChanged(from, to)
+	}
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as http date
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return ErrRetryWouldExceedDeadline
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-t.C:
+		return nil
+	}
+}
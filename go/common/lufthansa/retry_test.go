@@ -0,0 +1,283 @@
+package lufthansa_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/lufthansa/lhtest"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mtx         sync.Mutex
+	retries     []lufthansa.RetryEvent
+	giveUps     []lufthansa.RetryEvent
+	transitions []lufthansa.CircuitState
+	rejected    int
+}
+
+func (m *recordingMetrics) Retry(e lufthansa.RetryEvent) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.retries = append(m.retries, e)
+}
+
+func (m *recordingMetrics) GiveUp(e lufthansa.RetryEvent) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.giveUps = append(m.giveUps, e)
+}
+
+func (m *recordingMetrics) CircuitStateChanged(from, to lufthansa.CircuitState) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.transitions = append(m.transitions, to)
+}
+
+func (m *recordingMetrics) CircuitRejected(method, path string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.rejected++
+}
+
+func newRetryTestServer(t *testing.T) *lhtest.Server {
+	s := lhtest.NewServer(lhtest.Fixtures{
+		Airlines: []json.RawMessage{json.RawMessage(`{"AirlineID":"LH"}`)},
+	})
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func countRequests(s *lhtest.Server, prefix string) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if strings.HasPrefix(r, prefix) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	s := newRetryTestServer(t)
+	s.FailNext(2, http.StatusBadGateway)
+
+	metrics := new(recordingMetrics)
+	airlines, err := s.Client(lufthansa.WithRetryMetrics(metrics)).Airlines(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(airlines) != 1 {
+		t.Fatalf("expected 1 airline, got %d", len(airlines))
+	}
+
+	if len(metrics.retries) != 2 || metrics.retries[0].Attempt != 1 || metrics.retries[1].StatusCode != http.StatusBadGateway {
+		t.Fatalf("unexpected retries: %+v", metrics.retries)
+	}
+}
+
+func TestRetryGiveUp(t *testing.T) {
+	s := newRetryTestServer(t)
+	s.FailNext(3, http.StatusGatewayTimeout)
+
+	metrics := new(recordingMetrics)
+	_, err := s.Client(lufthansa.WithRetryMetrics(metrics)).Airlines(context.Background())
+
+	var statusErr lufthansa.ResponseStatusErr
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected gateway timeout, got %v", err)
+	}
+
+	if len(metrics.giveUps) != 1 || metrics.giveUps[0].Attempt != 3 {
+		t.Fatalf("unexpected give ups: %+v", metrics.giveUps)
+	}
+}
+
+func TestRetryNotFoundIsNotRetried(t *testing.T) {
+	s := newRetryTestServer(t)
+
+	_, err := s.Client().SeatMap(context.Background(), "LH400", "FRA", "JFK", xtime.NewLocalDateFromParts(2026, time.May, 1), lufthansa.RequestCabinClassEco)
+
+	var statusErr lufthansa.ResponseStatusErr
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	if n := countRequests(s, "GET /v1/offers/seatmaps/"); n != 1 {
+		t.Fatalf("expected 1 seat map request, got %d", n)
+	}
+}
+
+func TestRetryFlightSchedulesBadElementWithoutBackoff(t *testing.T) {
+	s := newRetryTestServer(t)
+	s.FailNext(100, http.StatusNotFound)
+
+	metrics := new(recordingMetrics)
+	c := s.Client(
+		lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour, MaxBackoff: time.Hour}),
+		lufthansa.WithRetryMetrics(metrics),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	d := xtime.NewLocalDateFromParts(2026, time.May, 1)
+	_, err := c.FlightSchedules(ctx, []common.AirlineIdentifier{"LH"}, d, d, nil)
+
+	var statusErr lufthansa.ResponseStatusErr
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	if len(metrics.retries) != 0 || len(metrics.giveUps) != 0 {
+		t.Fatalf("expected no backoff, got retries %+v and give ups %+v", metrics.retries, metrics.giveUps)
+	}
+
+	if n := countRequests(s, "GET /v1/flight-schedules/"); n != 10 {
+		t.Fatalf("expected 10 flight schedules requests, got %d", n)
+	}
+}
+
+func TestRetryFlightSchedulesBadElementRecovers(t *testing.T) {
+	s := newRetryTestServer(t)
+	s.FailNext(2, http.StatusInternalServerError)
+
+	c := s.Client(lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour, MaxBackoff: time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	d := xtime.NewLocalDateFromParts(2026, time.May, 1)
+	if _, err := c.FlightSchedules(ctx, []common.AirlineIdentifier{"LH"}, d, d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countRequests(s, "GET /v1/flight-schedules/"); n != 3 {
+		t.Fatalf("expected 3 flight schedules requests, got %d", n)
+	}
+}
+
+func TestRetryUnauthorizedRefreshesToken(t *testing.T) {
+	s := newRetryTestServer(t)
+	c := s.Client()
+
+	if _, err := c.Airlines(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	s.FailNext(1, http.StatusUnauthorized)
+	if _, err := c.Airlines(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countRequests(s, "POST /v1/oauth/token"); n != 2 {
+		t.Fatalf("expected 2 token requests, got %d", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var mtx sync.Mutex
+	var times []time.Time
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+			return
+		}
+
+		mtx.Lock()
+		times = append(times, time.Now())
+		first := len(times) == 1
+		mtx.Unlock()
+
+		if first {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"AirportCode":"FRA"}`))
+	}))
+	defer srv.Close()
+
+	c := lufthansa.NewClient(
+		"id",
+		"secret",
+		lufthansa.WithBaseUrl(srv.URL),
+		lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+	)
+
+	airport, err := c.Airport(context.Background(), "FRA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if airport.Code != "FRA" || len(times) != 2 {
+		t.Fatalf("unexpected result: %q after %d requests", airport.Code, len(times))
+	}
+
+	if d := times[1].Sub(times[0]); d < time.Second {
+		t.Fatalf("expected to wait at least 1s, waited %v", d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+	defer cancel()
+
+	mtx.Lock()
+	times = nil
+	mtx.Unlock()
+
+	if _, err = c.Airport(ctx, "FRA"); !errors.Is(err, lufthansa.ErrRetryWouldExceedDeadline) {
+		t.Fatalf("expected ErrRetryWouldExceedDeadline, got %v", err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	s := newRetryTestServer(t)
+	metrics := new(recordingMetrics)
+	c := s.Client(
+		lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{MaxAttempts: 1}),
+		lufthansa.WithCircuitBreaker(lufthansa.CircuitBreakerPolicy{FailureThreshold: 2, Cooldown: time.Millisecond * 50}),
+		lufthansa.WithRetryMetrics(metrics),
+	)
+
+	s.FailNext(2, http.StatusServiceUnavailable)
+	for range 2 {
+		if _, err := c.Airlines(context.Background()); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+
+	before := len(s.Requests())
+	if _, err := c.Airlines(context.Background()); !errors.Is(err, lufthansa.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	if len(s.Requests()) != before || metrics.rejected != 1 {
+		t.Fatal("expected the request to fail fast")
+	}
+
+	time.Sleep(time.Millisecond * 60)
+
+	if _, err := c.Airlines(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []lufthansa.CircuitState{lufthansa.CircuitOpen, lufthansa.CircuitHalfOpen, lufthansa.CircuitClosed}
+	if !slices.Equal(metrics.transitions, expected) {
+		t.Fatalf("unexpected transitions: %v", metrics.transitions)
+	}
+}
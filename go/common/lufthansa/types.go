@@ -121,6 +121,25 @@ func (v JsonStrAsInt) MarshalJSON() ([]byte, error) {
 	return json.Marshal(strconv.Itoa(int(v)))
 }
 
+// JsonNumOrStr is a string which the api returns either as json string or as json number, depending on its value
+type JsonNumOrStr string
+
+func (v *JsonNumOrStr) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = JsonNumOrStr(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+
+	*v = JsonNumOrStr(n)
+	return nil
+}
+
 type Code string
 
 func (v *Code) UnmarshalJSON(data []byte) error {
@@ -0,0 +1,138 @@
+// Package seatmapstore defines the s3 layout of raw Lufthansa seat maps, shared by the api and the cron prefetching them.
+package seatmapstore
+
+import (
+	"context"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+const versionFormat = "20060102T150405Z"
+
+// Prefix is the common prefix of the objects of all configurations
+const Prefix = "tmp/seatmap/"
+
+// CabinClasses lists all cabin classes a snapshot is requested for
+var CabinClasses = []lufthansa.RequestCabinClass{
+	lufthansa.RequestCabinClassEco,
+	lufthansa.RequestCabinClassPremiumEco,
+	lufthansa.RequestCabinClassBusiness,
+	lufthansa.RequestCabinClassFirst,
+}
+
+type ConfigurationKey struct {
+	AirlineIataCode              string
+	AircraftIataCode             string
+	AircraftConfigurationVersion string
+}
+
+type Snapshot struct {
+	Version time.Time `json:"version"`
+	// CheckedAt is the last time the seat maps were fetched and found unchanged
+	CheckedAt    time.Time                                                  `json:"checkedAt"`
+	CabinClasses map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability `json:"cabinClasses"`
+}
+
+// LastChecked returns CheckedAt, or the version for snapshots stored before CheckedAt was recorded
+func (sn Snapshot) LastChecked() time.Time {
+	if sn.CheckedAt.IsZero() {
+		return sn.Version
+	}
+
+	return sn.CheckedAt
+}
+
+// ConfigurationPrefix is the common prefix of all objects (versioned and legacy) stored for a configuration
+func ConfigurationPrefix(key ConfigurationKey) string {
+	return fmt.Sprintf("%s%s/%s/%s/", Prefix, key.AirlineIataCode, key.AircraftIataCode, key.AircraftConfigurationVersion)
+}
+
+func VersionsPrefix(key ConfigurationKey) string {
+	return ConfigurationPrefix(key) + "versions/"
+}
+
+func VersionKey(key ConfigurationKey, version time.Time) string {
+	return VersionsPrefix(key) + version.UTC().Format(versionFormat) + ".json"
+}
+
+// ParseVersionKey returns the version of an object key returned by listing VersionsPrefix
+func ParseVersionKey(s3Key string) (time.Time, bool) {
+	name, ok := strings.CutSuffix(path.Base(s3Key), ".json")
+	if !ok {
+		return time.Time{}, false
+	}
+
+	version, err := time.Parse(versionFormat, name)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return version, true
+}
+
+// LegacyKey is the key of the unversioned seat map of a single cabin class, stored before seat maps were versioned
+func LegacyKey(key ConfigurationKey, cabinClass lufthansa.RequestCabinClass) string {
+	return ConfigurationPrefix(key) + string(cabinClass) + ".json"
+}
+
+// LoadLegacySnapshot combines the unversioned seat maps of a configuration into a single snapshot, versioned by the latest modification.
+// It reports false if no unversioned seat map is stored for the configuration.
+func LoadLegacySnapshot(ctx context.Context, s3c adapt.S3Getter, bucket string, key ConfigurationKey) (Snapshot, bool, error) {
+	sn := Snapshot{
+		CabinClasses: make(map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability),
+	}
+
+	for _, cabinClass := range CabinClasses {
+		var sm *lufthansa.SeatAvailability
+		lastModified, err := adapt.S3GetJsonWithLastModified(ctx, s3c, bucket, LegacyKey(key, cabinClass), &sm)
+		if err != nil {
+			if adapt.IsS3NotFound(err) {
+				continue
+			}
+
+			return Snapshot{}, false, err
+		}
+
+		if sm != nil {
+			sn.CabinClasses[cabinClass] = *sm
+		}
+
+		if lastModified.After(sn.Version) {
+			sn.Version = NewVersion(lastModified)
+			sn.CheckedAt = sn.Version
+		}
+	}
+
+	return sn, len(sn.CabinClasses) > 0, nil
+}
+
+// ParseLegacyKey returns the configuration of an object key returned by listing Prefix if it is a LegacyKey
+func ParseLegacyKey(s3Key string) (ConfigurationKey, bool) {
+	parts := strings.Split(strings.TrimPrefix(s3Key, Prefix), "/")
+	if !strings.HasPrefix(s3Key, Prefix) || len(parts) != 4 || !strings.HasSuffix(parts[3], ".json") {
+		return ConfigurationKey{}, false
+	}
+
+	key := ConfigurationKey{
+		AirlineIataCode:              parts[0],
+		AircraftIataCode:             parts[1],
+		AircraftConfigurationVersion: parts[2],
+	}
+
+	cabinClass := lufthansa.RequestCabinClass(strings.TrimSuffix(parts[3], ".json"))
+	if !slices.Contains(CabinClasses, cabinClass) {
+		return ConfigurationKey{}, false
+	}
+
+	return key, true
+}
+
+// NewVersion returns the version of a snapshot fetched at the given time
+func NewVersion(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Second)
+}
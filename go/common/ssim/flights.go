@@ -0,0 +1,107 @@
+package ssim
+
+import (
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"strings"
+	"time"
+)
+
+// FlightDates yields all dates (in the TimeMode of the carrier) on which the flight of this leg operates
+func (l *Leg) FlightDates(yield func(xtime.LocalDate) bool) {
+	for d := range (xtime.LocalDateRange{l.PeriodFrom, l.PeriodTo}).Iter {
+		if l.OperatesOn(d) && !yield(d) {
+			return
+		}
+	}
+}
+
+func (l *Leg) OperatesOn(flightDate xtime.LocalDate) bool {
+	if flightDate < l.PeriodFrom || flightDate > l.PeriodTo {
+		return false
+	}
+
+	if l.BiWeekly && (l.PeriodFrom.DaysUntil(flightDate)/7)%2 != 0 {
+		return false
+	}
+
+	return l.DaysOfOperation[flightDate.Time(nil).Weekday()]
+}
+
+func (l *Leg) DepartureTime(flightDate xtime.LocalDate) time.Time {
+	return l.time(flightDate+xtime.LocalDate(l.DepartureDateVariation), l.AircraftDepartureTime, l.DepartureUTCOffset)
+}
+
+func (l *Leg) ArrivalTime(flightDate xtime.LocalDate) time.Time {
+	return l.time(flightDate+xtime.LocalDate(l.ArrivalDateVariation), l.AircraftArrivalTime, l.ArrivalUTCOffset)
+}
+
+func (l *Leg) time(d xtime.LocalDate, lt xtime.LocalTime, utcOffset int) time.Time {
+	loc := time.FixedZone("", utcOffset)
+	if l.TimeMode == TimeModeUTC {
+		return lt.Time(d, time.UTC).In(loc)
+	}
+
+	return lt.Time(d, loc)
+}
+
+// Flight converts this leg to a flight. The data elements referencing other flight numbers (10 and 50)
+// are normalized to the format accepted by common.ParseFlightNumber.
+func (l *Leg) Flight(flightDate xtime.LocalDate) *common.Flight {
+	dataElements := make(map[int]string, len(l.DataElements))
+	for _, de := range l.DataElements {
+		v := de.Data
+		if de.Id == DataElementCodeShares || de.Id == DataElementOperatingFlight {
+			v = normalizeFlightNumbers(v)
+		}
+
+		dataElements[de.Id] = v
+	}
+
+	return &common.Flight{
+		Airline:                      common.AirlineIdentifier(l.Airline),
+		FlightNumber:                 l.FlightNumber,
+		Suffix:                       l.OperationalSuffix,
+		DepartureTime:                l.DepartureTime(flightDate),
+		DepartureAirport:             l.DepartureStation,
+		ArrivalTime:                  l.ArrivalTime(flightDate),
+		ArrivalAirport:               l.ArrivalStation,
+		ServiceType:                  l.ServiceType,
+		AircraftOwner:                common.AirlineIdentifier(l.AircraftOwner),
+		AircraftType:                 l.AircraftType,
+		AircraftConfigurationVersion: l.AircraftConfigurationVersion,
+		DataElements:                 dataElements,
+		CodeShares:                   make(map[common.FlightNumber]common.CodeShare),
+	}
+}
+
+// normalizeFlightNumbers converts a / separated list of flight designators (e.g. LH 0400) to the format of common.FlightNumber (e.g. LH400)
+func normalizeFlightNumbers(v string) string {
+	values := strings.Split(strings.ReplaceAll(v, " ", ""), "/")
+	for i, value := range values {
+		if fn, err := common.ParseFlightNumber(value); err == nil {
+			values[i] = fn.String()
+		}
+	}
+
+	return strings.Join(values, "/")
+}
+
+// Flights returns the flights of all carriers departing on the given UTC date, one per leg.
+// Codeshares are returned as separate flights, just like the Lufthansa API does.
+func (f *File) Flights(departureDateUTC xtime.LocalDate) []*common.Flight {
+	result := make([]*common.Flight, 0)
+	for _, c := range f.Carriers {
+		for _, l := range c.Legs {
+			// the flight date is at most one day apart from the UTC departure date of the leg
+			firstDate := departureDateUTC - xtime.LocalDate(l.DepartureDateVariation)
+			for flightDate := firstDate - 1; flightDate <= firstDate+1; flightDate++ {
+				if l.OperatesOn(flightDate) && xtime.NewLocalDate(l.DepartureTime(flightDate).UTC()) == departureDateUTC {
+					result = append(result, l.Flight(flightDate))
+				}
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,68 @@
+package ssim
+
+import (
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"iter"
+	"slices"
+)
+
+type Period struct {
+	From            xtime.LocalDate
+	To              xtime.LocalDate
+	DaysOfOperation [7]bool // indexed by time.Weekday
+}
+
+func (p Period) Contains(d xtime.LocalDate) bool {
+	return d >= p.From && d <= p.To && p.DaysOfOperation[d.Time(nil).Weekday()]
+}
+
+// NewPeriods splits the given dates into periods of operation. Every period contains exactly
+// the given dates between its From and To which fall on one of its days of operation.
+func NewPeriods(dates iter.Seq[xtime.LocalDate]) []Period {
+	sorted := slices.Compact(slices.Sorted(dates))
+	contained := make(map[xtime.LocalDate]struct{}, len(sorted))
+	for _, d := range sorted {
+		contained[d] = struct{}{}
+	}
+
+	// covers checks that all dates of p in the (exclusive) range from-to are contained
+	covers := func(p Period, from, to xtime.LocalDate) bool {
+		for d := from + 1; d < to; d++ {
+			if _, ok := contained[d]; !ok && p.Contains(d) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	periods := make([]Period, 0)
+	for i := 0; i < len(sorted); {
+		p := Period{From: sorted[i], To: sorted[i]}
+		p.DaysOfOperation[sorted[i].Time(nil).Weekday()] = true
+
+		j := i + 1
+		for ; j < len(sorted); j++ {
+			candidate := p
+			candidate.To = sorted[j]
+			candidate.DaysOfOperation[sorted[j].Time(nil).Weekday()] = true
+
+			from := p.To
+			if candidate.DaysOfOperation != p.DaysOfOperation {
+				// a new day of operation requires checking the whole period again
+				from = p.From
+			}
+
+			if !covers(candidate, from, candidate.To) {
+				break
+			}
+
+			p = candidate
+		}
+
+		periods = append(periods, p)
+		i = j
+	}
+
+	return periods
+}
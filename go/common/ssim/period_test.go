@@ -0,0 +1,51 @@
+package ssim
+
+import (
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewPeriods(t *testing.T) {
+	dates := []xtime.LocalDate{
+		// daily 2026-06-01 until 2026-06-07
+		xtime.MustParseLocalDate("2026-06-01"),
+		xtime.MustParseLocalDate("2026-06-02"),
+		xtime.MustParseLocalDate("2026-06-03"),
+		xtime.MustParseLocalDate("2026-06-04"),
+		xtime.MustParseLocalDate("2026-06-05"),
+		xtime.MustParseLocalDate("2026-06-06"),
+		xtime.MustParseLocalDate("2026-06-07"),
+		// mondays and thursdays until 2026-06-22
+		xtime.MustParseLocalDate("2026-06-08"),
+		xtime.MustParseLocalDate("2026-06-11"),
+		xtime.MustParseLocalDate("2026-06-15"),
+		xtime.MustParseLocalDate("2026-06-18"),
+		xtime.MustParseLocalDate("2026-06-22"),
+	}
+
+	periods := NewPeriods(slices.Values(dates))
+
+	// the daily period greedily extends to the following monday
+	assert.Equal(
+		t,
+		[]Period{
+			{From: xtime.MustParseLocalDate("2026-06-01"), To: xtime.MustParseLocalDate("2026-06-08"), DaysOfOperation: [7]bool{true, true, true, true, true, true, true}},
+			{From: xtime.MustParseLocalDate("2026-06-11"), To: xtime.MustParseLocalDate("2026-06-22"), DaysOfOperation: [7]bool{time.Monday: true, time.Thursday: true}},
+		},
+		periods,
+	)
+
+	var expanded []xtime.LocalDate
+	for _, p := range periods {
+		for d := range (xtime.LocalDateRange{p.From, p.To}).Iter {
+			if p.Contains(d) {
+				expanded = append(expanded, d)
+			}
+		}
+	}
+
+	assert.Equal(t, dates, expanded)
+}
@@ -0,0 +1,135 @@
+package ssim
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// FromFlightSchedules converts the schedules to a File in local time mode, with one carrier per airline.
+// Every period of operation of a variant is written as its own single-leg itinerary variation; codeshares are
+// written as duplicate legs referencing their operating flight (data element 50) and vice versa (data element 10).
+// Flights with more than 999 periods exceed the itinerary variation identifiers of SSIM and are rejected by Write.
+func FromFlightSchedules(schedules []*common.FlightSchedule, creationDate xtime.LocalDate) *File {
+	carriers := make(map[common.AirlineIdentifier]*Carrier)
+
+	for _, fs := range schedules {
+		fn := fs.Number()
+		c, ok := carriers[fn.Airline]
+		if !ok {
+			c = &Carrier{
+				Airline:      string(fn.Airline),
+				TimeMode:     TimeModeLocal,
+				CreationDate: creationDate,
+			}
+
+			carriers[fn.Airline] = c
+		}
+
+		legs := make([]*Leg, 0, len(fs.Variants))
+		for _, fsv := range fs.Variants {
+			for _, p := range NewPeriods(fsv.Ranges.Iter) {
+				legs = append(legs, newLeg(fn, fsv, p))
+			}
+		}
+
+		slices.SortFunc(legs, func(a, b *Leg) int {
+			return cmp.Or(
+				cmp.Compare(a.PeriodFrom, b.PeriodFrom),
+				cmp.Compare(a.DepartureStation, b.DepartureStation),
+				cmp.Compare(a.PeriodTo, b.PeriodTo),
+			)
+		})
+
+		for i, l := range legs {
+			l.ItineraryVariation = i + 1
+
+			if c.ValidFrom.IsZero() || l.PeriodFrom < c.ValidFrom {
+				c.ValidFrom = l.PeriodFrom
+			}
+
+			c.ValidTo = max(c.ValidTo, l.PeriodTo)
+		}
+
+		c.Legs = append(c.Legs, legs...)
+	}
+
+	f := &File{
+		Carriers: slices.SortedFunc(maps.Values(carriers), func(a, b *Carrier) int {
+			return cmp.Compare(a.Airline, b.Airline)
+		}),
+	}
+
+	for _, c := range f.Carriers {
+		slices.SortStableFunc(c.Legs, func(a, b *Leg) int {
+			return cmp.Or(
+				cmp.Compare(a.FlightNumber, b.FlightNumber),
+				cmp.Compare(a.OperationalSuffix, b.OperationalSuffix),
+			)
+		})
+	}
+
+	return f
+}
+
+func newLeg(fn common.FlightNumber, fsv *common.FlightScheduleVariant, p Period) *Leg {
+	departure := fsv.DepartureTime(p.From)
+	arrival := fsv.ArrivalTime(p.From)
+	arrivalDate := xtime.NewLocalDate(arrival)
+
+	l := &Leg{
+		TimeMode:                     TimeModeLocal,
+		OperationalSuffix:            fn.Suffix,
+		Airline:                      string(fn.Airline),
+		FlightNumber:                 fn.Number,
+		LegSequence:                  1,
+		ServiceType:                  fsv.Data.ServiceType,
+		PeriodFrom:                   p.From,
+		PeriodTo:                     p.To,
+		DaysOfOperation:              p.DaysOfOperation,
+		DepartureStation:             fsv.Data.DepartureAirport,
+		PassengerDepartureTime:       xtime.NewLocalTime(departure),
+		AircraftDepartureTime:        xtime.NewLocalTime(departure),
+		DepartureUTCOffset:           fsv.Data.DepartureUTCOffset,
+		ArrivalStation:               fsv.Data.ArrivalAirport,
+		AircraftArrivalTime:          xtime.NewLocalTime(arrival),
+		PassengerArrivalTime:         xtime.NewLocalTime(arrival),
+		ArrivalUTCOffset:             fsv.Data.ArrivalUTCOffset,
+		AircraftType:                 fsv.Data.AircraftType,
+		AircraftOwner:                string(fsv.Data.AircraftOwner),
+		AircraftConfigurationVersion: fsv.Data.AircraftConfigurationVersion,
+		ArrivalDateVariation:         p.From.DaysUntil(arrivalDate),
+	}
+
+	if fsv.Data.OperatedAs != fn {
+		l.DataElements = append(l.DataElements, DataElement{
+			Id:   DataElementOperatingFlight,
+			Data: FormatFlightNumber(fsv.Data.OperatedAs),
+		})
+	} else if len(fsv.Data.CodeShares) > 0 {
+		codeShares := slices.SortedFunc(maps.Keys(fsv.Data.CodeShares), func(a, b common.FlightNumber) int {
+			return cmp.Compare(a.String(), b.String())
+		})
+
+		values := make([]string, 0, len(codeShares))
+		for _, codeShare := range codeShares {
+			values = append(values, FormatFlightNumber(codeShare))
+		}
+
+		l.DataElements = append(l.DataElements, DataElement{
+			Id:   DataElementCodeShares,
+			Data: strings.Join(values, "/"),
+		})
+	}
+
+	return l
+}
+
+// FormatFlightNumber formats the flight number as flight designator (3 characters airline designator, 4 digits flight number and suffix)
+func FormatFlightNumber(fn common.FlightNumber) string {
+	return fmt.Sprintf("%-3s%04d%s", fn.Airline, fn.Number, fn.Suffix)
+}
@@ -0,0 +1,412 @@
+// Package ssim reads schedules in the fixed-width record format of the IATA Standard Schedules Information Manual, chapter 7
+package ssim
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const recordLength = 200
+
+const (
+	recordTypeHeader      = '1'
+	recordTypeCarrier     = '2'
+	recordTypeFlightLeg   = '3'
+	recordTypeSegmentData = '4'
+	recordTypeTrailer     = '5'
+	recordTypeZero        = '0'
+)
+
+const (
+	// DataElementCodeShares lists the marketing flight numbers of an operating leg, separated by /
+	DataElementCodeShares = 10
+	// DataElementOperatingFlight references the operating flight number of a duplicate (codeshare) leg
+	DataElementOperatingFlight = 50
+)
+
+type TimeMode byte
+
+const (
+	TimeModeUTC   = TimeMode('U')
+	TimeModeLocal = TimeMode('L')
+)
+
+type File struct {
+	Carriers []*Carrier
+}
+
+type Carrier struct {
+	Airline      string
+	TimeMode     TimeMode
+	Season       string
+	ValidFrom    xtime.LocalDate
+	ValidTo      xtime.LocalDate
+	CreationDate xtime.LocalDate
+	Legs         []*Leg
+}
+
+type DataElement struct {
+	Id         int
+	BoardPoint string
+	OffPoint   string
+	Data       string
+}
+
+type Leg struct {
+	TimeMode           TimeMode
+	OperationalSuffix  string
+	Airline            string
+	FlightNumber       int
+	ItineraryVariation int
+	LegSequence        int
+	ServiceType        string
+	// PeriodFrom and PeriodTo are the dates of the flight (its first leg) in the TimeMode of the carrier
+	PeriodFrom      xtime.LocalDate
+	PeriodTo        xtime.LocalDate
+	DaysOfOperation [7]bool // indexed by time.Weekday
+	// BiWeekly flights only operate every other week, starting with the week of PeriodFrom
+	BiWeekly bool
+
+	DepartureStation       string
+	PassengerDepartureTime xtime.LocalTime
+	AircraftDepartureTime  xtime.LocalTime
+	DepartureUTCOffset     int
+	DepartureTerminal      string
+	ArrivalStation         string
+	AircraftArrivalTime    xtime.LocalTime
+	PassengerArrivalTime   xtime.LocalTime
+	ArrivalUTCOffset       int
+	ArrivalTerminal        string
+
+	AircraftType                 string
+	AircraftOwner                string
+	AircraftConfigurationVersion string
+	// DepartureDateVariation and ArrivalDateVariation are the days between the flight date and the departure/arrival of this leg
+	DepartureDateVariation int
+	ArrivalDateVariation   int
+
+	DataElements []DataElement
+}
+
+func Read(r io.Reader) (*File, error) {
+	file := new(File)
+	var carrier *Carrier
+	var lastLegs []*Leg
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024), 64*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		rec := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(rec) == "" {
+			continue
+		}
+
+		if len(rec) < recordLength {
+			rec += strings.Repeat(" ", recordLength-len(rec))
+		}
+
+		var err error
+		switch rec[0] {
+		case recordTypeHeader, recordTypeTrailer, recordTypeZero:
+			continue
+
+		case recordTypeCarrier:
+			carrier, err = parseCarrier(rec)
+			if err == nil {
+				file.Carriers = append(file.Carriers, carrier)
+			}
+
+			lastLegs = nil
+
+		case recordTypeFlightLeg:
+			if carrier == nil {
+				return nil, fmt.Errorf("line %d: flight leg record without carrier record", line)
+			}
+
+			var leg *Leg
+			if leg, err = parseLeg(rec, carrier); err == nil {
+				carrier.Legs = append(carrier.Legs, leg)
+
+				if len(lastLegs) > 0 && !lastLegs[0].sameItinerary(leg) {
+					lastLegs = nil
+				}
+
+				lastLegs = append(lastLegs, leg)
+			}
+
+		case recordTypeSegmentData:
+			err = addSegmentData(rec, lastLegs)
+
+		default:
+			err = fmt.Errorf("unknown record type %q", rec[0])
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	return file, scanner.Err()
+}
+
+func parseCarrier(rec string) (*Carrier, error) {
+	c := &Carrier{
+		Airline:  field(rec, 3, 5),
+		TimeMode: TimeMode(rec[1]),
+		Season:   field(rec, 11, 13),
+	}
+
+	if c.TimeMode != TimeModeUTC && c.TimeMode != TimeModeLocal {
+		return nil, fmt.Errorf("invalid time mode %q", rec[1])
+	}
+
+	var err error
+	c.ValidFrom, err = parseDate(field(rec, 15, 21))
+	if err != nil {
+		return nil, err
+	}
+
+	c.ValidTo, err = parseDate(field(rec, 22, 28))
+	if err != nil {
+		return nil, err
+	}
+
+	c.CreationDate, err = parseDate(field(rec, 29, 35))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parseLeg(rec string, c *Carrier) (*Leg, error) {
+	l := &Leg{
+		TimeMode:                     c.TimeMode,
+		OperationalSuffix:            field(rec, 2, 2),
+		Airline:                      field(rec, 3, 5),
+		ServiceType:                  field(rec, 14, 14),
+		BiWeekly:                     field(rec, 36, 36) == "2",
+		DepartureStation:             field(rec, 37, 39),
+		DepartureTerminal:            field(rec, 53, 54),
+		ArrivalStation:               field(rec, 55, 57),
+		ArrivalTerminal:              field(rec, 71, 72),
+		AircraftType:                 field(rec, 73, 75),
+		AircraftOwner:                field(rec, 129, 131),
+		AircraftConfigurationVersion: field(rec, 173, 192),
+	}
+
+	var errs []error
+	var err error
+
+	l.FlightNumber, err = strconv.Atoi(field(rec, 6, 9))
+	errs = append(errs, err)
+
+	l.ItineraryVariation, err = strconv.Atoi(field(rec, 10, 11))
+	errs = append(errs, err)
+
+	l.LegSequence, err = strconv.Atoi(field(rec, 12, 13))
+	errs = append(errs, err)
+
+	if overflow := field(rec, 128, 128); overflow != "" {
+		var v int
+		v, err = strconv.Atoi(overflow)
+		l.ItineraryVariation += v * 100
+		errs = append(errs, err)
+	}
+
+	l.PeriodFrom, err = parseDate(field(rec, 15, 21))
+	errs = append(errs, err)
+
+	l.PeriodTo, err = parseDate(field(rec, 22, 28))
+	errs = append(errs, err)
+
+	for i, r := range rec[28:35] {
+		if r == ' ' {
+			continue
+		}
+
+		if r != rune('1'+i) {
+			errs = append(errs, fmt.Errorf("invalid days of operation %q", rec[28:35]))
+			break
+		}
+
+		l.DaysOfOperation[(i+1)%7] = true
+	}
+
+	l.PassengerDepartureTime, err = parseTime(field(rec, 40, 43))
+	errs = append(errs, err)
+
+	l.AircraftDepartureTime, err = parseTime(field(rec, 44, 47))
+	errs = append(errs, err)
+
+	l.DepartureUTCOffset, err = parseUTCOffset(field(rec, 48, 52))
+	errs = append(errs, err)
+
+	l.AircraftArrivalTime, err = parseTime(field(rec, 58, 61))
+	errs = append(errs, err)
+
+	l.PassengerArrivalTime, err = parseTime(field(rec, 62, 65))
+	errs = append(errs, err)
+
+	l.ArrivalUTCOffset, err = parseUTCOffset(field(rec, 66, 70))
+	errs = append(errs, err)
+
+	l.DepartureDateVariation, err = parseDateVariation(rec[192])
+	errs = append(errs, err)
+
+	l.ArrivalDateVariation, err = parseDateVariation(rec[193])
+	errs = append(errs, err)
+
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if l.PeriodTo.IsZero() {
+		// open-ended periods last until the end of the schedule
+		l.PeriodTo = c.ValidTo
+	}
+
+	if l.AircraftOwner == "" {
+		l.AircraftOwner = l.Airline
+	}
+
+	return l, nil
+}
+
+func addSegmentData(rec string, legs []*Leg) error {
+	if len(legs) < 1 {
+		return errors.New("segment data record without flight leg record")
+	}
+
+	fn, err := strconv.Atoi(field(rec, 6, 9))
+	if err != nil {
+		return err
+	}
+
+	if legs[0].Airline != field(rec, 3, 5) || legs[0].FlightNumber != fn {
+		return errors.New("segment data record does not belong to the preceding flight leg record")
+	}
+
+	id, err := strconv.Atoi(field(rec, 31, 33))
+	if err != nil {
+		return err
+	}
+
+	de := DataElement{
+		Id:         id,
+		BoardPoint: field(rec, 34, 36),
+		OffPoint:   field(rec, 37, 39),
+		Data:       field(rec, 40, 194),
+	}
+
+	// segment data may span multiple legs of a flight (e.g. A-C of A-B-C); it applies to all legs in between
+	inSegment := false
+	for _, l := range legs {
+		if l.DepartureStation == de.BoardPoint {
+			inSegment = true
+		}
+
+		if inSegment {
+			l.DataElements = append(l.DataElements, de)
+		}
+
+		if l.ArrivalStation == de.OffPoint {
+			inSegment = false
+		}
+	}
+
+	return nil
+}
+
+func (l *Leg) sameItinerary(other *Leg) bool {
+	return l.Airline == other.Airline &&
+		l.FlightNumber == other.FlightNumber &&
+		l.OperationalSuffix == other.OperationalSuffix &&
+		l.ItineraryVariation == other.ItineraryVariation &&
+		l.LegSequence < other.LegSequence
+}
+
+// field returns the trimmed value of the given 1-based, inclusive columns, as documented by SSIM
+func field(rec string, from, to int) string {
+	return strings.TrimSpace(rec[from-1 : to])
+}
+
+// parseDate parses dates formatted as 02JAN06; 00XXX00 denotes an open date and is parsed as zero
+func parseDate(v string) (xtime.LocalDate, error) {
+	if v == "" || v == "00XXX00" {
+		return 0, nil
+	}
+
+	if len(v) != 7 {
+		return 0, fmt.Errorf("invalid date %q", v)
+	}
+
+	t, err := time.Parse("02Jan06", v[:3]+strings.ToLower(v[3:5])+v[5:])
+	if err != nil {
+		return 0, err
+	}
+
+	return xtime.NewLocalDate(t), nil
+}
+
+func parseTime(v string) (xtime.LocalTime, error) {
+	if len(v) != 4 {
+		return 0, fmt.Errorf("invalid time %q", v)
+	}
+
+	hours, err := strconv.Atoi(v[:2])
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := strconv.Atoi(v[2:])
+	if err != nil {
+		return 0, err
+	}
+
+	return xtime.LocalTime(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute), nil
+}
+
+// parseUTCOffset parses variations formatted as +0130 to seconds
+func parseUTCOffset(v string) (int, error) {
+	if len(v) != 5 || (v[0] != '+' && v[0] != '-') {
+		return 0, fmt.Errorf("invalid utc offset %q", v)
+	}
+
+	t, err := parseTime(v[1:])
+	if err != nil {
+		return 0, err
+	}
+
+	seconds := int(time.Duration(t).Seconds())
+	if v[0] == '-' {
+		seconds = -seconds
+	}
+
+	return seconds, nil
+}
+
+func parseDateVariation(v byte) (int, error) {
+	switch {
+	case v == ' ':
+		return 0, nil
+
+	case v == 'A':
+		return -1, nil
+
+	case v >= '0' && v <= '9':
+		return int(v - '0'), nil
+	}
+
+	return 0, fmt.Errorf("invalid date variation %q", v)
+}
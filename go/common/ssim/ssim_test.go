@@ -0,0 +1,153 @@
+package ssim
+
+import (
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readSample(t *testing.T) *File {
+	f, err := os.Open("testdata/sample.ssim")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	defer f.Close()
+
+	file, err := Read(f)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return file
+}
+
+func findFlight(flights []*common.Flight, fn, departureAirport string) *common.Flight {
+	for _, f := range flights {
+		if f.Number().String() == fn && f.DepartureAirport == departureAirport {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func TestRead(t *testing.T) {
+	file := readSample(t)
+
+	if !assert.Len(t, file.Carriers, 2) {
+		return
+	}
+
+	de := file.Carriers[0]
+	assert.Equal(t, "DE", de.Airline)
+	assert.Equal(t, TimeModeLocal, de.TimeMode)
+	assert.Equal(t, "S26", de.Season)
+	assert.Equal(t, xtime.MustParseLocalDate("2026-03-29"), de.ValidFrom)
+	assert.Equal(t, xtime.MustParseLocalDate("2026-10-24"), de.ValidTo)
+	assert.Len(t, de.Legs, 5)
+
+	leg := de.Legs[0]
+	assert.Equal(t, 2014, leg.FlightNumber)
+	assert.Equal(t, "FRA", leg.DepartureStation)
+	assert.Equal(t, "JFK", leg.ArrivalStation)
+	assert.Equal(t, xtime.MustParseLocalTime("13:30:00"), leg.AircraftDepartureTime)
+	assert.Equal(t, 7200, leg.DepartureUTCOffset)
+	assert.Equal(t, -14400, leg.ArrivalUTCOffset)
+	assert.Equal(t, "DE", leg.AircraftOwner)
+	assert.Equal(t, "C30Y280", leg.AircraftConfigurationVersion)
+	assert.Equal(t, []DataElement{{Id: 10, BoardPoint: "FRA", OffPoint: "JFK", Data: "WS 7001/AS 6050"}}, leg.DataElements)
+
+	// open-ended period
+	assert.Equal(t, de.ValidTo, de.Legs[2].PeriodTo)
+	assert.Equal(t, "4Y", de.Legs[2].AircraftOwner)
+	// segment data spanning both legs
+	assert.Len(t, de.Legs[2].DataElements, 1)
+	assert.Len(t, de.Legs[3].DataElements, 1)
+}
+
+func TestRead_Invalid(t *testing.T) {
+	_, err := Read(strings.NewReader("3 DE 20140101J01JUN2607JUN261234567 FRA13301330+0200  JFK16001600-0400  339\n"))
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestLeg_FlightDates(t *testing.T) {
+	file := readSample(t)
+
+	assert.Equal(
+		t,
+		[]xtime.LocalDate{
+			xtime.MustParseLocalDate("2026-06-01"),
+			xtime.MustParseLocalDate("2026-06-04"),
+			xtime.MustParseLocalDate("2026-06-08"),
+		},
+		slices.Collect(func(yield func(xtime.LocalDate) bool) {
+			for d := range file.Carriers[0].Legs[1].FlightDates {
+				if d > xtime.MustParseLocalDate("2026-06-10") || !yield(d) {
+					return
+				}
+			}
+		}),
+	)
+
+	// bi-weekly
+	assert.Equal(
+		t,
+		[]xtime.LocalDate{
+			xtime.MustParseLocalDate("2026-06-01"),
+			xtime.MustParseLocalDate("2026-06-15"),
+			xtime.MustParseLocalDate("2026-06-29"),
+		},
+		slices.Collect(file.Carriers[0].Legs[4].FlightDates),
+	)
+}
+
+func TestFile_Flights(t *testing.T) {
+	file := readSample(t)
+	flights := file.Flights(xtime.MustParseLocalDate("2026-06-01"))
+
+	f := findFlight(flights, "DE2014", "FRA")
+	if assert.NotNil(t, f) {
+		assert.True(t, f.DepartureTime.Equal(time.Date(2026, time.June, 1, 11, 30, 0, 0, time.UTC)))
+		assert.True(t, f.ArrivalTime.Equal(time.Date(2026, time.June, 1, 20, 0, 0, 0, time.UTC)))
+		assert.Equal(t, xtime.MustParseLocalDate("2026-06-01"), f.DepartureDateLocal())
+		assert.Equal(t, "WS7001/AS6050", f.DataElements[DataElementCodeShares])
+	}
+
+	// the duplicate leg, given in UTC
+	f = findFlight(flights, "WS7001", "FRA")
+	if assert.NotNil(t, f) {
+		assert.True(t, f.DepartureTime.Equal(time.Date(2026, time.June, 1, 11, 30, 0, 0, time.UTC)))
+		assert.Equal(t, 7200, offset(f.DepartureTime))
+		assert.Equal(t, "DE2014", f.DataElements[DataElementOperatingFlight])
+		assert.Equal(t, common.AirlineIdentifier("DE"), f.AircraftOwner)
+	}
+
+	f = findFlight(flights, "DE2270", "FRA")
+	if assert.NotNil(t, f) {
+		assert.Equal(t, xtime.MustParseLocalDate("2026-06-02"), xtime.NewLocalDate(f.ArrivalTime))
+	}
+
+	// the second leg of DE1234 departs on the following day
+	assert.NotNil(t, findFlight(flights, "DE1234", "FRA"))
+	assert.Nil(t, findFlight(flights, "DE1234", "LPA"))
+
+	f = findFlight(file.Flights(xtime.MustParseLocalDate("2026-06-02")), "DE1234", "LPA")
+	if assert.NotNil(t, f) {
+		assert.True(t, f.DepartureTime.Equal(time.Date(2026, time.June, 2, 1, 30, 0, 0, time.UTC)))
+		assert.Equal(t, "FUE", f.ArrivalAirport)
+		assert.Equal(t, "LH5678", f.DataElements[DataElementCodeShares])
+	}
+
+	assert.Nil(t, findFlight(file.Flights(xtime.MustParseLocalDate("2026-06-08")), "DE9", "FRA"))
+}
+
+func offset(t time.Time) int {
+	_, o := t.Zone()
+	return o
+}
@@ -0,0 +1,271 @@
+package ssim
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// recordsPerBlock is the number of records of a physical block; blocks are filled with zero records
+const recordsPerBlock = 5
+
+// maxItineraryVariation is the largest itinerary variation identifier representable by its two digits and the overflow digit
+const maxItineraryVariation = 999
+
+type record [recordLength]byte
+
+func newRecord(recordType byte) *record {
+	var r record
+	for i := range r {
+		r[i] = ' '
+	}
+
+	r[0] = recordType
+	return &r
+}
+
+// set writes v to the given 1-based column, as documented by SSIM
+func (r *record) set(col int, v string) {
+	copy(r[col-1:], v)
+}
+
+type writer struct {
+	w       *bufio.Writer
+	serial  int
+	inBlock int
+}
+
+// Write writes the File as SSIM chapter 7 data set.
+// It fails without writing anything if an itinerary variation identifier is outside 1-999.
+func Write(w io.Writer, f *File) error {
+	for _, c := range f.Carriers {
+		for _, l := range c.Legs {
+			if l.ItineraryVariation < 1 || l.ItineraryVariation > maxItineraryVariation {
+				return fmt.Errorf("%s%d%s: itinerary variation %d is outside 1-%d", l.Airline, l.FlightNumber, l.OperationalSuffix, l.ItineraryVariation, maxItineraryVariation)
+			}
+		}
+	}
+
+	sw := &writer{w: bufio.NewWriter(w)}
+
+	header := newRecord(recordTypeHeader)
+	header.set(2, "AIRLINE STANDARD SCHEDULE DATA SET")
+	header.set(192, "001")
+	sw.write(header)
+	sw.fillBlock()
+
+	for _, c := range f.Carriers {
+		sw.write(carrierRecord(c))
+		sw.fillBlock()
+
+		for i := 0; i < len(c.Legs); {
+			itinerary := []*Leg{c.Legs[i]}
+			for i++; i < len(c.Legs) && itinerary[0].sameItinerary(c.Legs[i]); i++ {
+				itinerary = append(itinerary, c.Legs[i])
+			}
+
+			for _, l := range itinerary {
+				sw.write(legRecord(l))
+			}
+
+			for _, r := range segmentRecords(itinerary) {
+				sw.write(r)
+			}
+		}
+
+		sw.fillBlock()
+
+		trailer := newRecord(recordTypeTrailer)
+		trailer.set(3, fmt.Sprintf("%-3s", c.Airline))
+		trailer.set(188, fmt.Sprintf("%06d", sw.serial))
+		trailer.set(194, "E")
+		sw.write(trailer)
+		sw.fillBlock()
+	}
+
+	return sw.w.Flush()
+}
+
+func (sw *writer) write(r *record) {
+	sw.inBlock = (sw.inBlock + 1) % recordsPerBlock
+
+	if r[0] != recordTypeZero {
+		sw.serial++
+		r.set(195, fmt.Sprintf("%06d", sw.serial))
+	}
+
+	_, _ = sw.w.Write(r[:])
+	_ = sw.w.WriteByte('\n')
+}
+
+func (sw *writer) fillBlock() {
+	for sw.inBlock != 0 {
+		r := newRecord(recordTypeZero)
+		for i := range r {
+			r[i] = '0'
+		}
+
+		sw.write(r)
+	}
+}
+
+func carrierRecord(c *Carrier) *record {
+	r := newRecord(recordTypeCarrier)
+	r.set(2, string(c.TimeMode))
+	r.set(3, fmt.Sprintf("%-3s", c.Airline))
+	r.set(11, c.Season)
+	r.set(15, formatDate(c.ValidFrom))
+	r.set(22, formatDate(c.ValidTo))
+	r.set(29, formatDate(c.CreationDate))
+
+	return r
+}
+
+func legRecord(l *Leg) *record {
+	days := []byte("       ")
+	for i := range days {
+		if l.DaysOfOperation[(i+1)%7] {
+			days[i] = byte('1' + i)
+		}
+	}
+
+	r := newRecord(recordTypeFlightLeg)
+	r.set(2, l.OperationalSuffix)
+	r.set(3, fmt.Sprintf("%-3s", l.Airline))
+	r.set(6, fmt.Sprintf("%04d", l.FlightNumber))
+	r.set(10, fmt.Sprintf("%02d", l.ItineraryVariation%100))
+	r.set(12, fmt.Sprintf("%02d", l.LegSequence))
+	r.set(14, l.ServiceType)
+	r.set(15, formatDate(l.PeriodFrom))
+	r.set(22, formatDate(l.PeriodTo))
+	r.set(29, string(days))
+
+	if l.BiWeekly {
+		r.set(36, "2")
+	}
+
+	r.set(37, l.DepartureStation)
+	r.set(40, formatTime(l.PassengerDepartureTime))
+	r.set(44, formatTime(l.AircraftDepartureTime))
+	r.set(48, formatUTCOffset(l.DepartureUTCOffset))
+	r.set(53, l.DepartureTerminal)
+	r.set(55, l.ArrivalStation)
+	r.set(58, formatTime(l.AircraftArrivalTime))
+	r.set(62, formatTime(l.PassengerArrivalTime))
+	r.set(66, formatUTCOffset(l.ArrivalUTCOffset))
+	r.set(71, l.ArrivalTerminal)
+	r.set(73, l.AircraftType)
+
+	if l.ItineraryVariation >= 100 {
+		r.set(128, fmt.Sprintf("%d", l.ItineraryVariation/100))
+	}
+
+	r.set(129, l.AircraftOwner)
+	r.set(173, l.AircraftConfigurationVersion)
+	r.set(193, formatDateVariation(l.DepartureDateVariation))
+	r.set(194, formatDateVariation(l.ArrivalDateVariation))
+
+	return r
+}
+
+// segmentRecords writes the data elements of all legs of an itinerary, once per segment
+func segmentRecords(itinerary []*Leg) []*record {
+	first := itinerary[0]
+	stations := []string{first.DepartureStation}
+	for _, l := range itinerary {
+		stations = append(stations, l.ArrivalStation)
+	}
+
+	// board and off point indicators identify stations by their position in the itinerary, starting at A
+	indicator := func(station string, fallback int) string {
+		idx := slices.Index(stations, station)
+		if idx < 0 {
+			idx = fallback
+		}
+
+		return string(rune('A' + idx))
+	}
+
+	written := make(map[DataElement]struct{})
+	records := make([]*record, 0)
+
+	for i, l := range itinerary {
+		for _, de := range l.DataElements {
+			de.BoardPoint = cmp.Or(de.BoardPoint, l.DepartureStation)
+			de.OffPoint = cmp.Or(de.OffPoint, l.ArrivalStation)
+
+			if _, ok := written[de]; ok {
+				continue
+			}
+
+			written[de] = struct{}{}
+
+			r := newRecord(recordTypeSegmentData)
+			r.set(2, first.OperationalSuffix)
+			r.set(3, fmt.Sprintf("%-3s", first.Airline))
+			r.set(6, fmt.Sprintf("%04d", first.FlightNumber))
+			r.set(10, fmt.Sprintf("%02d", first.ItineraryVariation%100))
+			r.set(12, fmt.Sprintf("%02d", l.LegSequence))
+			r.set(14, l.ServiceType)
+
+			if first.ItineraryVariation >= 100 {
+				r.set(28, fmt.Sprintf("%d", first.ItineraryVariation/100))
+			}
+
+			r.set(29, indicator(de.BoardPoint, i))
+			r.set(30, indicator(de.OffPoint, i+1))
+			r.set(31, fmt.Sprintf("%03d", de.Id))
+			r.set(34, de.BoardPoint)
+			r.set(37, de.OffPoint)
+			r.set(40, truncate(de.Data, 155))
+
+			records = append(records, r)
+		}
+	}
+
+	return records
+}
+
+func formatDate(d xtime.LocalDate) string {
+	if d.IsZero() {
+		return "00XXX00"
+	}
+
+	return strings.ToUpper(d.Time(nil).Format("02Jan06"))
+}
+
+func formatTime(lt xtime.LocalTime) string {
+	d := time.Duration(lt)
+	return fmt.Sprintf("%02d%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func formatUTCOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+func formatDateVariation(v int) string {
+	if v < 0 {
+		return "A"
+	}
+
+	return fmt.Sprintf("%d", min(v, 9))
+}
+
+func truncate(v string, length int) string {
+	if len(v) > length {
+		return v[:length]
+	}
+
+	return v
+}
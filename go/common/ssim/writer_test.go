@@ -0,0 +1,109 @@
+package ssim
+
+import (
+	"bytes"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite_RoundTrip(t *testing.T) {
+	original := readSample(t)
+
+	var buf bytes.Buffer
+	if !assert.NoError(t, Write(&buf, original)) {
+		return
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Zero(t, len(lines)%recordsPerBlock)
+	for _, line := range lines {
+		assert.Len(t, line, recordLength)
+	}
+
+	written, err := Read(&buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, original, written)
+}
+
+func TestFromFlightSchedules(t *testing.T) {
+	lh400 := common.FlightNumber{Airline: "LH", Number: 400}
+	ua9051 := common.FlightNumber{Airline: "UA", Number: 9051}
+	data := common.FlightScheduleData{
+		OperatedAs:                   lh400,
+		DepartureTime:                xtime.MustParseLocalTime("22:00:00"),
+		DepartureAirport:             "FRA",
+		DepartureUTCOffset:           7200,
+		DurationSeconds:              int64((8*time.Hour + 30*time.Minute).Seconds()),
+		ArrivalAirport:               "JFK",
+		ArrivalUTCOffset:             -14400,
+		ServiceType:                  "J",
+		AircraftOwner:                "LH",
+		AircraftType:                 "359",
+		AircraftConfigurationVersion: "C48E21M224",
+		CodeShares:                   common.Set[common.FlightNumber]{ua9051: {}},
+	}
+
+	var ranges xtime.LocalDateRanges
+	for d := range (xtime.LocalDateRange{xtime.MustParseLocalDate("2026-06-01"), xtime.MustParseLocalDate("2026-06-14")}).Iter {
+		ranges = ranges.Add(d)
+	}
+
+	codeShareData := data
+	codeShareData.CodeShares = nil
+
+	schedules := []*common.FlightSchedule{
+		{Airline: "UA", FlightNumber: 9051, Variants: []*common.FlightScheduleVariant{{Ranges: ranges, Data: codeShareData}}},
+		{Airline: "LH", FlightNumber: 400, Variants: []*common.FlightScheduleVariant{{Ranges: ranges, Data: data}}},
+	}
+
+	f := FromFlightSchedules(schedules, xtime.MustParseLocalDate("2026-05-01"))
+
+	var buf bytes.Buffer
+	if !assert.NoError(t, Write(&buf, f)) {
+		return
+	}
+
+	f, err := Read(&buf)
+	if !assert.NoError(t, err) || !assert.Len(t, f.Carriers, 2) {
+		return
+	}
+
+	lh := f.Carriers[0]
+	assert.Equal(t, "LH", lh.Airline)
+	assert.Equal(t, xtime.MustParseLocalDate("2026-06-01"), lh.ValidFrom)
+	assert.Equal(t, xtime.MustParseLocalDate("2026-06-14"), lh.ValidTo)
+
+	flights := f.Flights(xtime.MustParseLocalDate("2026-06-03"))
+	if !assert.Len(t, flights, 2) {
+		return
+	}
+
+	operating := findFlight(flights, "LH400", "FRA")
+	if assert.NotNil(t, operating) {
+		assert.True(t, operating.DepartureTime.Equal(time.Date(2026, time.June, 3, 20, 0, 0, 0, time.UTC)))
+		assert.Equal(t, time.Duration(data.DurationSeconds)*time.Second, operating.Duration())
+		assert.Equal(t, "UA9051", operating.DataElements[DataElementCodeShares])
+		assert.Equal(t, "C48E21M224", operating.AircraftConfigurationVersion)
+	}
+
+	codeShare := findFlight(flights, "UA9051", "FRA")
+	if assert.NotNil(t, codeShare) {
+		assert.Equal(t, "LH400", codeShare.DataElements[DataElementOperatingFlight])
+	}
+}
+
+func TestWrite_ItineraryVariationOverflow(t *testing.T) {
+	f := readSample(t)
+	f.Carriers[0].Legs[0].ItineraryVariation = maxItineraryVariation + 1
+
+	var buf bytes.Buffer
+	assert.Error(t, Write(&buf, f))
+	assert.Zero(t, buf.Len())
+}
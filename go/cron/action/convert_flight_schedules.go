@@ -10,7 +10,6 @@ import (
 	"github.com/explore-flights/monorepo/go/common"
 	"github.com/explore-flights/monorepo/go/common/adapt"
 	"github.com/explore-flights/monorepo/go/common/concurrent"
-	"github.com/explore-flights/monorepo/go/common/lufthansa"
 	"github.com/explore-flights/monorepo/go/common/xtime"
 	"strings"
 	"sync"
@@ -28,15 +27,22 @@ type Pair[T1, T2 any] struct {
 }
 
 type ConvertFlightSchedulesParams struct {
+	// Source is either lufthansa (default), reading one file per query date below InputPrefix,
+	// or ssim, reading the single SSIM chapter 7 file at InputKey
+	Source       string                `json:"source,omitempty"`
 	InputBucket  string                `json:"inputBucket"`
 	InputPrefix  string                `json:"inputPrefix"`
+	InputKey     string                `json:"inputKey,omitempty"`
 	OutputBucket string                `json:"outputBucket"`
 	OutputPrefix string                `json:"outputPrefix"`
 	DateRanges   xtime.LocalDateRanges `json:"dateRanges"`
+	// Validation enables quarantining invalid flights instead of publishing them
+	Validation *FlightValidationParams `json:"validation,omitempty"`
 }
 
 type ConvertFlightSchedulesOutput struct {
-	DateRanges xtime.LocalDateRanges `json:"dateRanges"`
+	DateRanges  xtime.LocalDateRanges `json:"dateRanges"`
+	Quarantined int                   `json:"quarantined,omitempty"`
 }
 
 type cfsAction struct {
@@ -52,28 +58,51 @@ func (a *cfsAction) Handle(ctx context.Context, params ConvertFlightSchedulesPar
 	defer cancel()
 
 	var output ConvertFlightSchedulesOutput
-	var err error
+
+	source, err := newFlightSource(ctx, a.s3c, params)
+	if err != nil {
+		return output, err
+	}
+
+	var vs *validatingFlightSource
+	if params.Validation != nil {
+		validator, err := newFlightValidator(ctx, a.s3c, *params.Validation)
+		if err != nil {
+			return output, err
+		}
+
+		vs = &validatingFlightSource{
+			s3c:       a.s3c,
+			source:    source,
+			validator: validator,
+			params:    *params.Validation,
+		}
+		source = vs
+	}
 
 	output.DateRanges, err = a.convertAndUpsertAll(
 		ctx,
-		params.InputBucket,
-		params.InputPrefix,
+		source,
 		params.OutputBucket,
 		params.OutputPrefix,
 		params.DateRanges,
 	)
 
+	if vs != nil {
+		output.Quarantined = int(vs.quarantined.Load())
+	}
+
 	return output, err
 }
 
-func (a *cfsAction) convertAndUpsertAll(ctx context.Context, inputBucket, inputPrefix, outputBucket, outputPrefix string, ldrs xtime.LocalDateRanges) (xtime.LocalDateRanges, error) {
+func (a *cfsAction) convertAndUpsertAll(ctx context.Context, source FlightSource, outputBucket, outputPrefix string, ldrs xtime.LocalDateRanges) (xtime.LocalDateRanges, error) {
 	locks := concurrent.NewMap[xtime.LocalDate, *sync.Mutex]()
 	wg := concurrent.WorkGroup[xtime.LocalDate, xtime.LocalDateRanges, xtime.LocalDateRanges]{
 		Parallelism: 10,
 		Worker: func(ctx context.Context, queryDate xtime.LocalDate, acc xtime.LocalDateRanges) (xtime.LocalDateRanges, error) {
 			fmt.Printf("loading and converting %v\n", queryDate)
 
-			flights, err := a.convertSingle(ctx, inputBucket, inputPrefix, queryDate)
+			flights, err := a.convertSingle(ctx, source, queryDate)
 			if err != nil {
 				return acc, err
 			}
@@ -121,33 +150,13 @@ func (a *cfsAction) convertAndUpsertAll(ctx context.Context, inputBucket, inputP
 	return wg.RunSeq(ctx, ldrs.Iter)
 }
 
-func (a *cfsAction) convertSingle(ctx context.Context, inputBucket, inputPrefix string, d xtime.LocalDate) ([]*common.Flight, error) {
-	lastModified, schedules, err := a.loadFlightSchedules(ctx, inputBucket, inputPrefix, d)
+func (a *cfsAction) convertSingle(ctx context.Context, source FlightSource, d xtime.LocalDate) ([]*common.Flight, error) {
+	lastModified, flights, err := source.Flights(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
-	return convertFlightSchedulesToFlights(d, lastModified, schedules)
-}
-
-func (a *cfsAction) loadFlightSchedules(ctx context.Context, bucket, prefix string, d xtime.LocalDate) (time.Time, []lufthansa.FlightSchedule, error) {
-	resp, err := a.s3c.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(prefix + d.Time(nil).Format("2006/01/02") + ".json"),
-	})
-
-	if err != nil {
-		if adapt.IsS3NotFound(err) {
-			err = nil
-		}
-
-		return time.Time{}, nil, err
-	}
-
-	defer resp.Body.Close()
-
-	var schedules []lufthansa.FlightSchedule
-	return *resp.LastModified, schedules, json.NewDecoder(resp.Body).Decode(&schedules)
+	return mergeCodeShares(d, lastModified, flights)
 }
 
 func (a *cfsAction) upsertFlights(ctx context.Context, bucket, prefix string, d xtime.LocalDate, queryDate xtime.LocalDate, flights []*common.Flight) error {
@@ -215,82 +224,66 @@ func (a *cfsAction) loadFlights(ctx context.Context, bucket, s3Key string) ([]*c
 	return flights, json.NewDecoder(resp.Body).Decode(&flights)
 }
 
-func convertFlightSchedulesToFlights(queryDate xtime.LocalDate, lastModified time.Time, schedules []lufthansa.FlightSchedule) ([]*common.Flight, error) {
+// mergeCodeShares attaches the codeshares provided by a FlightSource to their operating flights
+func mergeCodeShares(queryDate xtime.LocalDate, lastModified time.Time, flights []*common.Flight) ([]*common.Flight, error) {
 	lookup := make(map[common.FlightId]*common.Flight)
 	codeShareIds := make(map[common.FlightId]struct{})
 	addLater := make(map[common.FlightId][]*common.Flight)
 
-	for _, fs := range schedules {
-		for _, leg := range fs.Legs {
-			f := &common.Flight{
-				Airline:                      common.AirlineIdentifier(fs.Airline),
-				FlightNumber:                 fs.FlightNumber,
-				Suffix:                       fs.Suffix,
-				DepartureTime:                leg.DepartureTime(fs.PeriodOfOperationUTC.StartDate),
-				DepartureAirport:             leg.Origin,
-				ArrivalTime:                  leg.ArrivalTime(fs.PeriodOfOperationUTC.StartDate),
-				ArrivalAirport:               leg.Destination,
-				ServiceType:                  leg.ServiceType,
-				AircraftOwner:                common.AirlineIdentifier(leg.AircraftOwner),
-				AircraftType:                 leg.AircraftType,
-				AircraftConfigurationVersion: leg.AircraftConfigurationVersion,
-				Registration:                 leg.Registration,
-				DataElements:                 fs.DataElementsForSequence(leg.SequenceNumber),
-				CodeShares:                   make(map[common.FlightNumber]common.CodeShare),
-				Metadata: common.FlightMetadata{
-					QueryDate:    queryDate,
-					CreationTime: lastModified,
-					UpdateTime:   lastModified,
-				},
-			}
+	for _, f := range flights {
+		f.CodeShares = make(map[common.FlightNumber]common.CodeShare)
+		f.Metadata = common.FlightMetadata{
+			QueryDate:    queryDate,
+			CreationTime: lastModified,
+			UpdateTime:   lastModified,
+		}
 
-			lookup[f.Id()] = f
+		lookup[f.Id()] = f
 
-			if codeSharesRaw := f.DataElements[codeShareChildId]; codeSharesRaw != "" {
-				// this flight has codeshares
-				for _, codeShare := range strings.Split(codeSharesRaw, "/") {
-					codeShareFn, err := common.ParseFlightNumber(codeShare)
-					if err != nil {
-						return nil, err
-					}
+		if codeSharesRaw := f.DataElements[codeShareChildId]; codeSharesRaw != "" {
+			// this flight has codeshares
+			for _, codeShare := range strings.Split(codeSharesRaw, "/") {
+				codeShareFn, err := common.ParseFlightNumber(codeShare)
+				if err != nil {
+					return nil, err
+				}
 
-					if _, ok := f.CodeShares[codeShareFn]; !ok {
-						f.CodeShares[codeShareFn] = common.CodeShare{
-							DataElements: make(map[int]string),
-							Metadata: common.FlightMetadata{
-								QueryDate:    queryDate,
-								CreationTime: lastModified,
-								UpdateTime:   lastModified,
-							},
-						}
+				if _, ok := f.CodeShares[codeShareFn]; !ok {
+					f.CodeShares[codeShareFn] = common.CodeShare{
+						DataElements: make(map[int]string),
+						Metadata: common.FlightMetadata{
+							QueryDate:    queryDate,
+							CreationTime: lastModified,
+							UpdateTime:   lastModified,
+						},
 					}
-
-					// mark as codeshare
-					codeShareIds[codeShareFn.Id(f.DepartureLocal())] = struct{}{}
 				}
+
+				// mark as codeshare
+				codeShareIds[codeShareFn.Id(f.DepartureLocal())] = struct{}{}
 			}
+		}
 
-			if codeShare := f.DataElements[codeShareParentId]; codeShare != "" {
-				// this flight is a codeshare
-				parentFn, err := common.ParseFlightNumber(codeShare)
-				if err != nil {
-					return nil, err
-				}
+		if codeShare := f.DataElements[codeShareParentId]; codeShare != "" {
+			// this flight is a codeshare
+			parentFn, err := common.ParseFlightNumber(codeShare)
+			if err != nil {
+				return nil, err
+			}
 
-				parentFid := parentFn.Id(f.DepartureLocal())
+			parentFid := parentFn.Id(f.DepartureLocal())
 
-				if parent, ok := lookup[parentFid]; ok {
-					parent.CodeShares[f.Number()] = common.CodeShare{
-						DataElements: f.DataElements,
-						Metadata:     f.Metadata,
-					}
-				} else {
-					addLater[parentFid] = append(addLater[parentFid], f)
+			if parent, ok := lookup[parentFid]; ok {
+				parent.CodeShares[f.Number()] = common.CodeShare{
+					DataElements: f.DataElements,
+					Metadata:     f.Metadata,
 				}
-
-				// mark self as codeshare
-				codeShareIds[f.Id()] = struct{}{}
+			} else {
+				addLater[parentFid] = append(addLater[parentFid], f)
 			}
+
+			// mark self as codeshare
+			codeShareIds[f.Id()] = struct{}{}
 		}
 	}
 
@@ -0,0 +1,117 @@
+package action
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/ssim"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"time"
+)
+
+type ExportSSIMParams struct {
+	InputBucket string                     `json:"inputBucket"`
+	InputPrefix string                     `json:"inputPrefix"`
+	Airlines    []common.AirlineIdentifier `json:"airlines"`
+	// DateRanges restricts the exported departure dates (local); all dates are exported if empty
+	DateRanges   xtime.LocalDateRanges `json:"dateRanges"`
+	OutputBucket string                `json:"outputBucket"`
+	OutputKey    string                `json:"outputKey"`
+}
+
+type ExportSSIMOutput struct {
+	Carriers int `json:"carriers"`
+	Legs     int `json:"legs"`
+}
+
+type esAction struct {
+	s3c MinimalS3Client
+}
+
+func NewExportSSIMAction(s3c MinimalS3Client) Action[ExportSSIMParams, ExportSSIMOutput] {
+	return &esAction{s3c}
+}
+
+func (a *esAction) Handle(ctx context.Context, params ExportSSIMParams) (ExportSSIMOutput, error) {
+	schedules := make([]*common.FlightSchedule, 0)
+	for _, airline := range params.Airlines {
+		fss, err := a.loadFlightSchedules(ctx, params.InputBucket, params.InputPrefix, airline)
+		if err != nil {
+			return ExportSSIMOutput{}, err
+		}
+
+		for _, fs := range fss {
+			if !params.DateRanges.Empty() {
+				fs.DeleteAll(func(fsv *common.FlightScheduleVariant, d xtime.LocalDate) bool {
+					return !params.DateRanges.Contains(d)
+				})
+			}
+
+			if len(fs.Variants) > 0 {
+				schedules = append(schedules, fs)
+			}
+		}
+	}
+
+	f := ssim.FromFlightSchedules(schedules, xtime.NewLocalDate(time.Now().UTC()))
+
+	var buf bytes.Buffer
+	if err := ssim.Write(&buf, f); err != nil {
+		return ExportSSIMOutput{}, err
+	}
+
+	_, err := a.s3c.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(params.OutputBucket),
+		Key:         aws.String(params.OutputKey),
+		ContentType: aws.String("text/plain"),
+		Body:        bytes.NewReader(buf.Bytes()),
+	})
+
+	if err != nil {
+		return ExportSSIMOutput{}, err
+	}
+
+	output := ExportSSIMOutput{
+		Carriers: len(f.Carriers),
+	}
+
+	for _, c := range f.Carriers {
+		output.Legs += len(c.Legs)
+	}
+
+	return output, nil
+}
+
+func (a *esAction) loadFlightSchedules(ctx context.Context, bucket, prefix string, airline common.AirlineIdentifier) (map[common.FlightNumber]*common.FlightSchedule, error) {
+	resp, err := a.s3c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(prefix + string(airline) + ".json.gz"),
+	})
+
+	if err != nil {
+		if adapt.IsS3NotFound(err) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	defer resp.Body.Close()
+
+	r, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[common.FlightNumber]*common.FlightSchedule
+	if err = json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, r.Close()
+}
@@ -0,0 +1,162 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/ssim"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	FlightSourceLufthansa = "lufthansa"
+	FlightSourceSSIM      = "ssim"
+)
+
+// FlightSource provides the flights departing on a query date (UTC), together with the time the underlying data was last modified.
+// Codeshares are returned as separate flights, referencing each other by the data elements 10 and 50.
+type FlightSource interface {
+	Flights(ctx context.Context, queryDate xtime.LocalDate) (time.Time, []*common.Flight, error)
+}
+
+// lufthansaFlightSource reads the raw responses written by load_flight_schedules, one file per query date
+type lufthansaFlightSource struct {
+	s3c    MinimalS3Client
+	bucket string
+	prefix string
+}
+
+func (s *lufthansaFlightSource) Flights(ctx context.Context, queryDate xtime.LocalDate) (time.Time, []*common.Flight, error) {
+	resp, err := s.s3c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + queryDate.Time(nil).Format("2006/01/02") + ".json"),
+	})
+
+	if err != nil {
+		if adapt.IsS3NotFound(err) {
+			err = nil
+		}
+
+		return time.Time{}, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	var schedules []lufthansa.FlightSchedule
+	if err = json.NewDecoder(resp.Body).Decode(&schedules); err != nil {
+		return time.Time{}, nil, err
+	}
+
+	flights := make([]*common.Flight, 0)
+	for _, fs := range schedules {
+		for _, leg := range fs.Legs {
+			flights = append(flights, &common.Flight{
+				Airline:                      common.AirlineIdentifier(fs.Airline),
+				FlightNumber:                 fs.FlightNumber,
+				Suffix:                       fs.Suffix,
+				DepartureTime:                leg.DepartureTime(fs.PeriodOfOperationUTC.StartDate),
+				DepartureAirport:             leg.Origin,
+				ArrivalTime:                  leg.ArrivalTime(fs.PeriodOfOperationUTC.StartDate),
+				ArrivalAirport:               leg.Destination,
+				ServiceType:                  leg.ServiceType,
+				AircraftOwner:                common.AirlineIdentifier(leg.AircraftOwner),
+				AircraftType:                 leg.AircraftType,
+				AircraftConfigurationVersion: leg.AircraftConfigurationVersion,
+				Registration:                 leg.Registration,
+				DataElements:                 fs.DataElementsForSequence(leg.SequenceNumber),
+			})
+		}
+	}
+
+	return *resp.LastModified, flights, nil
+}
+
+// ssimFlightSource provides the flights of a single SSIM chapter 7 file, which usually covers a whole season
+type ssimFlightSource struct {
+	lastModified time.Time
+	file         *ssim.File
+}
+
+func loadSSIMFlightSource(ctx context.Context, s3c MinimalS3Client, bucket, key string) (*ssimFlightSource, error) {
+	resp, err := s3c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	file, err := ssim.Read(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ssim file %q: %w", key, err)
+	}
+
+	return &ssimFlightSource{
+		lastModified: *resp.LastModified,
+		file:         file,
+	}, nil
+}
+
+func (s *ssimFlightSource) Flights(ctx context.Context, queryDate xtime.LocalDate) (time.Time, []*common.Flight, error) {
+	return s.lastModified, s.file.Flights(queryDate), nil
+}
+
+func newFlightSource(ctx context.Context, s3c MinimalS3Client, params ConvertFlightSchedulesParams) (FlightSource, error) {
+	switch params.Source {
+	case "", FlightSourceLufthansa:
+		return &lufthansaFlightSource{
+			s3c:    s3c,
+			bucket: params.InputBucket,
+			prefix: params.InputPrefix,
+		}, nil
+
+	case FlightSourceSSIM:
+		if params.InputKey == "" {
+			return nil, errors.New("inputKey is required for ssim sources")
+		}
+
+		return loadSSIMFlightSource(ctx, s3c, params.InputBucket, params.InputKey)
+	}
+
+	return nil, fmt.Errorf("unknown flight source %q", params.Source)
+}
+
+// validatingFlightSource quarantines the invalid flights of another source
+type validatingFlightSource struct {
+	s3c         MinimalS3Client
+	source      FlightSource
+	validator   *FlightValidator
+	params      FlightValidationParams
+	quarantined atomic.Int64
+}
+
+func (s *validatingFlightSource) Flights(ctx context.Context, queryDate xtime.LocalDate) (time.Time, []*common.Flight, error) {
+	lastModified, flights, err := s.source.Flights(ctx, queryDate)
+	if err != nil {
+		return lastModified, nil, err
+	}
+
+	valid, invalid := s.validator.Validate(flights)
+	if len(invalid) > 0 {
+		fmt.Printf("quarantining %d of %d flights of %v\n", len(invalid), len(flights), queryDate)
+
+		if err = quarantineFlights(ctx, s.s3c, s.params, queryDate, invalid); err != nil {
+			return lastModified, nil, err
+		}
+
+		s.quarantined.Add(int64(len(invalid)))
+	}
+
+	return lastModified, valid, nil
+}
@@ -25,12 +25,22 @@ type LoadFlightSchedulesOutput struct {
 	Remaining xtime.LocalDateRanges `json:"remaining"`
 }
 
+func (o LoadFlightSchedulesOutput) Continue(params LoadFlightSchedulesParams) (LoadFlightSchedulesParams, bool) {
+	params.DateRanges = o.Remaining
+	return params, !o.Remaining.Empty()
+}
+
+func (o LoadFlightSchedulesOutput) Merge(previous LoadFlightSchedulesOutput) LoadFlightSchedulesOutput {
+	o.Completed = previous.Completed.ExpandAll(o.Completed)
+	return o
+}
+
 type lfsAction struct {
-	s3c *s3.Client
+	s3c MinimalS3Client
 	lhc *lufthansa.Client
 }
 
-func NewLoadFlightSchedulesAction(s3c *s3.Client, lhc *lufthansa.Client) Action[LoadFlightSchedulesParams, LoadFlightSchedulesOutput] {
+func NewLoadFlightSchedulesAction(s3c MinimalS3Client, lhc *lufthansa.Client) Action[LoadFlightSchedulesParams, LoadFlightSchedulesOutput] {
 	return &lfsAction{
 		s3c: s3c,
 		lhc: lhc,
@@ -20,13 +20,13 @@ type LoadMetadataOutput struct {
 type LoadMetadataFn func(c *lufthansa.Client, ctx context.Context) ([]json.RawMessage, error)
 
 type lmAction struct {
-	s3c  *s3.Client
+	s3c  MinimalS3Client
 	lhc  *lufthansa.Client
 	fn   LoadMetadataFn
 	name string
 }
 
-func NewLoadMetadataAction(s3c *s3.Client, lhc *lufthansa.Client, fn LoadMetadataFn, name string) Action[LoadMetadataParams, LoadMetadataOutput] {
+func NewLoadMetadataAction(s3c MinimalS3Client, lhc *lufthansa.Client, fn LoadMetadataFn, name string) Action[LoadMetadataParams, LoadMetadataOutput] {
 	return &lmAction{
 		s3c:  s3c,
 		lhc:  lhc,
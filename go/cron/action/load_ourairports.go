@@ -22,11 +22,11 @@ type LoadOurAirportsDataOutput struct {
 }
 
 type loa struct {
-	s3c        *s3.Client
+	s3c        MinimalS3Client
 	httpClient *http.Client
 }
 
-func NewLoadOurAirportsDataAction(s3c *s3.Client, httpClient *http.Client) Action[LoadOurAirportsDataParams, LoadOurAirportsDataOutput] {
+func NewLoadOurAirportsDataAction(s3c MinimalS3Client, httpClient *http.Client) Action[LoadOurAirportsDataParams, LoadOurAirportsDataOutput] {
 	return &loa{
 		s3c:        s3c,
 		httpClient: cmp.Or(httpClient, http.DefaultClient),
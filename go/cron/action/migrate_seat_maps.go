@@ -0,0 +1,91 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/seatmapstore"
+)
+
+type MigrateSeatMapsParams struct {
+	Bucket string `json:"bucket"`
+}
+
+type MigrateSeatMapsOutput struct {
+	Configurations int `json:"configurations"`
+	Migrated       int `json:"migrated"`
+}
+
+type msmAction struct {
+	s3c MinimalS3Client
+}
+
+// NewMigrateSeatMapsAction stores the unversioned per cabin seat maps of every configuration without any version as its first version
+func NewMigrateSeatMapsAction(s3c MinimalS3Client) Action[MigrateSeatMapsParams, MigrateSeatMapsOutput] {
+	return &msmAction{
+		s3c: s3c,
+	}
+}
+
+func (a *msmAction) Handle(ctx context.Context, params MigrateSeatMapsParams) (MigrateSeatMapsOutput, error) {
+	if params.Bucket == "" {
+		return MigrateSeatMapsOutput{}, errors.New("bucket must be provided")
+	}
+
+	legacy := make(common.Set[seatmapstore.ConfigurationKey])
+	versionedPrefixes := make(common.Set[string])
+
+	paginator := s3.NewListObjectsV2Paginator(a.s3c, &s3.ListObjectsV2Input{
+		Bucket: aws.String(params.Bucket),
+		Prefix: aws.String(seatmapstore.Prefix),
+	})
+
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return MigrateSeatMapsOutput{}, err
+		}
+
+		for _, obj := range resp.Contents {
+			if key, ok := seatmapstore.ParseLegacyKey(*obj.Key); ok {
+				legacy.Add(key)
+			} else if prefix, _, ok := strings.Cut(*obj.Key, "/versions/"); ok {
+				versionedPrefixes.Add(prefix + "/")
+			}
+		}
+	}
+
+	var output MigrateSeatMapsOutput
+	for key := range legacy {
+		if versionedPrefixes.Contains(seatmapstore.ConfigurationPrefix(key)) {
+			continue
+		}
+
+		output.Configurations++
+
+		migrated, err := a.migrate(ctx, params.Bucket, key)
+		if err != nil {
+			return output, err
+		}
+
+		if migrated {
+			output.Migrated++
+		}
+	}
+
+	return output, nil
+}
+
+func (a *msmAction) migrate(ctx context.Context, bucket string, key seatmapstore.ConfigurationKey) (bool, error) {
+	sn, ok, err := seatmapstore.LoadLegacySnapshot(ctx, a.s3c, bucket, key)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return true, adapt.S3PutJson(ctx, a.s3c, bucket, seatmapstore.VersionKey(key, sn.Version), sn)
+}
@@ -0,0 +1,264 @@
+package action
+
+import (
+	"cmp"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/seatmapstore"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type PrefetchSeatMapsParams struct {
+	InputBucket  string                     `json:"inputBucket"`
+	InputPrefix  string                     `json:"inputPrefix"`
+	Airlines     []common.AirlineIdentifier `json:"airlines"`
+	OutputBucket string                     `json:"outputBucket"`
+	// MaxRequests limits the number of requests sent to the Lufthansa API; every configuration requires one request per cabin class
+	MaxRequests int `json:"maxRequests"`
+}
+
+type PrefetchSeatMapsOutput struct {
+	Configurations int `json:"configurations"`
+	Existing       int `json:"existing"`
+	Fetched        int `json:"fetched"`
+	Unavailable    int `json:"unavailable"`
+	Remaining      int `json:"remaining"`
+	Requests       int `json:"requests"`
+}
+
+type seatMapCandidate struct {
+	fn               common.FlightNumber
+	departureAirport string
+	arrivalAirport   string
+	departureDate    xtime.LocalDate
+}
+
+type psmAction struct {
+	s3c MinimalS3Client
+	lhc *lufthansa.Client
+}
+
+func NewPrefetchSeatMapsAction(s3c MinimalS3Client, lhc *lufthansa.Client) Action[PrefetchSeatMapsParams, PrefetchSeatMapsOutput] {
+	return &psmAction{
+		s3c: s3c,
+		lhc: lhc,
+	}
+}
+
+func (a *psmAction) Handle(ctx context.Context, params PrefetchSeatMapsParams) (PrefetchSeatMapsOutput, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	today := xtime.NewLocalDate(time.Now().UTC())
+	candidates := make(map[seatmapstore.ConfigurationKey]seatMapCandidate)
+
+	for _, airline := range params.Airlines {
+		schedules, err := a.loadFlightSchedules(ctx, params.InputBucket, params.InputPrefix, airline)
+		if err != nil {
+			return PrefetchSeatMapsOutput{}, err
+		}
+
+		for fn, fs := range schedules {
+			collectSeatMapCandidates(candidates, fn, fs, today)
+		}
+	}
+
+	return a.prefetch(ctx, params.OutputBucket, candidates, params.MaxRequests)
+}
+
+// prefetch fetches and stores the seat maps of all candidates without any stored seat map. Configurations failing to load are
+// counted as remaining; only an open circuit or a cancelled context stops early, counting all configurations not checked as remaining.
+func (a *psmAction) prefetch(ctx context.Context, bucket string, candidates map[seatmapstore.ConfigurationKey]seatMapCandidate, maxRequests int) (PrefetchSeatMapsOutput, error) {
+	keys := slices.SortedFunc(maps.Keys(candidates), func(a, b seatmapstore.ConfigurationKey) int {
+		return cmp.Or(
+			cmp.Compare(a.AirlineIataCode, b.AirlineIataCode),
+			cmp.Compare(a.AircraftIataCode, b.AircraftIataCode),
+			cmp.Compare(a.AircraftConfigurationVersion, b.AircraftConfigurationVersion),
+		)
+	})
+
+	output := PrefetchSeatMapsOutput{
+		Configurations: len(keys),
+	}
+
+	for i, key := range keys {
+		exists, err := a.exists(ctx, bucket, key)
+		if err != nil {
+			return output, err
+		}
+
+		if exists {
+			output.Existing++
+			continue
+		}
+
+		if output.Requests+len(seatmapstore.CabinClasses) > maxRequests {
+			output.Remaining++
+			continue
+		}
+
+		sn, requests, err := a.fetch(ctx, candidates[key])
+		output.Requests += requests
+		if err != nil {
+			if errors.Is(err, lufthansa.ErrCircuitOpen) || ctx.Err() != nil {
+				fmt.Printf("stopping to prefetch seat maps at %v: %v\n", key, err)
+				output.Remaining += len(keys) - i
+				return output, nil
+			}
+
+			fmt.Printf("failed to prefetch seat maps of %v: %v\n", key, err)
+			output.Remaining++
+			continue
+		}
+
+		if len(sn.CabinClasses) < 1 {
+			output.Unavailable++
+			continue
+		}
+
+		if err = adapt.S3PutJson(ctx, a.s3c, bucket, seatmapstore.VersionKey(key, sn.Version), sn); err != nil {
+			return output, err
+		}
+
+		output.Fetched++
+	}
+
+	return output, nil
+}
+
+// collectSeatMapCandidates keeps the earliest future departure of every configuration operated by the given flight number
+func collectSeatMapCandidates(candidates map[seatmapstore.ConfigurationKey]seatMapCandidate, fn common.FlightNumber, fs *common.FlightSchedule, today xtime.LocalDate) {
+	for _, variant := range fs.Variants {
+		if variant.Data.OperatedAs != fn || variant.Data.ServiceType != "J" || variant.Data.AircraftConfigurationVersion == "" {
+			continue
+		}
+
+		var departureDate xtime.LocalDate
+		for d := range variant.Ranges.Iter {
+			if d > today {
+				departureDate = d
+				break
+			}
+		}
+
+		if departureDate.IsZero() {
+			continue
+		}
+
+		key := seatmapstore.ConfigurationKey{
+			AirlineIataCode:              string(fn.Airline),
+			AircraftIataCode:             variant.Data.AircraftType,
+			AircraftConfigurationVersion: variant.Data.AircraftConfigurationVersion,
+		}
+
+		candidate := seatMapCandidate{
+			fn:               fn,
+			departureAirport: variant.Data.DepartureAirport,
+			arrivalAirport:   variant.Data.ArrivalAirport,
+			departureDate:    departureDate,
+		}
+
+		if existing, ok := candidates[key]; !ok || compareSeatMapCandidates(candidate, existing) < 0 {
+			candidates[key] = candidate
+		}
+	}
+}
+
+func compareSeatMapCandidates(a, b seatMapCandidate) int {
+	return cmp.Or(
+		cmp.Compare(a.departureDate, b.departureDate),
+		cmp.Compare(a.fn.String(), b.fn.String()),
+		cmp.Compare(a.departureAirport, b.departureAirport),
+	)
+}
+
+// exists checks for any stored seat map of the configuration, versioned or legacy
+func (a *psmAction) exists(ctx context.Context, bucket string, key seatmapstore.ConfigurationKey) (bool, error) {
+	resp, err := a.s3c.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		Prefix:  aws.String(seatmapstore.ConfigurationPrefix(key)),
+		MaxKeys: aws.Int32(1),
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(resp.Contents) > 0, nil
+}
+
+func (a *psmAction) fetch(ctx context.Context, candidate seatMapCandidate) (seatmapstore.Snapshot, int, error) {
+	now := seatmapstore.NewVersion(time.Now())
+	sn := seatmapstore.Snapshot{
+		Version:      now,
+		CheckedAt:    now,
+		CabinClasses: make(map[lufthansa.RequestCabinClass]lufthansa.SeatAvailability),
+	}
+
+	requests := 0
+	for _, cabinClass := range seatmapstore.CabinClasses {
+		requests++
+		sm, err := a.lhc.SeatMap(
+			ctx,
+			candidate.fn.String(),
+			candidate.departureAirport,
+			candidate.arrivalAirport,
+			candidate.departureDate,
+			cabinClass,
+		)
+
+		if err != nil {
+			var rse lufthansa.ResponseStatusErr
+			if errors.As(err, &rse) && rse.StatusCode == http.StatusNotFound {
+				continue
+			}
+
+			return seatmapstore.Snapshot{}, requests, err
+		}
+
+		sn.CabinClasses[cabinClass] = sm
+	}
+
+	return sn, requests, nil
+}
+
+func (a *psmAction) loadFlightSchedules(ctx context.Context, bucket, prefix string, airline common.AirlineIdentifier) (map[common.FlightNumber]*common.FlightSchedule, error) {
+	resp, err := a.s3c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(prefix + string(airline) + ".json.gz"),
+	})
+
+	if err != nil {
+		if adapt.IsS3NotFound(err) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	defer resp.Body.Close()
+
+	r, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[common.FlightNumber]*common.FlightSchedule
+	if err = json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, r.Close()
+}
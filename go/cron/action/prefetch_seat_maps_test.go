@@ -0,0 +1,101 @@
+//go:build !lambda
+
+package action
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/local"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/lufthansa/lhtest"
+	"github.com/explore-flights/monorepo/go/common/seatmapstore"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+func testSeatMapCandidates(t *testing.T) map[seatmapstore.ConfigurationKey]seatMapCandidate {
+	candidate := func(fn, departureAirport, arrivalAirport string) seatMapCandidate {
+		number, err := common.ParseFlightNumber(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return seatMapCandidate{
+			fn:               number,
+			departureAirport: departureAirport,
+			arrivalAirport:   arrivalAirport,
+			departureDate:    xtime.NewLocalDateFromParts(2026, time.May, 1),
+		}
+	}
+
+	return map[seatmapstore.ConfigurationKey]seatMapCandidate{
+		{AirlineIataCode: "LH", AircraftIataCode: "320", AircraftConfigurationVersion: "C12M156"}:    candidate("LH100", "FRA", "MUC"),
+		{AirlineIataCode: "LH", AircraftIataCode: "359", AircraftConfigurationVersion: "C48E21M224"}: candidate("LH400", "FRA", "JFK"),
+		{AirlineIataCode: "LH", AircraftIataCode: "359", AircraftConfigurationVersion: "C48E21M225"}: candidate("LH402", "FRA", "EWR"),
+	}
+}
+
+// newTestSeatMapServer serves the business class seat map of LH400 only
+func newTestSeatMapServer(t *testing.T) *lhtest.Server {
+	s := lhtest.NewServer(lhtest.Fixtures{
+		SeatMaps: map[lhtest.SeatMapKey]json.RawMessage{
+			{FlightNumber: "LH400", DepartureAirport: "FRA", ArrivalAirport: "JFK", CabinClass: lufthansa.RequestCabinClassBusiness}: json.RawMessage(`{"SeatAvailabilityResource":{}}`),
+		},
+	})
+
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestPrefetchSeatMapsContinuesAfterFailure(t *testing.T) {
+	s := newTestSeatMapServer(t)
+	s.FailNext(1, http.StatusBadRequest)
+
+	act := &psmAction{
+		s3c: local.NewS3Client(t.TempDir()),
+		lhc: s.Client(),
+	}
+
+	output, err := act.prefetch(t.Context(), "bucket", testSeatMapCandidates(t), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := PrefetchSeatMapsOutput{Configurations: 3, Fetched: 1, Unavailable: 1, Remaining: 1, Requests: 9}
+	if output != expected {
+		t.Fatalf("expected %+v, got %+v", expected, output)
+	}
+
+	exists, err := act.exists(t.Context(), "bucket", seatmapstore.ConfigurationKey{AirlineIataCode: "LH", AircraftIataCode: "359", AircraftConfigurationVersion: "C48E21M224"})
+	if err != nil {
+		t.Fatal(err)
+	} else if !exists {
+		t.Fatal("expected the seat maps of LH400 to be stored")
+	}
+}
+
+func TestPrefetchSeatMapsStopsOnOpenCircuit(t *testing.T) {
+	s := newTestSeatMapServer(t)
+	s.FailNext(1, http.StatusServiceUnavailable)
+
+	act := &psmAction{
+		s3c: local.NewS3Client(t.TempDir()),
+		lhc: s.Client(
+			lufthansa.WithRetryPolicy(lufthansa.RetryPolicy{MaxAttempts: 1}),
+			lufthansa.WithCircuitBreaker(lufthansa.CircuitBreakerPolicy{FailureThreshold: 1, Cooldown: time.Hour}),
+		),
+	}
+
+	output, err := act.prefetch(t.Context(), "bucket", testSeatMapCandidates(t), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := PrefetchSeatMapsOutput{Configurations: 3, Remaining: 3, Requests: 2}
+	if output != expected {
+		t.Fatalf("expected %+v, got %+v", expected, output)
+	}
+}
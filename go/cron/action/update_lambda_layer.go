@@ -26,6 +26,7 @@ type UpdateLambdaLayerParams struct {
 	ParquetPrefix       string `json:"parquetPrefix"`
 	LayerName           string `json:"layerName"`
 	SsmParameterName    string `json:"ssmParameterName"`
+	VersionKey          string `json:"versionKey,omitempty"`
 }
 
 type UpdateLambdaLayerOutput struct {
@@ -45,13 +46,19 @@ type ullActionSsmClient interface {
 	PutParameter(ctx context.Context, params *ssm.PutParameterInput, optFns ...func(*ssm.Options)) (*ssm.PutParameterOutput, error)
 }
 
+type ullActionS3Client interface {
+	adapt.S3Getter
+	adapt.S3Putter
+	adapt.S3Copier
+}
+
 type ullAction struct {
-	s3c     adapt.S3Getter
+	s3c     ullActionS3Client
 	lambdaC ullActionLambdaClient
 	ssmc    ullActionSsmClient
 }
 
-func NewUpdateLambdaLayerAction(s3c adapt.S3Getter, lambdaC ullActionLambdaClient, ssmc ullActionSsmClient) Action[UpdateLambdaLayerParams, UpdateLambdaLayerOutput] {
+func NewUpdateLambdaLayerAction(s3c ullActionS3Client, lambdaC ullActionLambdaClient, ssmc ullActionSsmClient) Action[UpdateLambdaLayerParams, UpdateLambdaLayerOutput] {
 	return &ullAction{
 		s3c:     s3c,
 		lambdaC: lambdaC,
@@ -72,8 +79,34 @@ func (a *ullAction) Handle(ctx context.Context, params UpdateLambdaLayerParams)
 		params.LayerName,
 		params.SsmParameterName,
 	)
+	if err != nil {
+		return output, err
+	}
+
+	// publish the version for running APIs which reload the dataset without a new layer
+	if params.VersionKey != "" {
+		// the base data is read alongside the parquet files of the version, it might be replaced by the next update before the reload
+		_, err = a.s3c.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(params.ParquetBucket),
+			Key:        aws.String(params.ParquetPrefix + "basedata.db"),
+			CopySource: aws.String(params.DatabaseBucket + "/" + params.BaseDataDatabaseKey),
+		})
+		if err != nil {
+			return output, fmt.Errorf("failed to copy base data database: %w", err)
+		}
+
+		_, err = a.s3c.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(params.DatabaseBucket),
+			Key:         aws.String(params.VersionKey),
+			ContentType: aws.String("text/plain"),
+			Body:        strings.NewReader(params.Version),
+		})
+		if err != nil {
+			return output, fmt.Errorf("failed to write version file: %w", err)
+		}
+	}
 
-	return output, err
+	return output, nil
 }
 
 func (a *ullAction) updateLambdaLayer(ctx context.Context, version, databaseBucket, baseDataDatabaseKey, parquetBucket, parquetPrefix, layerName, ssmParameterName string) ([]string, error) {
@@ -0,0 +1,99 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/concurrent"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+type ValidateFlightSchedulesParams struct {
+	// Source, InputBucket, InputPrefix and InputKey are interpreted like by convert_flight_schedules
+	Source       string                 `json:"source,omitempty"`
+	InputBucket  string                 `json:"inputBucket"`
+	InputPrefix  string                 `json:"inputPrefix"`
+	InputKey     string                 `json:"inputKey,omitempty"`
+	DateRanges   xtime.LocalDateRanges  `json:"dateRanges"`
+	Validation   FlightValidationParams `json:"validation"`
+	ReportBucket string                 `json:"reportBucket,omitempty"`
+	ReportKey    string                 `json:"reportKey,omitempty"`
+}
+
+type ValidateFlightSchedulesOutput struct {
+	Report ValidationReport `json:"report"`
+}
+
+type vfsAction struct {
+	s3c MinimalS3Client
+}
+
+func NewValidateFlightSchedulesAction(s3c MinimalS3Client) Action[ValidateFlightSchedulesParams, ValidateFlightSchedulesOutput] {
+	return &vfsAction{s3c}
+}
+
+func (a *vfsAction) Handle(ctx context.Context, params ValidateFlightSchedulesParams) (ValidateFlightSchedulesOutput, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	source, err := newFlightSource(ctx, a.s3c, ConvertFlightSchedulesParams{
+		Source:      params.Source,
+		InputBucket: params.InputBucket,
+		InputPrefix: params.InputPrefix,
+		InputKey:    params.InputKey,
+	})
+
+	if err != nil {
+		return ValidateFlightSchedulesOutput{}, err
+	}
+
+	validator, err := newFlightValidator(ctx, a.s3c, params.Validation)
+	if err != nil {
+		return ValidateFlightSchedulesOutput{}, err
+	}
+
+	sampleSize := params.Validation.sampleSize()
+	wg := concurrent.WorkGroup[xtime.LocalDate, ValidationReport, ValidationReport]{
+		Parallelism: 10,
+		Worker: func(ctx context.Context, queryDate xtime.LocalDate, acc ValidationReport) (ValidationReport, error) {
+			_, flights, err := source.Flights(ctx, queryDate)
+			if err != nil {
+				return acc, err
+			}
+
+			_, invalid := validator.Validate(flights)
+			if len(invalid) > 0 {
+				fmt.Printf("%d of %d flights of %v are invalid\n", len(invalid), len(flights), queryDate)
+			}
+
+			if err = quarantineFlights(ctx, a.s3c, params.Validation, queryDate, invalid); err != nil {
+				return acc, err
+			}
+
+			return acc.add(len(flights), invalid, sampleSize), nil
+		},
+		Combiner: func(ctx context.Context, a, b ValidationReport) (ValidationReport, error) {
+			return a.merge(b, sampleSize), nil
+		},
+		Finisher: func(ctx context.Context, acc ValidationReport) (ValidationReport, error) {
+			return acc, nil
+		},
+	}
+
+	report, err := wg.RunSeq(ctx, params.DateRanges.Iter)
+	if err != nil {
+		return ValidateFlightSchedulesOutput{}, err
+	}
+
+	if report.Rules == nil {
+		report.Rules = make(map[string]*RuleReport)
+	}
+
+	if params.ReportBucket != "" && params.ReportKey != "" {
+		if err = adapt.S3PutJson(ctx, a.s3c, params.ReportBucket, params.ReportKey, report); err != nil {
+			return ValidateFlightSchedulesOutput{}, err
+		}
+	}
+
+	return ValidateFlightSchedulesOutput{report}, nil
+}
@@ -0,0 +1,100 @@
+//go:build !lambda
+
+package action
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/local"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+)
+
+// testRawFlightSchedules contains LH400 arriving before its departure, its codeshare UA9051 and the valid LX1000
+const testRawFlightSchedules = `[
+	{
+		"airline": "LH",
+		"flightNumber": 400,
+		"suffix": "",
+		"periodOfOperationUTC": {"startDate": "01MAY26", "endDate": "01MAY26", "daysOfOperation": "1234567"},
+		"legs": [{
+			"sequenceNumber": 1, "origin": "FRA", "destination": "JFK", "serviceType": "J", "aircraftOwner": "LH", "aircraftType": "359",
+			"aircraftDepartureTimeUTC": 600, "aircraftDepartureTimeVariation": 120, "aircraftArrivalTimeUTC": 540, "aircraftArrivalTimeVariation": -240
+		}],
+		"dataElements": [{"startLegSequenceNumber": 1, "endLegSequenceNumber": 1, "id": 10, "value": "UA9051"}]
+	},
+	{
+		"airline": "UA",
+		"flightNumber": 9051,
+		"suffix": "",
+		"periodOfOperationUTC": {"startDate": "01MAY26", "endDate": "01MAY26", "daysOfOperation": "1234567"},
+		"legs": [{
+			"sequenceNumber": 1, "origin": "FRA", "destination": "JFK", "serviceType": "J", "aircraftOwner": "LH", "aircraftType": "359",
+			"aircraftDepartureTimeUTC": 600, "aircraftDepartureTimeVariation": 120, "aircraftArrivalTimeUTC": 1140, "aircraftArrivalTimeVariation": -240
+		}],
+		"dataElements": [{"startLegSequenceNumber": 1, "endLegSequenceNumber": 1, "id": 50, "value": "LH400"}]
+	},
+	{
+		"airline": "LX",
+		"flightNumber": 1000,
+		"suffix": "",
+		"periodOfOperationUTC": {"startDate": "01MAY26", "endDate": "01MAY26", "daysOfOperation": "1234567"},
+		"legs": [{
+			"sequenceNumber": 1, "origin": "ZRH", "destination": "FRA", "serviceType": "J", "aircraftOwner": "LX", "aircraftType": "221",
+			"aircraftDepartureTimeUTC": 360, "aircraftDepartureTimeVariation": 120, "aircraftArrivalTimeUTC": 420, "aircraftArrivalTimeVariation": 120
+		}],
+		"dataElements": []
+	}
+]`
+
+func TestValidateFlightSchedulesQuarantinesCodeShares(t *testing.T) {
+	s3c := local.NewS3Client(t.TempDir())
+	queryDate := xtime.NewLocalDateFromParts(2026, time.May, 1)
+
+	if err := adapt.S3PutJson(t.Context(), s3c, "bucket", "raw/2026/05/01.json", json.RawMessage(testRawFlightSchedules)); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := NewValidateFlightSchedulesAction(s3c).Handle(t.Context(), ValidateFlightSchedulesParams{
+		InputBucket: "bucket",
+		InputPrefix: "raw/",
+		DateRanges:  xtime.NewLocalDateRanges(xtime.LocalDateRange{queryDate, queryDate}.Iter),
+		Validation: FlightValidationParams{
+			QuarantineBucket: "bucket",
+			QuarantinePrefix: "quarantine/",
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := output.Report
+	if report.Checked != 3 || report.Invalid != 2 {
+		t.Fatalf("expected 2 of 3 flights to be invalid, got %d of %d", report.Invalid, report.Checked)
+	}
+
+	for _, rule := range []string{"duration", RuleInvalidOperatingFlight} {
+		if rr, ok := report.Rules[rule]; !ok || rr.Count != 1 {
+			t.Fatalf("expected a single violation of %q, got %+v", rule, report.Rules)
+		}
+	}
+
+	var quarantined []InvalidFlight
+	if err = adapt.S3GetJson(t.Context(), s3c, "bucket", "quarantine/2026/05/01.json", &quarantined); err != nil {
+		t.Fatal(err)
+	}
+
+	numbers := make([]string, 0, len(quarantined))
+	for _, f := range quarantined {
+		numbers = append(numbers, f.Flight.Number().String())
+	}
+
+	slices.Sort(numbers)
+	if !slices.Equal(numbers, []string{"LH400", "UA9051"}) {
+		t.Fatalf("expected LH400 and its codeshare UA9051 to be quarantined, got %v", numbers)
+	}
+}
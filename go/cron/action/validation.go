@@ -0,0 +1,394 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/xtime"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultValidationSampleSize = 5
+	maxFlightDuration           = time.Hour * 24
+	// RuleInvalidOperatingFlight is reported for codeshares of flights violating any other rule
+	RuleInvalidOperatingFlight = "invalid_operating_flight"
+)
+
+// FlightRule checks a batch of flights (all flights of a single query date)
+type FlightRule interface {
+	Name() string
+	// Violations returns the reason for each flight (by index) violating this rule
+	Violations(flights []*common.Flight) map[int]string
+}
+
+type flightRuleFunc struct {
+	name string
+	fn   func(f *common.Flight) string
+}
+
+// NewFlightRule creates a rule checking each flight on its own. fn returns the reason of the violation, or an empty string.
+func NewFlightRule(name string, fn func(f *common.Flight) string) FlightRule {
+	return flightRuleFunc{name, fn}
+}
+
+func (r flightRuleFunc) Name() string {
+	return r.name
+}
+
+func (r flightRuleFunc) Violations(flights []*common.Flight) map[int]string {
+	result := make(map[int]string)
+	for i, f := range flights {
+		if reason := r.fn(f); reason != "" {
+			result[i] = reason
+		}
+	}
+
+	return result
+}
+
+var airportCodeRgx = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func durationRule() FlightRule {
+	return NewFlightRule("duration", func(f *common.Flight) string {
+		if d := f.Duration(); d <= 0 || d > maxFlightDuration {
+			return fmt.Sprintf("duration %v", d)
+		}
+
+		return ""
+	})
+}
+
+func utcOffsetRule() FlightRule {
+	valid := func(t time.Time) bool {
+		_, offset := t.Zone()
+		return offset >= -12*60*60 && offset <= 14*60*60 && offset%(15*60) == 0
+	}
+
+	return NewFlightRule("utc_offset", func(f *common.Flight) string {
+		if !valid(f.DepartureTime) {
+			return fmt.Sprintf("departure %v", f.DepartureTime.Format(time.RFC3339))
+		} else if !valid(f.ArrivalTime) {
+			return fmt.Sprintf("arrival %v", f.ArrivalTime.Format(time.RFC3339))
+		}
+
+		return ""
+	})
+}
+
+func airportCodeRule() FlightRule {
+	return NewFlightRule("airport_code", func(f *common.Flight) string {
+		if !airportCodeRgx.MatchString(f.DepartureAirport) {
+			return fmt.Sprintf("departure %q", f.DepartureAirport)
+		} else if !airportCodeRgx.MatchString(f.ArrivalAirport) {
+			return fmt.Sprintf("arrival %q", f.ArrivalAirport)
+		} else if f.DepartureAirport == f.ArrivalAirport {
+			return fmt.Sprintf("departure equals arrival %q", f.DepartureAirport)
+		}
+
+		return ""
+	})
+}
+
+// unknownAirportRule is only checked if reference data is available
+func unknownAirportRule(airports common.Set[string]) FlightRule {
+	return NewFlightRule("unknown_airport", func(f *common.Flight) string {
+		if airports == nil {
+			return ""
+		}
+
+		for _, airport := range []string{f.DepartureAirport, f.ArrivalAirport} {
+			if _, ok := airports[airport]; !ok {
+				return airport
+			}
+		}
+
+		return ""
+	})
+}
+
+// unknownAircraftRule is only checked if reference data is available
+func unknownAircraftRule(aircraft common.Set[string]) FlightRule {
+	return NewFlightRule("unknown_aircraft", func(f *common.Flight) string {
+		if aircraft == nil {
+			return ""
+		}
+
+		if _, ok := aircraft[f.AircraftType]; !ok {
+			return f.AircraftType
+		}
+
+		return ""
+	})
+}
+
+type duplicateLegRule struct{}
+
+func (duplicateLegRule) Name() string {
+	return "duplicate_leg"
+}
+
+// Violations reports every occurrence of a flight but the first
+func (duplicateLegRule) Violations(flights []*common.Flight) map[int]string {
+	result := make(map[int]string)
+	seen := make(map[common.FlightId]struct{}, len(flights))
+
+	for i, f := range flights {
+		fid := f.Id()
+		if _, ok := seen[fid]; ok {
+			result[i] = fid.String()
+		} else {
+			seen[fid] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// FlightRules returns all built-in rules. The reference data is optional, rules requiring it are skipped if it is nil.
+func FlightRules(airports, aircraft common.Set[string]) []FlightRule {
+	return []FlightRule{
+		durationRule(),
+		utcOffsetRule(),
+		airportCodeRule(),
+		unknownAirportRule(airports),
+		unknownAircraftRule(aircraft),
+		duplicateLegRule{},
+	}
+}
+
+type RuleViolation struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+type InvalidFlight struct {
+	Flight     *common.Flight  `json:"flight"`
+	Violations []RuleViolation `json:"violations"`
+}
+
+type FlightValidator struct {
+	rules []FlightRule
+}
+
+func NewFlightValidator(rules ...FlightRule) *FlightValidator {
+	return &FlightValidator{rules}
+}
+
+// Validate splits flights into valid and invalid ones. Codeshares (data elements 10 and 50) of operating flights without any valid
+// occurrence are invalid too.
+func (v *FlightValidator) Validate(flights []*common.Flight) ([]*common.Flight, []InvalidFlight) {
+	violations := make(map[int][]RuleViolation)
+	for _, rule := range v.rules {
+		for i, reason := range rule.Violations(flights) {
+			violations[i] = append(violations[i], RuleViolation{rule.Name(), reason})
+		}
+	}
+
+	invalidIds := make(map[common.FlightId]struct{}, len(violations))
+	for i := range violations {
+		invalidIds[flights[i].Id()] = struct{}{}
+	}
+
+	// a duplicate leg only removes the duplicate, the first occurrence remains valid
+	for i, f := range flights {
+		if _, ok := violations[i]; !ok {
+			delete(invalidIds, f.Id())
+		}
+	}
+
+	invalidCodeShares := make(map[common.FlightId]common.FlightNumber)
+	for i := range violations {
+		f := flights[i]
+		if _, ok := invalidIds[f.Id()]; !ok || f.DataElements[codeShareChildId] == "" {
+			continue
+		}
+
+		for _, codeShare := range strings.Split(f.DataElements[codeShareChildId], "/") {
+			if codeShareFn, err := common.ParseFlightNumber(codeShare); err == nil {
+				invalidCodeShares[codeShareFn.Id(f.DepartureLocal())] = f.Number()
+			}
+		}
+	}
+
+	for i, f := range flights {
+		if _, ok := violations[i]; ok {
+			continue
+		}
+
+		if parentFn, ok := invalidCodeShares[f.Id()]; ok {
+			violations[i] = []RuleViolation{{RuleInvalidOperatingFlight, parentFn.String()}}
+		} else if parent := f.DataElements[codeShareParentId]; parent != "" {
+			if parentFn, err := common.ParseFlightNumber(parent); err == nil {
+				if _, ok := invalidIds[parentFn.Id(f.DepartureLocal())]; ok {
+					violations[i] = []RuleViolation{{RuleInvalidOperatingFlight, parentFn.String()}}
+				}
+			}
+		}
+	}
+
+	valid := make([]*common.Flight, 0, len(flights)-len(violations))
+	invalid := make([]InvalidFlight, 0, len(violations))
+	for i, f := range flights {
+		if v, ok := violations[i]; ok {
+			invalid = append(invalid, InvalidFlight{f, v})
+		} else {
+			valid = append(valid, f)
+		}
+	}
+
+	return valid, invalid
+}
+
+type RuleSample struct {
+	Flight string `json:"flight"`
+	Reason string `json:"reason"`
+}
+
+type RuleReport struct {
+	Count   int          `json:"count"`
+	Samples []RuleSample `json:"samples"`
+}
+
+type ValidationReport struct {
+	Checked int                    `json:"checked"`
+	Invalid int                    `json:"invalid"`
+	Rules   map[string]*RuleReport `json:"rules"`
+}
+
+func (r ValidationReport) add(checked int, invalid []InvalidFlight, sampleSize int) ValidationReport {
+	if r.Rules == nil {
+		r.Rules = make(map[string]*RuleReport)
+	}
+
+	r.Checked += checked
+	r.Invalid += len(invalid)
+
+	for _, f := range invalid {
+		for _, violation := range f.Violations {
+			rr, ok := r.Rules[violation.Rule]
+			if !ok {
+				rr = &RuleReport{Samples: make([]RuleSample, 0, sampleSize)}
+				r.Rules[violation.Rule] = rr
+			}
+
+			rr.Count++
+			if len(rr.Samples) < sampleSize {
+				rr.Samples = append(rr.Samples, RuleSample{f.Flight.Id().String(), violation.Reason})
+			}
+		}
+	}
+
+	return r
+}
+
+func (r ValidationReport) merge(other ValidationReport, sampleSize int) ValidationReport {
+	if r.Rules == nil {
+		r.Rules = make(map[string]*RuleReport)
+	}
+
+	r.Checked += other.Checked
+	r.Invalid += other.Invalid
+
+	for _, name := range slices.Sorted(maps.Keys(other.Rules)) {
+		rr, ok := r.Rules[name]
+		if !ok {
+			rr = &RuleReport{Samples: make([]RuleSample, 0, sampleSize)}
+			r.Rules[name] = rr
+		}
+
+		rr.Count += other.Rules[name].Count
+		for _, sample := range other.Rules[name].Samples {
+			if len(rr.Samples) < sampleSize {
+				rr.Samples = append(rr.Samples, sample)
+			}
+		}
+	}
+
+	return r
+}
+
+type FlightValidationParams struct {
+	// Rules restricts the checked rules by name; all rules are checked if empty
+	Rules []string `json:"rules,omitempty"`
+	// ReferencePrefix contains airports.json and aircraft.json as written by load_airports and load_aircraft
+	ReferenceBucket string `json:"referenceBucket,omitempty"`
+	ReferencePrefix string `json:"referencePrefix,omitempty"`
+	// invalid flights are written to QuarantinePrefix, one file per query date
+	QuarantineBucket string `json:"quarantineBucket,omitempty"`
+	QuarantinePrefix string `json:"quarantinePrefix,omitempty"`
+	SampleSize       int    `json:"sampleSize,omitempty"`
+}
+
+func (p FlightValidationParams) sampleSize() int {
+	if p.SampleSize > 0 {
+		return p.SampleSize
+	}
+
+	return defaultValidationSampleSize
+}
+
+func newFlightValidator(ctx context.Context, s3c MinimalS3Client, params FlightValidationParams) (*FlightValidator, error) {
+	var airports, aircraft common.Set[string]
+	if params.ReferenceBucket != "" {
+		var err error
+		airports, err = loadReferenceCodes(ctx, s3c, params.ReferenceBucket, params.ReferencePrefix+"airports.json", func(a lufthansa.Airport) string { return a.Code })
+		if err != nil {
+			return nil, err
+		}
+
+		aircraft, err = loadReferenceCodes(ctx, s3c, params.ReferenceBucket, params.ReferencePrefix+"aircraft.json", func(a lufthansa.Aircraft) string { return a.AircraftCode })
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rules := FlightRules(airports, aircraft)
+	if len(params.Rules) > 0 {
+		for _, name := range params.Rules {
+			if !slices.ContainsFunc(rules, func(r FlightRule) bool { return r.Name() == name }) {
+				return nil, fmt.Errorf("unknown rule %q", name)
+			}
+		}
+
+		rules = slices.DeleteFunc(rules, func(r FlightRule) bool {
+			return !slices.Contains(params.Rules, r.Name())
+		})
+	}
+
+	return NewFlightValidator(rules...), nil
+}
+
+// loadReferenceCodes returns nil if the reference file does not exist
+func loadReferenceCodes[T any](ctx context.Context, s3c MinimalS3Client, bucket, key string, code func(T) string) (common.Set[string], error) {
+	var values []T
+	if err := adapt.S3GetJson(ctx, s3c, bucket, key, &values); err != nil {
+		if adapt.IsS3NotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	result := make(common.Set[string], len(values))
+	for _, v := range values {
+		result[code(v)] = struct{}{}
+	}
+
+	return result, nil
+}
+
+// quarantineFlights writes the invalid flights of a query date, if a quarantine is configured
+func quarantineFlights(ctx context.Context, s3c MinimalS3Client, params FlightValidationParams, queryDate xtime.LocalDate, invalid []InvalidFlight) error {
+	if params.QuarantineBucket == "" || len(invalid) < 1 {
+		return nil
+	}
+
+	return adapt.S3PutJson(ctx, s3c, params.QuarantineBucket, params.QuarantinePrefix+queryDate.Time(nil).Format("2006/01/02")+".json", invalid)
+}
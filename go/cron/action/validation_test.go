@@ -0,0 +1,139 @@
+package action
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common"
+)
+
+var testValidationDeparture = time.Date(2026, time.May, 1, 8, 0, 0, 0, time.FixedZone("", 2*60*60))
+
+func testValidationFlight(t *testing.T, fn, departureAirport, arrivalAirport string, departure time.Time, duration time.Duration, dataElements map[int]string) *common.Flight {
+	number, err := common.ParseFlightNumber(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dataElements == nil {
+		dataElements = make(map[int]string)
+	}
+
+	return &common.Flight{
+		Airline:          number.Airline,
+		FlightNumber:     number.Number,
+		Suffix:           number.Suffix,
+		DepartureTime:    departure,
+		DepartureAirport: departureAirport,
+		ArrivalTime:      departure.Add(duration),
+		ArrivalAirport:   arrivalAirport,
+		AircraftType:     "320",
+		DataElements:     dataElements,
+	}
+}
+
+func TestFlightRules(t *testing.T) {
+	valid := testValidationFlight(t, "LH400", "FRA", "MUC", testValidationDeparture, time.Hour, nil)
+	invalidOffset := testValidationDeparture.In(time.FixedZone("", 10*60))
+	tooFarEast := time.FixedZone("", 15*60*60)
+	nepal := time.FixedZone("", 5*60*60+45*60)
+
+	withAircraft := func(f *common.Flight, aircraftType string) *common.Flight {
+		f.AircraftType = aircraftType
+		return f
+	}
+
+	withArrival := func(f *common.Flight, arrival time.Time) *common.Flight {
+		f.ArrivalTime = arrival
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		rule     FlightRule
+		flights  []*common.Flight
+		expected map[int]string
+	}{
+		{"duration valid", durationRule(), []*common.Flight{valid}, map[int]string{}},
+		{"duration zero", durationRule(), []*common.Flight{testValidationFlight(t, "LH400", "FRA", "MUC", testValidationDeparture, 0, nil)}, map[int]string{0: "duration 0s"}},
+		{"duration negative", durationRule(), []*common.Flight{testValidationFlight(t, "LH400", "FRA", "MUC", testValidationDeparture, -time.Hour, nil)}, map[int]string{0: "duration -1h0m0s"}},
+		{"duration too long", durationRule(), []*common.Flight{testValidationFlight(t, "LH400", "FRA", "JFK", testValidationDeparture, time.Hour*25, nil)}, map[int]string{0: "duration 25h0m0s"}},
+
+		{"utc_offset valid", utcOffsetRule(), []*common.Flight{valid, withArrival(testValidationFlight(t, "LH768", "FRA", "KTM", testValidationDeparture, 0, nil), testValidationDeparture.Add(time.Hour*9).In(nepal))}, map[int]string{}},
+		{"utc_offset departure", utcOffsetRule(), []*common.Flight{testValidationFlight(t, "LH400", "FRA", "MUC", invalidOffset, time.Hour, nil)}, map[int]string{0: "departure " + invalidOffset.Format(time.RFC3339)}},
+		{"utc_offset arrival", utcOffsetRule(), []*common.Flight{withArrival(testValidationFlight(t, "LH400", "FRA", "MUC", testValidationDeparture, 0, nil), testValidationDeparture.Add(time.Hour).In(tooFarEast))}, map[int]string{0: "arrival " + testValidationDeparture.Add(time.Hour).In(tooFarEast).Format(time.RFC3339)}},
+
+		{"airport_code valid", airportCodeRule(), []*common.Flight{valid}, map[int]string{}},
+		{"airport_code departure", airportCodeRule(), []*common.Flight{testValidationFlight(t, "LH400", "FR1", "MUC", testValidationDeparture, time.Hour, nil)}, map[int]string{0: `departure "FR1"`}},
+		{"airport_code arrival", airportCodeRule(), []*common.Flight{testValidationFlight(t, "LH400", "FRA", "muc", testValidationDeparture, time.Hour, nil)}, map[int]string{0: `arrival "muc"`}},
+		{"airport_code same airport", airportCodeRule(), []*common.Flight{testValidationFlight(t, "LH400", "FRA", "FRA", testValidationDeparture, time.Hour, nil)}, map[int]string{0: `departure equals arrival "FRA"`}},
+
+		{"unknown_airport known", unknownAirportRule(common.Set[string]{"FRA": {}, "MUC": {}}), []*common.Flight{valid}, map[int]string{}},
+		{"unknown_airport unknown", unknownAirportRule(common.Set[string]{"FRA": {}, "MUC": {}}), []*common.Flight{valid, testValidationFlight(t, "LH401", "JFK", "FRA", testValidationDeparture, time.Hour*8, nil)}, map[int]string{1: "JFK"}},
+		{"unknown_airport without reference data", unknownAirportRule(nil), []*common.Flight{testValidationFlight(t, "LH401", "JFK", "FRA", testValidationDeparture, time.Hour*8, nil)}, map[int]string{}},
+
+		{"unknown_aircraft known", unknownAircraftRule(common.Set[string]{"320": {}}), []*common.Flight{valid}, map[int]string{}},
+		{"unknown_aircraft unknown", unknownAircraftRule(common.Set[string]{"320": {}}), []*common.Flight{valid, withAircraft(testValidationFlight(t, "LH401", "JFK", "FRA", testValidationDeparture, time.Hour*8, nil), "359")}, map[int]string{1: "359"}},
+		{"unknown_aircraft without reference data", unknownAircraftRule(nil), []*common.Flight{withAircraft(testValidationFlight(t, "LH401", "JFK", "FRA", testValidationDeparture, time.Hour*8, nil), "359")}, map[int]string{}},
+
+		{"duplicate_leg unique", duplicateLegRule{}, []*common.Flight{valid, testValidationFlight(t, "LH400", "MUC", "FRA", testValidationDeparture.Add(time.Hour*2), time.Hour, nil)}, map[int]string{}},
+		{
+			"duplicate_leg duplicate",
+			duplicateLegRule{},
+			[]*common.Flight{valid, testValidationFlight(t, "LH401", "FRA", "MUC", testValidationDeparture, time.Hour, nil), testValidationFlight(t, "LH400", "FRA", "MUC", testValidationDeparture.Add(time.Hour), time.Hour, nil)},
+			map[int]string{2: valid.Id().String()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if violations := tt.rule.Violations(tt.flights); !maps.Equal(violations, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, violations)
+			}
+		})
+	}
+}
+
+func TestFlightValidatorCodeShares(t *testing.T) {
+	flights := []*common.Flight{
+		testValidationFlight(t, "LH400", "FRA", "JFK", testValidationDeparture, -time.Hour, map[int]string{codeShareChildId: "UA9051/AC1234"}),
+		// codeshare referencing the invalid operating flight
+		testValidationFlight(t, "UA9051", "FRA", "JFK", testValidationDeparture, time.Hour*8, map[int]string{codeShareParentId: "LH400"}),
+		// codeshare only listed by the invalid operating flight
+		testValidationFlight(t, "AC1234", "FRA", "JFK", testValidationDeparture, time.Hour*8, nil),
+		testValidationFlight(t, "LH1000", "FRA", "MUC", testValidationDeparture, time.Hour, map[int]string{codeShareChildId: "OS7000"}),
+		testValidationFlight(t, "LH1000", "FRA", "MUC", testValidationDeparture, time.Hour, map[int]string{codeShareChildId: "OS7000"}),
+		// codeshare of an operating flight of which only a duplicate is invalid
+		testValidationFlight(t, "OS7000", "FRA", "MUC", testValidationDeparture, time.Hour, map[int]string{codeShareParentId: "LH1000"}),
+	}
+
+	valid, invalid := NewFlightValidator(FlightRules(nil, nil)...).Validate(flights)
+
+	validNumbers := make([]string, 0, len(valid))
+	for _, f := range valid {
+		validNumbers = append(validNumbers, f.Number().String())
+	}
+
+	if !slices.Equal(validNumbers, []string{"LH1000", "OS7000"}) {
+		t.Fatalf("unexpected valid flights: %v", validNumbers)
+	}
+
+	expected := map[string][]RuleViolation{
+		"LH400":  {{"duration", "duration -1h0m0s"}},
+		"UA9051": {{RuleInvalidOperatingFlight, "LH400"}},
+		"AC1234": {{RuleInvalidOperatingFlight, "LH400"}},
+		"LH1000": {{"duplicate_leg", flights[3].Id().String()}},
+	}
+
+	if len(invalid) != len(expected) {
+		t.Fatalf("expected %d invalid flights, got %d", len(expected), len(invalid))
+	}
+
+	for _, f := range invalid {
+		if violations := expected[f.Flight.Number().String()]; !slices.Equal(f.Violations, violations) {
+			t.Fatalf("%v: expected %v, got %v", f.Flight.Number(), violations, f.Violations)
+		}
+	}
+}
@@ -0,0 +1,538 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/explore-flights/monorepo/go/common/adapt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultWorkflowMaxIterations = 100
+
+// Workflow declares a sequence of steps. The params of each step may reference the input of the workflow
+// and the outputs of previous steps:
+//
+//	"$.input.time"                 is replaced by the referenced value (of any type)
+//	"{$.input.time}/"              references in braces are formatted into the string
+//	"$.steps.load.completed"       output of the step named load
+type Workflow struct {
+	Name  string         `json:"name"`
+	Steps []WorkflowStep `json:"steps"`
+}
+
+type WorkflowStep struct {
+	// Name defaults to the action and must be unique within the workflow
+	Name   string          `json:"name,omitempty"`
+	Action string          `json:"action"`
+	Params json.RawMessage `json:"params,omitempty"`
+	// When skips the step if the referenced value is missing or empty
+	When  string         `json:"when,omitempty"`
+	Retry *WorkflowRetry `json:"retry,omitempty"`
+	// MaxIterations limits how often a step returning a partial output is run again
+	MaxIterations int `json:"maxIterations,omitempty"`
+}
+
+type WorkflowRetry struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts       int     `json:"maxAttempts"`
+	BackoffSeconds    float64 `json:"backoffSeconds"`
+	MaxBackoffSeconds float64 `json:"maxBackoffSeconds,omitempty"`
+}
+
+func (r *WorkflowRetry) backoff(attempt int) time.Duration {
+	d := time.Duration(r.BackoffSeconds*float64(time.Second)) << (attempt - 1)
+	if r.MaxBackoffSeconds > 0 {
+		d = min(d, time.Duration(r.MaxBackoffSeconds*float64(time.Second)))
+	}
+
+	return d
+}
+
+// WorkflowTask runs a single step of a workflow. previous is the output of the last partial run of the same step, if any.
+// done is false if the output is partial and the task has to run again.
+type WorkflowTask interface {
+	Run(ctx context.Context, params, previous json.RawMessage) (output json.RawMessage, done bool, err error)
+}
+
+// PartialOutput is implemented by outputs of actions which may complete only part of their work
+type PartialOutput[IN any, OUT any] interface {
+	// Continue returns the params to continue the work with, or false if the work is complete
+	Continue(params IN) (IN, bool)
+	// Merge combines this output with the output of the previous partial run
+	Merge(previous OUT) OUT
+}
+
+type actionTask[IN any, OUT any] struct {
+	act Action[IN, OUT]
+}
+
+func NewWorkflowTask[IN any, OUT any](act Action[IN, OUT]) WorkflowTask {
+	return actionTask[IN, OUT]{act}
+}
+
+func (t actionTask[IN, OUT]) Run(ctx context.Context, params, previous json.RawMessage) (json.RawMessage, bool, error) {
+	var input IN
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, false, err
+	}
+
+	var prev OUT
+	if len(previous) > 0 {
+		if err := json.Unmarshal(previous, &prev); err != nil {
+			return nil, false, err
+		}
+
+		if p, ok := any(prev).(PartialOutput[IN, OUT]); ok {
+			var more bool
+			if input, more = p.Continue(input); !more {
+				return previous, true, nil
+			}
+		}
+	}
+
+	output, err := t.act.Handle(ctx, input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	done := true
+	if p, ok := any(output).(PartialOutput[IN, OUT]); ok {
+		if len(previous) > 0 {
+			output = p.Merge(prev)
+			p = any(output).(PartialOutput[IN, OUT])
+		}
+
+		_, more := p.Continue(input)
+		done = !more
+	}
+
+	b, err := json.Marshal(output)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return b, done, nil
+}
+
+type RunWorkflowParams struct {
+	// Workflow is loaded from WorkflowBucket and WorkflowKey if not given
+	Workflow       *Workflow       `json:"workflow,omitempty"`
+	WorkflowBucket string          `json:"workflowBucket,omitempty"`
+	WorkflowKey    string          `json:"workflowKey,omitempty"`
+	Input          json.RawMessage `json:"input,omitempty"`
+	// CheckpointBucket and CheckpointKey enable resuming an unfinished run of the same workflow
+	CheckpointBucket string `json:"checkpointBucket,omitempty"`
+	CheckpointKey    string `json:"checkpointKey,omitempty"`
+	// Restart ignores the progress of an unfinished run
+	Restart bool `json:"restart,omitempty"`
+}
+
+type RunWorkflowOutput struct {
+	// Finished is false if the run stopped early because of the deadline and has to be invoked again
+	Finished bool                       `json:"finished"`
+	Resumed  bool                       `json:"resumed"`
+	Steps    map[string]json.RawMessage `json:"steps"`
+}
+
+type workflowCheckpoint struct {
+	Workflow string                     `json:"workflow"`
+	Input    json.RawMessage            `json:"input"`
+	Steps    map[string]json.RawMessage `json:"steps"`
+	// Completed holds the names of all completed or skipped steps
+	Completed []string `json:"completed"`
+	// Partial is the output of the last partial run of the current step
+	Partial  json.RawMessage `json:"partial,omitempty"`
+	Finished bool            `json:"finished"`
+}
+
+type rwAction struct {
+	s3c   MinimalS3Client
+	tasks map[string]WorkflowTask
+}
+
+func NewRunWorkflowAction(s3c MinimalS3Client, tasks map[string]WorkflowTask) Action[RunWorkflowParams, RunWorkflowOutput] {
+	return &rwAction{
+		s3c:   s3c,
+		tasks: tasks,
+	}
+}
+
+func (a *rwAction) Handle(ctx context.Context, params RunWorkflowParams) (RunWorkflowOutput, error) {
+	wf, err := a.loadWorkflow(ctx, params)
+	if err != nil {
+		return RunWorkflowOutput{}, err
+	}
+
+	cp, resumed, err := a.loadCheckpoint(ctx, params, wf)
+	if err != nil {
+		return RunWorkflowOutput{}, err
+	}
+
+	output := RunWorkflowOutput{
+		Resumed: resumed,
+		Steps:   cp.Steps,
+	}
+
+	for _, step := range wf.Steps {
+		if slices.Contains(cp.Completed, step.Name) {
+			continue
+		}
+
+		done, err := a.runStep(ctx, params, step, &cp)
+		if err != nil {
+			return output, fmt.Errorf("workflow %q step %q: %w", wf.Name, step.Name, err)
+		} else if !done {
+			fmt.Printf("workflow %q stopped at step %q, resume to continue\n", wf.Name, step.Name)
+			return output, nil
+		}
+	}
+
+	cp.Finished = true
+	output.Finished = true
+
+	return output, a.saveCheckpoint(ctx, params, cp)
+}
+
+func (a *rwAction) runStep(ctx context.Context, params RunWorkflowParams, step WorkflowStep, cp *workflowCheckpoint) (bool, error) {
+	state, err := cp.state()
+	if err != nil {
+		return false, err
+	}
+
+	if step.When != "" {
+		if v, ok := lookupReference(state, step.When); !ok || isEmptyValue(v) {
+			fmt.Printf("skipping step %q\n", step.Name)
+			cp.Completed = append(cp.Completed, step.Name)
+			return true, a.saveCheckpoint(ctx, params, *cp)
+		}
+	}
+
+	maxIterations := step.MaxIterations
+	if maxIterations < 1 {
+		maxIterations = defaultWorkflowMaxIterations
+	}
+
+	for range maxIterations {
+		state, err = cp.state()
+		if err != nil {
+			return false, err
+		}
+
+		stepParams, err := resolveParams(step.Params, state)
+		if err != nil {
+			return false, err
+		}
+
+		fmt.Printf("running step %q\n", step.Name)
+
+		output, done, err := a.runWithRetry(ctx, a.tasks[step.Action], step.Retry, stepParams, cp.Partial)
+		if err != nil {
+			return false, err
+		}
+
+		cp.Steps[step.Name] = output
+		if done {
+			cp.Partial = nil
+			cp.Completed = append(cp.Completed, step.Name)
+			return true, a.saveCheckpoint(ctx, params, *cp)
+		}
+
+		cp.Partial = output
+		if err = a.saveCheckpoint(ctx, params, *cp); err != nil {
+			return false, err
+		}
+
+		// a partial output under a deadline means the time ran out; the next invocation resumes from the checkpoint
+		if _, ok := ctx.Deadline(); ok {
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("step not completed after %d iterations", maxIterations)
+}
+
+func (a *rwAction) runWithRetry(ctx context.Context, task WorkflowTask, retry *WorkflowRetry, params, previous json.RawMessage) (json.RawMessage, bool, error) {
+	maxAttempts := 1
+	if retry != nil {
+		maxAttempts = max(retry.MaxAttempts, 1)
+	}
+
+	var errs []error
+	for attempt := 1; ; attempt++ {
+		output, done, err := task.Run(ctx, params, previous)
+		if err == nil {
+			return output, done, nil
+		}
+
+		errs = append(errs, err)
+		if attempt >= maxAttempts || ctx.Err() != nil {
+			return nil, false, errors.Join(errs...)
+		}
+
+		fmt.Printf("attempt %d failed, retrying: %v\n", attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, false, errors.Join(append(errs, ctx.Err())...)
+
+		case <-time.After(retry.backoff(attempt)):
+		}
+	}
+}
+
+func (a *rwAction) loadWorkflow(ctx context.Context, params RunWorkflowParams) (Workflow, error) {
+	var wf Workflow
+	if params.Workflow != nil {
+		wf = *params.Workflow
+	} else if params.WorkflowBucket != "" && params.WorkflowKey != "" {
+		if err := adapt.S3GetJson(ctx, a.s3c, params.WorkflowBucket, params.WorkflowKey, &wf); err != nil {
+			return Workflow{}, err
+		}
+	} else {
+		return Workflow{}, errors.New("either workflow or workflowBucket and workflowKey are required")
+	}
+
+	wf.Steps = slices.Clone(wf.Steps)
+	names := make(map[string]struct{}, len(wf.Steps))
+	for i, step := range wf.Steps {
+		if step.Name == "" {
+			step.Name = step.Action
+		}
+
+		if _, ok := names[step.Name]; ok {
+			return Workflow{}, fmt.Errorf("duplicate step name %q", step.Name)
+		} else if _, ok = a.tasks[step.Action]; !ok {
+			return Workflow{}, fmt.Errorf("step %q: unsupported action %q", step.Name, step.Action)
+		}
+
+		if len(step.Params) < 1 {
+			step.Params = json.RawMessage("{}")
+		}
+
+		names[step.Name] = struct{}{}
+		wf.Steps[i] = step
+	}
+
+	return wf, nil
+}
+
+func (a *rwAction) loadCheckpoint(ctx context.Context, params RunWorkflowParams, wf Workflow) (workflowCheckpoint, bool, error) {
+	input := params.Input
+	if len(input) < 1 {
+		input = json.RawMessage("{}")
+	}
+
+	fresh := workflowCheckpoint{
+		Workflow:  wf.Name,
+		Input:     input,
+		Steps:     make(map[string]json.RawMessage),
+		Completed: make([]string, 0),
+	}
+
+	if params.CheckpointBucket == "" || params.CheckpointKey == "" || params.Restart {
+		return fresh, false, nil
+	}
+
+	var cp workflowCheckpoint
+	if err := adapt.S3GetJson(ctx, a.s3c, params.CheckpointBucket, params.CheckpointKey, &cp); err != nil {
+		if adapt.IsS3NotFound(err) {
+			return fresh, false, nil
+		}
+
+		return workflowCheckpoint{}, false, err
+	}
+
+	if cp.Finished || cp.Workflow != wf.Name {
+		return fresh, false, nil
+	}
+
+	if cp.Steps == nil {
+		cp.Steps = make(map[string]json.RawMessage)
+	}
+
+	fmt.Printf("resuming workflow %q after steps %v\n", wf.Name, cp.Completed)
+
+	return cp, true, nil
+}
+
+func (a *rwAction) saveCheckpoint(ctx context.Context, params RunWorkflowParams, cp workflowCheckpoint) error {
+	if params.CheckpointBucket == "" || params.CheckpointKey == "" {
+		return nil
+	}
+
+	return adapt.S3PutJson(ctx, a.s3c, params.CheckpointBucket, params.CheckpointKey, cp)
+}
+
+// state returns the document references are resolved against
+func (cp workflowCheckpoint) state() (any, error) {
+	b, err := json.Marshal(map[string]any{
+		"input": cp.Input,
+		"steps": cp.Steps,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeValue(b)
+}
+
+var referenceInStringRgx = regexp.MustCompile(`\{(\$[^{}]*)}`)
+
+func resolveParams(params json.RawMessage, state any) (json.RawMessage, error) {
+	v, err := decodeValue(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, err = resolveValue(v, state); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+func resolveValue(v any, state any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		if v == "$" || strings.HasPrefix(v, "$.") || strings.HasPrefix(v, "$[") {
+			resolved, ok := lookupReference(state, v)
+			if !ok {
+				return nil, fmt.Errorf("unresolved reference %q", v)
+			}
+
+			return resolved, nil
+		}
+
+		var err error
+		formatted := referenceInStringRgx.ReplaceAllStringFunc(v, func(s string) string {
+			ref := s[1 : len(s)-1]
+			resolved, ok := lookupReference(state, ref)
+			if !ok {
+				err = errors.Join(err, fmt.Errorf("unresolved reference %q", ref))
+				return s
+			}
+
+			if str, ok := resolved.(string); ok {
+				return str
+			}
+
+			b, _ := json.Marshal(resolved)
+			return string(b)
+		})
+
+		return formatted, err
+
+	case []any:
+		for i, e := range v {
+			resolved, err := resolveValue(e, state)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = resolved
+		}
+
+	case map[string]any:
+		for k, e := range v {
+			resolved, err := resolveValue(e, state)
+			if err != nil {
+				return nil, err
+			}
+
+			v[k] = resolved
+		}
+	}
+
+	return v, nil
+}
+
+// lookupReference resolves references of the form $.a.b[0].c
+func lookupReference(state any, ref string) (any, bool) {
+	rest, ok := strings.CutPrefix(ref, "$")
+	if !ok {
+		return nil, false
+	}
+
+	v := state
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+
+			if v, ok = m[rest[1:end+1]]; !ok {
+				return nil, false
+			}
+
+			rest = rest[end+1:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, false
+			}
+
+			idx, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, false
+			}
+
+			s, ok := v.([]any)
+			if !ok || idx < 0 || idx >= len(s) {
+				return nil, false
+			}
+
+			v = s[idx]
+			rest = rest[end+1:]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+func isEmptyValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+
+	case bool:
+		return !v
+
+	case string:
+		return v == ""
+
+	case []any:
+		return len(v) < 1
+
+	case map[string]any:
+		return len(v) < 1
+	}
+
+	return false
+}
+
+func decodeValue(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	return v, dec.Decode(&v)
+}
@@ -0,0 +1,330 @@
+//go:build !lambda
+
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common/local"
+)
+
+func testWorkflowState(t *testing.T) any {
+	state, err := decodeValue([]byte(`{
+		"input": {"time": "2026-01-01T00:00:00Z"},
+		"steps": {
+			"load": {
+				"dates": ["2026-01-01", "2026-01-02"],
+				"summary": {"count": 2, "files": [{"key": "a.json"}, {"key": "b.json"}]},
+				"empty": []
+			}
+		}
+	}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return state
+}
+
+func TestLookupReference(t *testing.T) {
+	state := testWorkflowState(t)
+
+	tests := []struct {
+		ref      string
+		expected any
+		ok       bool
+	}{
+		{"$.input.time", "2026-01-01T00:00:00Z", true},
+		{"$.steps.load.summary.count", json.Number("2"), true},
+		{"$.steps.load.dates[1]", "2026-01-02", true},
+		{"$.steps.load.summary.files[0].key", "a.json", true},
+		{"$.steps.load.empty", []any{}, true},
+		{"$.steps.missing", nil, false},
+		{"$.steps.missing.count", nil, false},
+		{"$.steps.load.summary.missing", nil, false},
+		{"$.steps.load.dates[2]", nil, false},
+		{"$.steps.load.dates[-1]", nil, false},
+		{"$.steps.load.dates[x]", nil, false},
+		{"$.steps.load.dates[0", nil, false},
+		{"$.steps.load.summary[0]", nil, false},
+		{"$.steps.load.dates.first", nil, false},
+		{"steps.load", nil, false},
+		{"$steps", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			v, ok := lookupReference(state, tt.ref)
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v (%v)", tt.ok, ok, v)
+			}
+
+			if ok && !reflect.DeepEqual(v, tt.expected) {
+				t.Fatalf("expected %#v, got %#v", tt.expected, v)
+			}
+		})
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   string
+		expected string
+		err      bool
+	}{
+		{"plain", `{"bucket":"data","count":1}`, `{"bucket":"data","count":1}`, false},
+		{"value", `{"time":"$.input.time"}`, `{"time":"2026-01-01T00:00:00Z"}`, false},
+		{"nested path keeps type", `{"count":"$.steps.load.summary.count"}`, `{"count":2}`, false},
+		{"array index", `{"date":"$.steps.load.dates[0]"}`, `{"date":"2026-01-01"}`, false},
+		{"object", `{"files":"$.steps.load.summary.files"}`, `{"files":[{"key":"a.json"},{"key":"b.json"}]}`, false},
+		{"in array", `{"keys":["$.steps.load.summary.files[1].key","c.json"]}`, `{"keys":["b.json","c.json"]}`, false},
+		{"in string", `{"prefix":"raw/{$.steps.load.dates[1]}/{$.steps.load.summary.count}/"}`, `{"prefix":"raw/2026-01-02/2/"}`, false},
+		{"missing step", `{"time":"$.steps.missing.time"}`, "", true},
+		{"missing index", `{"date":"$.steps.load.dates[5]"}`, "", true},
+		{"missing in string", `{"prefix":"raw/{$.steps.missing.date}/"}`, "", true},
+		{"missing in array", `{"keys":["$.steps.load.summary.files[2].key"]}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := resolveParams(json.RawMessage(tt.params), testWorkflowState(t))
+			if tt.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", resolved)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(resolved) != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, resolved)
+			}
+		})
+	}
+}
+
+type testWorkflowTask struct {
+	params []string
+	fail   int
+}
+
+func (t *testWorkflowTask) Run(ctx context.Context, params, previous json.RawMessage) (json.RawMessage, bool, error) {
+	t.params = append(t.params, string(params))
+	if t.fail > 0 {
+		t.fail--
+		return nil, false, errors.New("failed")
+	}
+
+	return json.RawMessage(`{"value":"` + time.Now().Format(time.RFC3339Nano) + `"}`), true, nil
+}
+
+func TestRunWorkflowResumesFromCheckpoint(t *testing.T) {
+	s3c := local.NewS3Client(t.TempDir())
+	first := &testWorkflowTask{}
+	second := &testWorkflowTask{fail: 1}
+	third := &testWorkflowTask{}
+
+	act := NewRunWorkflowAction(s3c, map[string]WorkflowTask{
+		"first":  first,
+		"second": second,
+		"third":  third,
+	})
+
+	params := RunWorkflowParams{
+		Workflow: &Workflow{
+			Name: "test",
+			Steps: []WorkflowStep{
+				{Action: "first"},
+				{Action: "second", Params: json.RawMessage(`{"value":"$.steps.first.value"}`)},
+				{Action: "third", When: "$.input.third"},
+			},
+		},
+		Input:            json.RawMessage(`{"third":true}`),
+		CheckpointBucket: "bucket",
+		CheckpointKey:    "checkpoint.json",
+	}
+
+	if _, err := act.Handle(t.Context(), params); err == nil {
+		t.Fatal("expected the second step to fail")
+	}
+
+	output, err := act.Handle(t.Context(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !output.Finished || !output.Resumed {
+		t.Fatalf("expected a finished resumed run, got %+v", output)
+	}
+
+	if len(first.params) != 1 || len(second.params) != 2 || len(third.params) != 1 {
+		t.Fatalf("expected the first step to run once, got runs %d/%d/%d", len(first.params), len(second.params), len(third.params))
+	}
+
+	// the resumed step is given the output of the step completed before
+	var firstOutput map[string]string
+	if err = json.Unmarshal(output.Steps["first"], &firstOutput); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"value":"` + firstOutput["value"] + `"}`
+	if second.params[0] != expected || second.params[1] != expected {
+		t.Fatalf("expected params %s, got %v", expected, second.params)
+	}
+
+	// a finished run is not resumed
+	output, err = act.Handle(t.Context(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !output.Finished || output.Resumed || len(first.params) != 2 {
+		t.Fatalf("expected a fresh run, got %+v", output)
+	}
+}
+
+type testPartialParams struct {
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
+}
+
+type testPartialOutput struct {
+	Processed []int `json:"processed"`
+	Next      int   `json:"next"`
+	Total     int   `json:"total"`
+}
+
+func (o testPartialOutput) Continue(params testPartialParams) (testPartialParams, bool) {
+	params.Offset = o.Next
+	return params, o.Next < o.Total
+}
+
+func (o testPartialOutput) Merge(previous testPartialOutput) testPartialOutput {
+	o.Processed = append(slices.Clone(previous.Processed), o.Processed...)
+	return o
+}
+
+// testPartialAction processes two elements per run
+type testPartialAction struct {
+	offsets []int
+}
+
+func (a *testPartialAction) Handle(ctx context.Context, params testPartialParams) (testPartialOutput, error) {
+	a.offsets = append(a.offsets, params.Offset)
+
+	output := testPartialOutput{Total: params.Total}
+	for i := params.Offset; i < min(params.Offset+2, params.Total); i++ {
+		output.Processed = append(output.Processed, i)
+	}
+
+	output.Next = params.Offset + len(output.Processed)
+	return output, nil
+}
+
+func TestWorkflowTaskPartialOutput(t *testing.T) {
+	act := new(testPartialAction)
+	task := NewWorkflowTask[testPartialParams, testPartialOutput](act)
+	params := json.RawMessage(`{"offset":0,"total":5}`)
+
+	var previous json.RawMessage
+	for i := range 3 {
+		output, done, err := task.Run(t.Context(), params, previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if done != (i == 2) {
+			t.Fatalf("run %d: unexpected done=%v", i, done)
+		}
+
+		previous = output
+	}
+
+	var output testPartialOutput
+	if err := json.Unmarshal(previous, &output); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(output.Processed, []int{0, 1, 2, 3, 4}) || output.Next != 5 {
+		t.Fatalf("unexpected merged output: %+v", output)
+	}
+
+	if !slices.Equal(act.offsets, []int{0, 2, 4}) {
+		t.Fatalf("expected to continue at offsets 0, 2 and 4, got %v", act.offsets)
+	}
+
+	// a complete previous output is returned without running the action again
+	output2, done, err := task.Run(t.Context(), params, previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !done || string(output2) != string(previous) || len(act.offsets) != 3 {
+		t.Fatalf("expected the previous output, got %s (done=%v)", output2, done)
+	}
+}
+
+func TestRunWorkflowPartialOutputUnderDeadline(t *testing.T) {
+	act := new(testPartialAction)
+	wf := NewRunWorkflowAction(local.NewS3Client(t.TempDir()), map[string]WorkflowTask{
+		"partial": NewWorkflowTask[testPartialParams, testPartialOutput](act),
+	})
+
+	params := RunWorkflowParams{
+		Workflow: &Workflow{
+			Name:  "test",
+			Steps: []WorkflowStep{{Action: "partial", Params: json.RawMessage(`{"total":5}`)}},
+		},
+		CheckpointBucket: "bucket",
+		CheckpointKey:    "checkpoint.json",
+	}
+
+	// every invocation under a deadline stops after a single partial run
+	var output RunWorkflowOutput
+	invocations := 0
+	for !output.Finished && invocations < 10 {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+		var err error
+		output, err = wf.Handle(ctx, params)
+		cancel()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		invocations++
+	}
+
+	if invocations != 3 {
+		t.Fatalf("expected 3 invocations, got %d", invocations)
+	}
+
+	var merged testPartialOutput
+	if err := json.Unmarshal(output.Steps["partial"], &merged); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(merged.Processed, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("unexpected merged output: %+v", merged)
+	}
+
+	// without deadline, the step runs until complete within a single invocation
+	params.Restart = true
+	act.offsets = nil
+
+	if output, err := wf.Handle(t.Context(), params); err != nil {
+		t.Fatal(err)
+	} else if !output.Finished || !slices.Equal(act.offsets, []int{0, 2, 4}) {
+		t.Fatalf("expected a single finished invocation, got %+v with offsets %v", output, act.offsets)
+	}
+}
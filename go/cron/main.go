@@ -4,21 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
-	"github.com/aws/aws-lambda-go/lambda"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	lambdasdk "github.com/aws/aws-sdk-go-v2/service/lambda"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/explore-flights/monorepo/go/common/adapt"
 	"github.com/explore-flights/monorepo/go/common/lufthansa"
 	"github.com/explore-flights/monorepo/go/cron/action"
-	"golang.org/x/time/rate"
 )
 
 type InputEvent struct {
@@ -26,154 +19,53 @@ type InputEvent struct {
 	Params json.RawMessage `json:"params"`
 }
 
-func main() {
-	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer cancel()
-
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		panic(err)
-	}
-
-	s3c := s3.NewFromConfig(cfg)
-	lambdaC := lambdasdk.NewFromConfig(cfg)
-	ssmc := ssm.NewFromConfig(cfg)
-
-	lhc, err := lufthansaClient(ctx, cfg)
-	if err != nil {
-		panic(err)
-	}
-
-	lambda.StartWithOptions(newHandler(s3c, lambdaC, ssmc, lhc), lambda.WithContext(ctx))
+type handlerS3Client interface {
+	action.MinimalS3Client
+	adapt.S3Copier
+	adapt.S3Deleter
 }
 
-func lufthansaClient(ctx context.Context, cfg aws.Config) (*lufthansa.Client, error) {
-	envNames := []string{"FLIGHTS_SSM_LUFTHANSA_CLIENT_ID", "FLIGHTS_SSM_LUFTHANSA_CLIENT_SECRET"}
-	reqNames := make([]string, 0, len(envNames))
-	lookup := make(map[string]string)
-
-	for _, envName := range envNames {
-		reqName := os.Getenv(envName)
-		if reqName == "" {
-			return nil, fmt.Errorf("env variable %s required", envName)
-		}
-
-		reqNames = append(reqNames, reqName)
-		lookup[reqName] = envName
-	}
-
-	ssmc := ssm.NewFromConfig(cfg)
-	resp, err := ssmc.GetParameters(ctx, &ssm.GetParametersInput{
-		Names:          reqNames,
-		WithDecryption: aws.Bool(true),
-	})
+func newHandler(s3c handlerS3Client, lambdaC *lambdasdk.Client, ssmc *ssm.Client, lhc *lufthansa.Client) func(ctx context.Context, event InputEvent) (json.RawMessage, error) {
+	lfsAction := action.NewLoadFlightSchedulesAction(s3c, lhc)
+	cfsAction := action.NewConvertFlightSchedulesAction(s3c)
 
-	if err != nil {
-		return nil, err
-	} else if len(resp.InvalidParameters) > 0 {
-		return nil, fmt.Errorf("ssm invalid parameters: %v", resp.InvalidParameters)
+	tasks := map[string]action.WorkflowTask{
+		"load_countries":                  action.NewWorkflowTask(action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).CountriesRaw, "countries")),
+		"load_cities":                     action.NewWorkflowTask(action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).CitiesRaw, "cities")),
+		"load_airports":                   action.NewWorkflowTask(action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).AirportsRaw, "airports")),
+		"load_airlines":                   action.NewWorkflowTask(action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).AirlinesRaw, "airlines")),
+		"load_aircraft":                   action.NewWorkflowTask(action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).AircraftRaw, "aircraft")),
+		"load_flight_schedules":           action.NewWorkflowTask(lfsAction),
+		"convert_flight_schedules":        action.NewWorkflowTask(cfsAction),
+		"validate_flight_schedules":       action.NewWorkflowTask(action.NewValidateFlightSchedulesAction(s3c)),
+		"convert_flights":                 action.NewWorkflowTask(action.NewConvertFlightsAction(s3c)),
+		"create_flight_schedules_history": action.NewWorkflowTask(action.CreateFlightSchedulesHistoryAction(s3c)),
+		"cron":                            action.NewWorkflowTask(action.NewCronAction(lfsAction, cfsAction)),
+		"load_our_airports_data":          action.NewWorkflowTask(action.NewLoadOurAirportsDataAction(s3c, nil)),
+		"update_allegris_feed":            action.NewWorkflowTask(action.NewUpdateAllegrisFeedAction(s3c)),
+		"update_metadata":                 action.NewWorkflowTask(action.NewUpdateMetadataAction(s3c)),
+		"invoke_webhook":                  action.NewWorkflowTask(action.NewInvokeWebhookAction(http.DefaultClient)),
+		"delete_s3_data":                  action.NewWorkflowTask(action.NewDeleteS3DataAction(s3c)),
+		"prefetch_seat_maps":              action.NewWorkflowTask(action.NewPrefetchSeatMapsAction(s3c, lhc)),
+		"migrate_seat_maps":               action.NewWorkflowTask(action.NewMigrateSeatMapsAction(s3c)),
+		"export_ssim":                     action.NewWorkflowTask(action.NewExportSSIMAction(s3c)),
 	}
 
-	result := make(map[string]string)
-	for _, p := range resp.Parameters {
-		result[lookup[*p.Name]] = *p.Value
+	// not available when running locally
+	if lambdaC != nil && ssmc != nil {
+		tasks["update_lambda_layer"] = action.NewWorkflowTask(action.NewUpdateLambdaLayerAction(s3c, lambdaC, ssmc))
 	}
 
-	return lufthansa.NewClient(
-		result["FLIGHTS_SSM_LUFTHANSA_CLIENT_ID"],
-		result["FLIGHTS_SSM_LUFTHANSA_CLIENT_SECRET"],
-		lufthansa.WithRateLimiter(rate.NewLimiter(rate.Every(time.Hour)*490, 1)),
-	), nil
-}
-
-func newHandler(s3c *s3.Client, lambdaC *lambdasdk.Client, ssmc *ssm.Client, lhc *lufthansa.Client) func(ctx context.Context, event InputEvent) (json.RawMessage, error) {
-	lCountriesAction := action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).CountriesRaw, "countries")
-	lCitiesAction := action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).CitiesRaw, "cities")
-	lAirportsAction := action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).AirportsRaw, "airports")
-	lAirlinesAction := action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).AirlinesRaw, "airlines")
-	lAircraftAction := action.NewLoadMetadataAction(s3c, lhc, (*lufthansa.Client).AircraftRaw, "aircraft")
-	lfsAction := action.NewLoadFlightSchedulesAction(s3c, lhc)
-	cfsAction := action.NewConvertFlightSchedulesAction(s3c)
-	cfAction := action.NewConvertFlightsAction(s3c)
-	cfshAction := action.CreateFlightSchedulesHistoryAction(s3c)
-	cronAction := action.NewCronAction(lfsAction, cfsAction)
-	loaAction := action.NewLoadOurAirportsDataAction(s3c, nil)
-	uafAction := action.NewUpdateAllegrisFeedAction(s3c)
-	ullAction := action.NewUpdateLambdaLayerAction(s3c, lambdaC, ssmc)
-	umdAction := action.NewUpdateMetadataAction(s3c)
-	invWHAction := action.NewInvokeWebhookAction(http.DefaultClient)
-	ds3DataAction := action.NewDeleteS3DataAction(s3c)
+	// workflows may use every other action as step
+	tasks["run_workflow"] = action.NewWorkflowTask(action.NewRunWorkflowAction(s3c, maps.Clone(tasks)))
 
 	return func(ctx context.Context, event InputEvent) (json.RawMessage, error) {
-		switch event.Action {
-		case "load_countries":
-			return handle(ctx, lCountriesAction, event.Params)
-
-		case "load_cities":
-			return handle(ctx, lCitiesAction, event.Params)
-
-		case "load_airports":
-			return handle(ctx, lAirportsAction, event.Params)
-
-		case "load_airlines":
-			return handle(ctx, lAirlinesAction, event.Params)
-
-		case "load_aircraft":
-			return handle(ctx, lAircraftAction, event.Params)
-
-		case "load_flight_schedules":
-			return handle(ctx, lfsAction, event.Params)
-
-		case "convert_flight_schedules":
-			return handle(ctx, cfsAction, event.Params)
-
-		case "convert_flights":
-			return handle(ctx, cfAction, event.Params)
-
-		case "create_flight_schedules_history":
-			return handle(ctx, cfshAction, event.Params)
-
-		case "cron":
-			return handle(ctx, cronAction, event.Params)
-
-		case "load_our_airports_data":
-			return handle(ctx, loaAction, event.Params)
-
-		case "update_allegris_feed":
-			return handle(ctx, uafAction, event.Params)
-
-		case "update_lambda_layer":
-			return handle(ctx, ullAction, event.Params)
-
-		case "update_metadata":
-			return handle(ctx, umdAction, event.Params)
-
-		case "invoke_webhook":
-			return handle(ctx, invWHAction, event.Params)
-
-		case "delete_s3_data":
-			return handle(ctx, ds3DataAction, event.Params)
+		task, ok := tasks[event.Action]
+		if !ok {
+			return nil, fmt.Errorf("unsupported action: %v", event.Action)
 		}
 
-		return nil, fmt.Errorf("unsupported action: %v", event.Action)
-	}
-}
-
-func handle[IN any, OUT any](ctx context.Context, act action.Action[IN, OUT], params json.RawMessage) (json.RawMessage, error) {
-	var input IN
-	if err := json.Unmarshal(params, &input); err != nil {
-		return nil, err
+		output, _, err := task.Run(ctx, event.Params, nil)
+		return output, err
 	}
-
-	output, err := act.Handle(ctx, input)
-	if err != nil {
-		return nil, err
-	}
-
-	b, err := json.Marshal(output)
-	if err != nil {
-		return nil, err
-	}
-
-	return b, nil
 }
@@ -0,0 +1,81 @@
+//go:build lambda
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	lambdasdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"golang.org/x/time/rate"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	s3c := s3.NewFromConfig(cfg)
+	lambdaC := lambdasdk.NewFromConfig(cfg)
+	ssmc := ssm.NewFromConfig(cfg)
+
+	lhc, err := lufthansaClient(ctx, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	lambda.StartWithOptions(newHandler(s3c, lambdaC, ssmc, lhc), lambda.WithContext(ctx))
+}
+
+func lufthansaClient(ctx context.Context, cfg aws.Config) (*lufthansa.Client, error) {
+	envNames := []string{"FLIGHTS_SSM_LUFTHANSA_CLIENT_ID", "FLIGHTS_SSM_LUFTHANSA_CLIENT_SECRET"}
+	reqNames := make([]string, 0, len(envNames))
+	lookup := make(map[string]string)
+
+	for _, envName := range envNames {
+		reqName := os.Getenv(envName)
+		if reqName == "" {
+			return nil, fmt.Errorf("env variable %s required", envName)
+		}
+
+		reqNames = append(reqNames, reqName)
+		lookup[reqName] = envName
+	}
+
+	ssmc := ssm.NewFromConfig(cfg)
+	resp, err := ssmc.GetParameters(ctx, &ssm.GetParametersInput{
+		Names:          reqNames,
+		WithDecryption: aws.Bool(true),
+	})
+
+	if err != nil {
+		return nil, err
+	} else if len(resp.InvalidParameters) > 0 {
+		return nil, fmt.Errorf("ssm invalid parameters: %v", resp.InvalidParameters)
+	}
+
+	result := make(map[string]string)
+	for _, p := range resp.Parameters {
+		result[lookup[*p.Name]] = *p.Value
+	}
+
+	return lufthansa.NewClient(
+		result["FLIGHTS_SSM_LUFTHANSA_CLIENT_ID"],
+		result["FLIGHTS_SSM_LUFTHANSA_CLIENT_SECRET"],
+		lufthansa.WithRateLimiter(rate.NewLimiter(rate.Every(time.Hour)*490, 1)),
+	), nil
+}
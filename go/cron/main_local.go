@@ -0,0 +1,185 @@
+//go:build !lambda
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/explore-flights/monorepo/go/common/local"
+	"github.com/explore-flights/monorepo/go/common/lufthansa"
+	"github.com/explore-flights/monorepo/go/common/lufthansa/lhtest"
+	"github.com/explore-flights/monorepo/go/cron/action"
+	"golang.org/x/time/rate"
+)
+
+// main runs cron actions or workflows locally against a directory emulating S3:
+//
+//	go run . -action convert_flight_schedules -params params.json
+//	go run . -workflow workflow.json -input input.json -lufthansa-fixtures ./fixtures
+//
+// Lufthansa API requests are served from recorded fixtures (see lhtest.LoadFixtures) if -lufthansa-fixtures is given,
+// otherwise the real API is used with the credentials from FLIGHTS_LUFTHANSA_CLIENT_ID and FLIGHTS_LUFTHANSA_CLIENT_SECRET.
+func main() {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		panic(err)
+	}
+
+	s3BasePath := flag.String("s3", filepath.Join(home, "Downloads", "local_s3"), "directory emulating s3, one subdirectory per bucket")
+	lhFixtures := flag.String("lufthansa-fixtures", "", "directory with recorded lufthansa api responses")
+	actionName := flag.String("action", "", "name of the action to run")
+	paramsFile := flag.String("params", "", "json file with the params of the action (- for stdin)")
+	workflowFile := flag.String("workflow", "", "json file declaring a workflow (see action.Workflow)")
+	inputFile := flag.String("input", "", "json file with the input of the workflow (- for stdin)")
+	checkpoint := flag.String("checkpoint", "", "bucket/key of the workflow checkpoint, enables resuming unfinished runs")
+	restart := flag.Bool("restart", false, "ignore the checkpoint of an unfinished workflow run")
+	timeout := flag.Duration("timeout", 0, "deadline of the action, like the lambda timeout")
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	lhc, closeLhc, err := localLufthansaClient(*lhFixtures)
+	if err != nil {
+		fail(err)
+	}
+	defer closeLhc()
+
+	var event InputEvent
+	switch {
+	case *workflowFile != "" && *actionName == "":
+		event, err = workflowEvent(*workflowFile, *inputFile, *checkpoint, *restart)
+
+	case *actionName != "" && *workflowFile == "":
+		event.Action = *actionName
+		event.Params, err = readJSON(*paramsFile)
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fail(err)
+	}
+
+	if *timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	output, err := newHandler(local.NewS3Client(*s3BasePath), nil, nil, lhc)(ctx, event)
+	if err != nil {
+		fail(fmt.Errorf("%s: %w", event.Action, err))
+	}
+
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, output, "", "  "); err != nil {
+		fail(err)
+	}
+
+	fmt.Println(buf.String())
+}
+
+func workflowEvent(workflowFile, inputFile, checkpoint string, restart bool) (InputEvent, error) {
+	b, err := os.ReadFile(workflowFile)
+	if err != nil {
+		return InputEvent{}, err
+	}
+
+	var wf action.Workflow
+	if err = json.Unmarshal(b, &wf); err != nil {
+		return InputEvent{}, fmt.Errorf("failed to parse workflow %q: %w", workflowFile, err)
+	}
+
+	params := action.RunWorkflowParams{
+		Workflow: &wf,
+		Restart:  restart,
+	}
+
+	if params.Input, err = readJSON(inputFile); err != nil {
+		return InputEvent{}, err
+	}
+
+	if checkpoint != "" {
+		var ok bool
+		if params.CheckpointBucket, params.CheckpointKey, ok = strings.Cut(checkpoint, "/"); !ok {
+			return InputEvent{}, fmt.Errorf("invalid checkpoint %q, expected bucket/key", checkpoint)
+		}
+	}
+
+	if b, err = json.Marshal(params); err != nil {
+		return InputEvent{}, err
+	}
+
+	return InputEvent{Action: "run_workflow", Params: b}, nil
+}
+
+func readJSON(name string) (json.RawMessage, error) {
+	var b []byte
+	var err error
+
+	switch name {
+	case "":
+		return json.RawMessage("{}"), nil
+
+	case "-":
+		b, err = io.ReadAll(os.Stdin)
+
+	default:
+		b, err = os.ReadFile(name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	b = bytes.TrimSpace(b)
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("%q is not valid json", name)
+	}
+
+	return b, nil
+}
+
+func localLufthansaClient(fixturesDir string) (*lufthansa.Client, func(), error) {
+	if fixturesDir != "" {
+		fixtures, err := lhtest.LoadFixtures(os.DirFS(fixturesDir))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		s := lhtest.NewServer(fixtures)
+		return s.Client(), s.Close, nil
+	}
+
+	clientId, clientSecret := os.Getenv("FLIGHTS_LUFTHANSA_CLIENT_ID"), os.Getenv("FLIGHTS_LUFTHANSA_CLIENT_SECRET")
+	if clientId == "" || clientSecret == "" {
+		return nil, nil, errors.New("either -lufthansa-fixtures or FLIGHTS_LUFTHANSA_CLIENT_ID and FLIGHTS_LUFTHANSA_CLIENT_SECRET are required")
+	}
+
+	lhc := lufthansa.NewClient(
+		clientId,
+		clientSecret,
+		lufthansa.WithRateLimiter(rate.NewLimiter(rate.Every(time.Hour)*490, 1)),
+	)
+
+	return lhc, func() {}, nil
+}
+
+func fail(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -154,6 +154,7 @@ export interface ConnectionsSearchRequest {
   excludeFlightNumber?: ReadonlyArray<string>;
   includeAircraft?: ReadonlyArray<string>;
   excludeAircraft?: ReadonlyArray<string>;
+  asOf?: string;
 }
 
 export interface ConnectionFlight {
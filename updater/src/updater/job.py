@@ -269,7 +269,7 @@ COPY (
   FROM flight_variant_history fvh
   LEFT JOIN flight_variants fv
   ON fvh.flight_variant_id = fv.id
-  ORDER BY fvh.airline_iata_code ASC, number_mod_10 ASC
+  ORDER BY fvh.airline_iata_code ASC, number_mod_10 ASC, fvh.departure_date_local ASC
 ) TO '{export_uri}' (
   FORMAT parquet,
   COMPRESSION gzip,